@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// PlayActionRouter 表情动作路由
+type PlayActionRouter struct {
+	BaseRouter
+}
+
+func (*PlayActionRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PlayAction{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PlayAction unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.PlayAction(msg.Action)
+	}
+}
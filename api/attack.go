@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// AttackRouter 普通攻击路由
+type AttackRouter struct {
+	BaseRouter
+}
+
+func (*AttackRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Attack{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Attack unmarshal error ", err)
+		return
+	}
+	// 谁发起的攻击
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	// 找到攻击的player
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.AttackTarget(msg.TargetId)
+	}
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"aoi_mmo_game/core"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/aceld/zinx/znet"
+)
+
+// BaseRouter 业务路由基类，处理请求前刷新连接的活跃时间
+type BaseRouter struct {
+	znet.BaseRouter
+}
+
+func (*BaseRouter) PreHandle(request ziface.IRequest) {
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session != nil {
+		session.KeepAlive()
+	}
+}
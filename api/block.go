@@ -0,0 +1,37 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// BlockPlayerRouter 屏蔽玩家路由
+type BlockPlayerRouter struct {
+	BaseRouter
+}
+
+func (*BlockPlayerRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.BlockPlayer{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("BlockPlayer unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.BlockPlayer(msg.PlayerId, msg.Block)
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// CharacterListRouter 角色列表路由
+type CharacterListRouter struct {
+	BaseRouter
+}
+
+func (*CharacterListRouter) Handle(request ziface.IRequest) {
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session == nil {
+		fmt.Println("session not exist, conn id = ", request.GetConnection().GetConnID())
+		return
+	}
+
+	session.SendCharacterList()
+}
+
+// CreateCharacterRouter 创建角色路由
+type CreateCharacterRouter struct {
+	BaseRouter
+}
+
+func (*CreateCharacterRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.CreateCharacter{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("CreateCharacter unmarshal error ", err)
+		return
+	}
+
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session == nil {
+		fmt.Println("session not exist, conn id = ", request.GetConnection().GetConnID())
+		return
+	}
+
+	session.CreateCharacter(msg.Name, msg.Class)
+}
+
+// SelectCharacterRouter 选择角色路由
+type SelectCharacterRouter struct {
+	BaseRouter
+}
+
+func (*SelectCharacterRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.SelectCharacter{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("SelectCharacter unmarshal error ", err)
+		return
+	}
+
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session == nil {
+		fmt.Println("session not exist, conn id = ", request.GetConnection().GetConnID())
+		return
+	}
+
+	session.SelectCharacter(msg.PlayerId)
+}
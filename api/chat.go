@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// ChatRouter 聊天路由
+type ChatRouter struct {
+	BaseRouter
+}
+
+func (*ChatRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Talk{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Talk unmarshal error ", err)
+		return
+	}
+	// 聊天消息是谁发的
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	// 找到发聊天的player
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.Talk(msg.Channel, msg.TargetPlayerId, msg.Content)
+	}
+}
+
+// WhisperHistoryRouter 私聊记录路由
+type WhisperHistoryRouter struct {
+	BaseRouter
+}
+
+func (*WhisperHistoryRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.GetWhisperHistory{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("GetWhisperHistory unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.SendWhisperHistory(msg.PlayerId)
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// FriendRequestRouter 请求添加好友路由
+type FriendRequestRouter struct {
+	BaseRouter
+}
+
+func (*FriendRequestRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.FriendRequest{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("FriendRequest unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RequestFriend(msg.TargetId)
+	}
+}
+
+// FriendRespondRouter 回应好友请求路由
+type FriendRespondRouter struct {
+	BaseRouter
+}
+
+func (*FriendRespondRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.FriendRespond{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("FriendRespond unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RespondFriend(msg.RequesterId, msg.Accept)
+	}
+}
+
+// RemoveFriendRouter 删除好友路由
+type RemoveFriendRouter struct {
+	BaseRouter
+}
+
+func (*RemoveFriendRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.RemoveFriend{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("RemoveFriend unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RemoveFriend(msg.PlayerId)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// PongRouter 客户端心跳应答路由
+type PongRouter struct {
+	BaseRouter
+}
+
+func (*PongRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Pong{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Pong unmarshal error ", err)
+		return
+	}
+
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session != nil {
+		// 记录往返延迟
+		session.OnPong(msg.Timestamp)
+	}
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// MoveItemRouter 移动物品路由
+type MoveItemRouter struct {
+	BaseRouter
+}
+
+func (*MoveItemRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.MoveItem{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("MoveItem unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.MoveItem(msg.From, msg.To)
+	}
+}
+
+// SplitItemRouter 拆分物品路由
+type SplitItemRouter struct {
+	BaseRouter
+}
+
+func (*SplitItemRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.SplitItem{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("SplitItem unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.SplitItem(msg.From, msg.To, msg.Count)
+	}
+}
+
+// UseItemRouter 使用物品路由
+type UseItemRouter struct {
+	BaseRouter
+}
+
+func (*UseItemRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.UseItem{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("UseItem unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.UseItem(msg.Slot)
+	}
+}
+
+// EquipItemRouter 穿戴装备路由
+type EquipItemRouter struct {
+	BaseRouter
+}
+
+func (*EquipItemRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.EquipItem{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("EquipItem unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.EquipItem(msg.Slot)
+	}
+}
+
+// UnequipItemRouter 卸下装备路由
+type UnequipItemRouter struct {
+	BaseRouter
+}
+
+func (*UnequipItemRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.UnequipItem{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("UnequipItem unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.UnequipItem(msg.EquipSlot)
+	}
+}
+
+// PickupLootRouter 拾取掉落物路由
+type PickupLootRouter struct {
+	BaseRouter
+}
+
+func (*PickupLootRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PickupLoot{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PickupLoot unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.PickupLoot(msg.LootId)
+	}
+}
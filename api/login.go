@@ -0,0 +1,57 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// LoginRouter 账号登录路由
+type LoginRouter struct {
+	BaseRouter
+}
+
+func (*LoginRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Login{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Login unmarshal error ", err)
+		return
+	}
+
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session == nil {
+		fmt.Println("session not exist, conn id = ", request.GetConnection().GetConnID())
+		return
+	}
+
+	session.Login(msg.Account, msg.Password)
+}
+
+// RegisterRouter 注册账号路由
+type RegisterRouter struct {
+	BaseRouter
+}
+
+func (*RegisterRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Register{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Register unmarshal error ", err)
+		return
+	}
+
+	// 找到连接对应的会话
+	session := core.SessionMgrObj.GetSession(request.GetConnection().GetConnID())
+	if session == nil {
+		fmt.Println("session not exist, conn id = ", request.GetConnection().GetConnID())
+		return
+	}
+
+	session.Register(msg.Account, msg.Password)
+}
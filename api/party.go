@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// PartyInviteRouter 邀请组队路由
+type PartyInviteRouter struct {
+	BaseRouter
+}
+
+func (*PartyInviteRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PartyInvite{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PartyInvite unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.InviteToParty(msg.TargetId)
+	}
+}
+
+// PartyRespondRouter 回应组队邀请路由
+type PartyRespondRouter struct {
+	BaseRouter
+}
+
+func (*PartyRespondRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PartyRespond{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PartyRespond unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RespondParty(msg.InviterId, msg.Accept)
+	}
+}
+
+// PartyLeaveRouter 离开队伍路由
+type PartyLeaveRouter struct {
+	BaseRouter
+}
+
+func (*PartyLeaveRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PartyLeave{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PartyLeave unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.LeaveParty()
+	}
+}
+
+// PartyKickRouter 踢出队伍路由
+type PartyKickRouter struct {
+	BaseRouter
+}
+
+func (*PartyKickRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PartyKick{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PartyKick unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.KickFromParty(msg.PlayerId)
+	}
+}
+
+// PartyTransferRouter 转让队长路由
+type PartyTransferRouter struct {
+	BaseRouter
+}
+
+func (*PartyTransferRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.PartyTransfer{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("PartyTransfer unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.TransferPartyLeader(msg.PlayerId)
+	}
+}
@@ -7,13 +7,12 @@ import (
 	"aoi_mmo_game/mmopb"
 
 	"github.com/aceld/zinx/ziface"
-	"github.com/aceld/zinx/znet"
 	"github.com/golang/protobuf/proto"
 )
 
 // PlayerMoveRouter 玩家移动路由
 type PlayerMoveRouter struct {
-	znet.BaseRouter
+	BaseRouter
 }
 
 func (*PlayerMoveRouter) Handle(request ziface.IRequest) {
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// AcceptQuestRouter 接取任务路由
+type AcceptQuestRouter struct {
+	BaseRouter
+}
+
+func (*AcceptQuestRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.AcceptQuest{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("AcceptQuest unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.AcceptQuest(msg.QuestId)
+	}
+}
+
+// AbandonQuestRouter 放弃任务路由
+type AbandonQuestRouter struct {
+	BaseRouter
+}
+
+func (*AbandonQuestRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.AbandonQuest{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("AbandonQuest unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.AbandonQuest(msg.QuestId)
+	}
+}
+
+// TurnInQuestRouter 交付任务路由
+type TurnInQuestRouter struct {
+	BaseRouter
+}
+
+func (*TurnInQuestRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TurnInQuest{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TurnInQuest unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.TurnInQuest(msg.QuestId)
+	}
+}
+
+// TalkNpcRouter 和NPC对话路由
+type TalkNpcRouter struct {
+	BaseRouter
+}
+
+func (*TalkNpcRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TalkNpc{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TalkNpc unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.TalkToNpc(msg.NpcId)
+	}
+}
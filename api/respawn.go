@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// RespawnRouter 复活路由
+type RespawnRouter struct {
+	BaseRouter
+}
+
+func (*RespawnRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.Respawn{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("Respawn unmarshal error ", err)
+		return
+	}
+	// 谁要复活
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	// 找到要复活的player
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.Respawn(msg.PointId)
+	}
+}
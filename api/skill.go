@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// CastSkillRouter 释放技能路由
+type CastSkillRouter struct {
+	BaseRouter
+}
+
+func (*CastSkillRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.CastSkill{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("CastSkill unmarshal error ", err)
+		return
+	}
+	// 谁释放的技能
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	// 找到施法的player
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.CastSkill(msg.SkillId, msg.TargetId, msg.X, msg.Z)
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/core"
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// TradeRequestRouter 发起交易邀请路由
+type TradeRequestRouter struct {
+	BaseRouter
+}
+
+func (*TradeRequestRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeRequest{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeRequest unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RequestTrade(msg.TargetId)
+	}
+}
+
+// TradeRespondRouter 回应交易邀请路由
+type TradeRespondRouter struct {
+	BaseRouter
+}
+
+func (*TradeRespondRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeRespond{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeRespond unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.RespondTrade(msg.InviterId, msg.Accept)
+	}
+}
+
+// TradeOfferRouter 设置交易内容路由
+type TradeOfferRouter struct {
+	BaseRouter
+}
+
+func (*TradeOfferRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeOffer{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeOffer unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		items := make([]*core.ItemStack, 0, len(msg.Items))
+		for _, item := range msg.Items {
+			items = append(items, &core.ItemStack{ItemId: item.ItemId, Count: item.Count})
+		}
+		player.SetTradeOffer(items, msg.Gold)
+	}
+}
+
+// TradeLockRouter 锁定交易路由
+type TradeLockRouter struct {
+	BaseRouter
+}
+
+func (*TradeLockRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeLock{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeLock unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.LockTrade()
+	}
+}
+
+// TradeConfirmRouter 确认交易路由
+type TradeConfirmRouter struct {
+	BaseRouter
+}
+
+func (*TradeConfirmRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeConfirm{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeConfirm unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.ConfirmTrade()
+	}
+}
+
+// TradeCancelRouter 取消交易路由
+type TradeCancelRouter struct {
+	BaseRouter
+}
+
+func (*TradeCancelRouter) Handle(request ziface.IRequest) {
+	msg := &mmopb.TradeCancel{}
+	err := proto.Unmarshal(request.GetData(), msg)
+	if err != nil {
+		fmt.Println("TradeCancel unmarshal error ", err)
+		return
+	}
+	playerId, err := request.GetConnection().GetProperty("playerId")
+	if err != nil {
+		fmt.Println("GetProperty playerId error ", err)
+		request.GetConnection().Stop()
+		return
+	}
+
+	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
+
+	if player != nil {
+		player.CancelTrade(mmopb.TradeCloseReason_Trade_Cancelled)
+	}
+}
@@ -61,21 +61,93 @@ func sendMessage(conn net.Conn) {
 			handleSingleTalk(conn)
 		case 3:
 			handleServerTalk(conn)
+		case 4:
+			handleLogin(conn, mmopb.CSMsgIdLogin)
+		case 5:
+			handleCharacterList(conn)
+		case 6:
+			handleCreateCharacter(conn)
+		case 7:
+			handleSelectCharacter(conn)
+		case 8:
+			handleAttack(conn)
+		case 9:
+			handleCastSkill(conn)
+		case 10:
+			handleRespawn(conn)
+		case 11:
+			handleMoveItem(conn)
+		case 12:
+			handleSplitItem(conn)
+		case 13:
+			handleUseItem(conn)
+		case 14:
+			handleEquipItem(conn)
+		case 15:
+			handleUnequipItem(conn)
+		case 16:
+			handlePickupLoot(conn)
+		case 17:
+			handleTradeRequest(conn)
+		case 18:
+			handleTradeRespond(conn)
+		case 19:
+			handleTradeOffer(conn)
+		case 20:
+			writeMessage(conn, mmopb.CSMsgIdTradeLock, &mmopb.TradeLock{})
+		case 21:
+			writeMessage(conn, mmopb.CSMsgIdTradeConfirm, &mmopb.TradeConfirm{})
+		case 22:
+			writeMessage(conn, mmopb.CSMsgIdTradeCancel, &mmopb.TradeCancel{})
+		case 23:
+			handleQuest(conn, mmopb.CSMsgIdAcceptQuest)
+		case 24:
+			handleQuest(conn, mmopb.CSMsgIdAbandonQuest)
+		case 25:
+			handleQuest(conn, mmopb.CSMsgIdTurnInQuest)
+		case 26:
+			handleTalkNpc(conn)
+		case 27:
+			handleBlockPlayer(conn)
+		case 28:
+			handleWhisperHistory(conn)
+		case 29:
+			handlePlayAction(conn)
+		case 30:
+			handlePartyPlayer(conn, mmopb.CSMsgIdPartyInvite)
+		case 31:
+			handlePartyRespond(conn)
+		case 32:
+			writeMessage(conn, mmopb.CSMsgIdPartyLeave, &mmopb.PartyLeave{})
+		case 33:
+			handlePartyPlayer(conn, mmopb.CSMsgIdPartyKick)
+		case 34:
+			handlePartyPlayer(conn, mmopb.CSMsgIdPartyTransfer)
+		case 35:
+			handleFriendPlayer(conn, mmopb.CSMsgIdFriendRequest)
+		case 36:
+			handleFriendRespond(conn)
+		case 37:
+			handleFriendPlayer(conn, mmopb.CSMsgIdRemoveFriend)
+		case 38:
+			handleLogin(conn, mmopb.CSMsgIdRegister)
 		}
 	}
 }
 
 func handleServerTalk(conn net.Conn) {
-	fmt.Println("请输入聊天内容")
+	fmt.Println("请输入频道（0世界 1附近 2场景 3队伍 4公会）、聊天内容（参数用空格分割）")
+	var channel int32
 	var content string
-	scanf, err := fmt.Scanf("%s", &content)
-	if err != nil || scanf != 1 || len(content) == 0 {
+	scanf, err := fmt.Scanf("%d %s", &channel, &content)
+	if err != nil || scanf != 2 || len(content) == 0 || channel < 0 {
 		log.Println("handleServerTalk--输入错误或参数个数不足!", err)
 		return
 	}
 
 	request := &mmopb.Talk{
 		Content: content,
+		Channel: mmopb.ChatChannel(channel),
 	}
 
 	// 发封包message消息
@@ -107,6 +179,7 @@ func handleSingleTalk(conn net.Conn) {
 	request := &mmopb.Talk{
 		TargetPlayerId: playerId,
 		Content:        content,
+		Channel:        mmopb.ChatChannel_Chat_Channel_Whisper,
 	}
 
 	// 发封包message消息
@@ -160,14 +233,478 @@ func handleMove(conn net.Conn) {
 	}
 }
 
+func handlePing(conn net.Conn, ping *mmopb.Ping) {
+	request := &mmopb.Pong{
+		Timestamp: ping.Timestamp,
+	}
+	writeMessage(conn, mmopb.CSMsgIdPong, request)
+}
+
+func handleLogin(conn net.Conn, msgId uint32) {
+	fmt.Println("请输入账号、密码（参数用空格分割）")
+	var account, password string
+	scanf, err := fmt.Scanf("%s %s", &account, &password)
+	if err != nil || scanf != 2 || len(account) == 0 {
+		log.Println("handleLogin--输入错误或参数个数不足!", err)
+		return
+	}
+
+	var request proto.Message
+	if msgId == mmopb.CSMsgIdRegister {
+		request = &mmopb.Register{Account: account, Password: password}
+	} else {
+		request = &mmopb.Login{Account: account, Password: password}
+	}
+	writeMessage(conn, msgId, request)
+}
+
+func handleCharacterList(conn net.Conn) {
+	writeMessage(conn, mmopb.CSMsgIdCharacterList, &mmopb.CharacterList{})
+}
+
+func handleCreateCharacter(conn net.Conn) {
+	fmt.Println("请输入角色名、职业(1战士 2法师 3弓手 4牧师)（参数用空格分割）")
+	var name string
+	var class int32
+	scanf, err := fmt.Scanf("%s %d", &name, &class)
+	if err != nil || scanf != 2 || len(name) == 0 {
+		log.Println("handleCreateCharacter--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.CreateCharacter{
+		Name:  name,
+		Class: mmopb.PlayerClass(class),
+	}
+	writeMessage(conn, mmopb.CSMsgIdCreateCharacter, request)
+}
+
+func handleSelectCharacter(conn net.Conn) {
+	fmt.Println("请输入角色id")
+	var playerId int32
+	scanf, err := fmt.Scanf("%d", &playerId)
+	if err != nil || scanf != 1 || playerId <= 0 {
+		log.Println("handleSelectCharacter--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.SelectCharacter{
+		PlayerId: playerId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdSelectCharacter, request)
+}
+
+func handleAttack(conn net.Conn) {
+	fmt.Println("请输入目标玩家或怪物id")
+	var targetId int32
+	scanf, err := fmt.Scanf("%d", &targetId)
+	if err != nil || scanf != 1 || targetId <= 0 {
+		log.Println("handleAttack--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.Attack{
+		TargetId: targetId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdAttack, request)
+}
+
+func handleCastSkill(conn net.Conn) {
+	fmt.Println("请输入技能id、目标id、目标点x、目标点y（参数用空格分割，没有的填0）")
+	var skillId int32
+	var targetId int32
+	var x int32
+	var z int32
+	scanf, err := fmt.Scanf("%d %d %d %d", &skillId, &targetId, &x, &z)
+	if err != nil || scanf != 4 || skillId <= 0 {
+		log.Println("handleCastSkill--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.CastSkill{
+		SkillId:  skillId,
+		TargetId: targetId,
+		X:        float32(x),
+		Z:        float32(z),
+	}
+	writeMessage(conn, mmopb.CSMsgIdCastSkill, request)
+}
+
+func handleRespawn(conn net.Conn) {
+	fmt.Println("请输入复活点id（0为最近的复活点）")
+	var pointId int32
+	scanf, err := fmt.Scanf("%d", &pointId)
+	if err != nil || scanf != 1 || pointId < 0 {
+		log.Println("handleRespawn--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.Respawn{
+		PointId: pointId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdRespawn, request)
+}
+
+func handleMoveItem(conn net.Conn) {
+	fmt.Println("请输入原格子、目标格子（参数用空格分割）")
+	var from int32
+	var to int32
+	scanf, err := fmt.Scanf("%d %d", &from, &to)
+	if err != nil || scanf != 2 {
+		log.Println("handleMoveItem--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.MoveItem{
+		From: from,
+		To:   to,
+	}
+	writeMessage(conn, mmopb.CSMsgIdMoveItem, request)
+}
+
+func handleSplitItem(conn net.Conn) {
+	fmt.Println("请输入原格子、目标格子、拆分数量（参数用空格分割）")
+	var from int32
+	var to int32
+	var count int32
+	scanf, err := fmt.Scanf("%d %d %d", &from, &to, &count)
+	if err != nil || scanf != 3 || count <= 0 {
+		log.Println("handleSplitItem--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.SplitItem{
+		From:  from,
+		To:    to,
+		Count: count,
+	}
+	writeMessage(conn, mmopb.CSMsgIdSplitItem, request)
+}
+
+func handleUseItem(conn net.Conn) {
+	fmt.Println("请输入物品所在的格子")
+	var slot int32
+	scanf, err := fmt.Scanf("%d", &slot)
+	if err != nil || scanf != 1 {
+		log.Println("handleUseItem--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.UseItem{
+		Slot: slot,
+	}
+	writeMessage(conn, mmopb.CSMsgIdUseItem, request)
+}
+
+func handleEquipItem(conn net.Conn) {
+	fmt.Println("请输入装备所在的格子")
+	var slot int32
+	scanf, err := fmt.Scanf("%d", &slot)
+	if err != nil || scanf != 1 {
+		log.Println("handleEquipItem--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.EquipItem{
+		Slot: slot,
+	}
+	writeMessage(conn, mmopb.CSMsgIdEquipItem, request)
+}
+
+func handleUnequipItem(conn net.Conn) {
+	fmt.Println("请输入装备部位（1武器 2头部 3胸甲 4腿部 5鞋子 6饰品）")
+	var equipSlot int32
+	scanf, err := fmt.Scanf("%d", &equipSlot)
+	if err != nil || scanf != 1 || equipSlot <= 0 {
+		log.Println("handleUnequipItem--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.UnequipItem{
+		EquipSlot: mmopb.EquipSlot(equipSlot),
+	}
+	writeMessage(conn, mmopb.CSMsgIdUnequipItem, request)
+}
+
+func handlePickupLoot(conn net.Conn) {
+	fmt.Println("请输入掉落物id")
+	var lootId int32
+	scanf, err := fmt.Scanf("%d", &lootId)
+	if err != nil || scanf != 1 || lootId <= 0 {
+		log.Println("handlePickupLoot--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.PickupLoot{
+		LootId: lootId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdPickupLoot, request)
+}
+
+func handleTradeRequest(conn net.Conn) {
+	fmt.Println("请输入交易对象的玩家id")
+	var targetId int32
+	scanf, err := fmt.Scanf("%d", &targetId)
+	if err != nil || scanf != 1 || targetId <= 0 {
+		log.Println("handleTradeRequest--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.TradeRequest{
+		TargetId: targetId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdTradeRequest, request)
+}
+
+func handleTradeRespond(conn net.Conn) {
+	fmt.Println("请输入邀请者的玩家id和是否接受（1接受 0拒绝），以空格分隔")
+	var inviterId, accept int32
+	scanf, err := fmt.Scanf("%d %d", &inviterId, &accept)
+	if err != nil || scanf != 2 || inviterId <= 0 {
+		log.Println("handleTradeRespond--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.TradeRespond{
+		InviterId: inviterId,
+		Accept:    accept == 1,
+	}
+	writeMessage(conn, mmopb.CSMsgIdTradeRespond, request)
+}
+
+func handleTradeOffer(conn net.Conn) {
+	fmt.Println("请输入交出的金币、物品id和数量（物品id为0表示不交出物品），以空格分隔")
+	var gold int64
+	var itemId, count int32
+	scanf, err := fmt.Scanf("%d %d %d", &gold, &itemId, &count)
+	if err != nil || scanf != 3 || gold < 0 {
+		log.Println("handleTradeOffer--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.TradeOffer{
+		Gold: gold,
+	}
+	if itemId > 0 {
+		request.Items = append(request.Items, &mmopb.ItemCount{ItemId: itemId, Count: count})
+	}
+	writeMessage(conn, mmopb.CSMsgIdTradeOffer, request)
+}
+
+// handleQuest 接取、放弃和交付任务都只需要任务id
+func handleQuest(conn net.Conn, msgId uint32) {
+	fmt.Println("请输入任务id")
+	var questId int32
+	scanf, err := fmt.Scanf("%d", &questId)
+	if err != nil || scanf != 1 || questId <= 0 {
+		log.Println("handleQuest--输入错误或参数个数不足!", err)
+		return
+	}
+
+	var request proto.Message
+	switch msgId {
+	case mmopb.CSMsgIdAcceptQuest:
+		request = &mmopb.AcceptQuest{QuestId: questId}
+	case mmopb.CSMsgIdAbandonQuest:
+		request = &mmopb.AbandonQuest{QuestId: questId}
+	default:
+		request = &mmopb.TurnInQuest{QuestId: questId}
+	}
+	writeMessage(conn, msgId, request)
+}
+
+func handleTalkNpc(conn net.Conn) {
+	fmt.Println("请输入NPC id")
+	var npcId int32
+	scanf, err := fmt.Scanf("%d", &npcId)
+	if err != nil || scanf != 1 || npcId <= 0 {
+		log.Println("handleTalkNpc--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.TalkNpc{
+		NpcId: npcId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdTalkNpc, request)
+}
+
+func handleBlockPlayer(conn net.Conn) {
+	fmt.Println("请输入玩家id、是否屏蔽（1屏蔽 0取消，参数用空格分割）")
+	var playerId, block int32
+	scanf, err := fmt.Scanf("%d %d", &playerId, &block)
+	if err != nil || scanf != 2 || playerId <= 0 {
+		log.Println("handleBlockPlayer--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.BlockPlayer{
+		PlayerId: playerId,
+		Block:    block == 1,
+	}
+	writeMessage(conn, mmopb.CSMsgIdBlockPlayer, request)
+}
+
+func handleWhisperHistory(conn net.Conn) {
+	fmt.Println("请输入玩家id")
+	var playerId int32
+	scanf, err := fmt.Scanf("%d", &playerId)
+	if err != nil || scanf != 1 || playerId <= 0 {
+		log.Println("handleWhisperHistory--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.GetWhisperHistory{
+		PlayerId: playerId,
+	}
+	writeMessage(conn, mmopb.CSMsgIdWhisperHistory, request)
+}
+
+func handlePlayAction(conn net.Conn) {
+	fmt.Println("请输入动作id（0停止循环动作）")
+	var action int32
+	scanf, err := fmt.Scanf("%d", &action)
+	if err != nil || scanf != 1 || action < 0 {
+		log.Println("handlePlayAction--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.PlayAction{
+		Action: action,
+	}
+	writeMessage(conn, mmopb.CSMsgIdPlayAction, request)
+}
+
+func handlePartyPlayer(conn net.Conn, msgId uint32) {
+	fmt.Println("请输入玩家id")
+	var playerId int32
+	scanf, err := fmt.Scanf("%d", &playerId)
+	if err != nil || scanf != 1 || playerId <= 0 {
+		log.Println("handlePartyPlayer--输入错误或参数个数不足!", err)
+		return
+	}
+
+	var request proto.Message
+	switch msgId {
+	case mmopb.CSMsgIdPartyInvite:
+		request = &mmopb.PartyInvite{TargetId: playerId}
+	case mmopb.CSMsgIdPartyKick:
+		request = &mmopb.PartyKick{PlayerId: playerId}
+	default:
+		request = &mmopb.PartyTransfer{PlayerId: playerId}
+	}
+	writeMessage(conn, msgId, request)
+}
+
+func handlePartyRespond(conn net.Conn) {
+	fmt.Println("请输入邀请者的玩家id和是否接受（1接受 0拒绝），以空格分隔")
+	var inviterId, accept int32
+	scanf, err := fmt.Scanf("%d %d", &inviterId, &accept)
+	if err != nil || scanf != 2 || inviterId <= 0 {
+		log.Println("handlePartyRespond--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.PartyRespond{
+		InviterId: inviterId,
+		Accept:    accept == 1,
+	}
+	writeMessage(conn, mmopb.CSMsgIdPartyRespond, request)
+}
+
+func handleFriendPlayer(conn net.Conn, msgId uint32) {
+	fmt.Println("请输入玩家id")
+	var playerId int32
+	scanf, err := fmt.Scanf("%d", &playerId)
+	if err != nil || scanf != 1 || playerId <= 0 {
+		log.Println("handleFriendPlayer--输入错误或参数个数不足!", err)
+		return
+	}
+
+	var request proto.Message
+	if msgId == mmopb.CSMsgIdFriendRequest {
+		request = &mmopb.FriendRequest{TargetId: playerId}
+	} else {
+		request = &mmopb.RemoveFriend{PlayerId: playerId}
+	}
+	writeMessage(conn, msgId, request)
+}
+
+func handleFriendRespond(conn net.Conn) {
+	fmt.Println("请输入请求者的玩家id和是否接受（1接受 0拒绝），以空格分隔")
+	var requesterId, accept int32
+	scanf, err := fmt.Scanf("%d %d", &requesterId, &accept)
+	if err != nil || scanf != 2 || requesterId <= 0 {
+		log.Println("handleFriendRespond--输入错误或参数个数不足!", err)
+		return
+	}
+
+	request := &mmopb.FriendRespond{
+		RequesterId: requesterId,
+		Accept:      accept == 1,
+	}
+	writeMessage(conn, mmopb.CSMsgIdFriendRespond, request)
+}
+
+// writeMessage 封包并发送消息
+func writeMessage(conn net.Conn, msgId uint32, request proto.Message) {
+	dp := znet.NewDataPack()
+	data, err := proto.Marshal(request)
+	if err != nil {
+		log.Println("writeMessage--proto Marshal错误!", err)
+		return
+	}
+
+	msg, _ := dp.Pack(znet.NewMsgPackage(msgId, data))
+	_, err = conn.Write(msg)
+	if err != nil {
+		log.Println("writeMessage--conn写入数据错误!", err)
+		return
+	}
+}
+
 var orderMap = map[uint]string{
-	0: "退出",
-	1: "移动",
-	2: "个人聊天",
-	3: "全服聊天",
+	0:  "退出",
+	1:  "移动",
+	2:  "个人聊天",
+	3:  "频道聊天",
+	4:  "登录",
+	5:  "角色列表",
+	6:  "创建角色",
+	7:  "选择角色",
+	8:  "攻击",
+	9:  "释放技能",
+	10: "复活",
+	11: "移动物品",
+	12: "拆分物品",
+	13: "使用物品",
+	14: "穿戴装备",
+	15: "卸下装备",
+	16: "拾取掉落物",
+	17: "邀请交易",
+	18: "回应交易邀请",
+	19: "设置交易内容",
+	20: "锁定交易",
+	21: "确认交易",
+	22: "取消交易",
+	23: "接取任务",
+	24: "放弃任务",
+	25: "交付任务",
+	26: "和NPC对话",
+	27: "屏蔽玩家",
+	28: "私聊记录",
+	29: "表情动作",
+	30: "邀请组队",
+	31: "回应组队邀请",
+	32: "离开队伍",
+	33: "踢出队伍",
+	34: "转让队长",
+	35: "添加好友",
+	36: "回应好友请求",
+	37: "删除好友",
+	38: "注册账号",
 }
 
-var orderSlice = []uint{0, 1, 2, 3}
+var orderSlice = []uint{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38}
 
 func showMenu() {
 	fmt.Println("客户端功能菜单：")
@@ -218,6 +755,12 @@ func receiveMessage(conn net.Conn) {
 				return
 			}
 
+			// 心跳探测直接应答，不打印
+			if msg.Id == mmopb.SCMsgIdPing {
+				handlePing(conn, pbmsg.(*mmopb.Ping))
+				continue
+			}
+
 			fmt.Printf("==> Receive Msg: ID=%d, message=%s\n", msg.Id, convertOctonaryUtf8(pbmsg.String()))
 		}
 	}
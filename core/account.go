@@ -0,0 +1,644 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"regexp"
+	"sync"
+	"sync/atomic"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrNotLogin          = errors.New("not login")
+	ErrAccountInvalid    = errors.New("account invalid")
+	ErrCharacterLimit    = errors.New("character limit reached")
+	ErrCharacterNotFound = errors.New("character not found")
+	ErrCharacterOnline   = errors.New("character already online")
+	ErrServerClosing     = errors.New("server is shutting down")
+	ErrLoginFailed       = errors.New("account or password wrong")
+	ErrAccountExists     = errors.New("account exists")
+	ErrPasswordInvalid   = errors.New("password invalid")
+)
+
+// accountPattern 账号只允许字母、数字和下划线，同时保证可以安全地作为存档文件名
+var accountPattern = regexp.MustCompile(`^[A-Za-z0-9_]{3,32}$`)
+
+// serverData 全服存档数据
+var serverData *ServerData
+var serverDataLock sync.Mutex
+
+// onlineAccount 已登录的账号，同一个账号的多个会话共享同一份数据
+type onlineAccount struct {
+	data *AccountData
+	refs int // 登录该账号的会话数量
+}
+
+var (
+	accounts        = make(map[string]*onlineAccount) // 已登录的账号，由accountLock保护
+	selectedPlayers = make(map[int32]bool)            // 已经被会话选择的角色，会话关闭后才释放，由accountLock保护
+	accountLock     sync.Mutex                        // 保护账号数据、角色列表和角色选择
+)
+
+// InitServerData 启动时加载全服存档，恢复playerId生成器和已占用的名字
+func InitServerData() error {
+	serverDataLock.Lock()
+	defer serverDataLock.Unlock()
+
+	data, err := StorageObj.LoadServerData()
+	if err == ErrDataNotFound {
+		data = &ServerData{
+			NextPlayerId: 1,
+			Names:        make(map[string]int32),
+		}
+	} else if err != nil {
+		return err
+	}
+
+	serverData = data
+	NameRegistryObj.Load(data.Names)
+	return nil
+}
+
+// genPlayerId 分配一个新的playerId，并立即存档，防止重启后重复分配
+func genPlayerId() (int32, error) {
+	serverDataLock.Lock()
+	defer serverDataLock.Unlock()
+
+	playerId := serverData.NextPlayerId
+	serverData.NextPlayerId++
+	serverData.Names = NameRegistryObj.Snapshot()
+	if err := StorageObj.SaveServerData(serverData); err != nil {
+		serverData.NextPlayerId--
+		return 0, err
+	}
+	return playerId, nil
+}
+
+// saveNames 将已占用的名字存档
+func saveNames() error {
+	serverDataLock.Lock()
+	defer serverDataLock.Unlock()
+
+	serverData.Names = NameRegistryObj.Snapshot()
+	return StorageObj.SaveServerData(serverData)
+}
+
+// CheckAccount 校验账号格式
+func CheckAccount(account string) error {
+	if !accountPattern.MatchString(account) {
+		return ErrAccountInvalid
+	}
+	return nil
+}
+
+// CheckPassword 检查密码长度
+func CheckPassword(password string) error {
+	if len(password) < PASSWORD_MIN_LEN || len(password) > PASSWORD_MAX_LEN {
+		return ErrPasswordInvalid
+	}
+	return nil
+}
+
+// hashPassword 加盐迭代计算密码哈希
+func hashPassword(salt []byte, password string) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), password...))
+	for i := 1; i < PASSWORD_HASH_ROUNDS; i++ {
+		sum = sha256.Sum256(append(append([]byte{}, salt...), sum[:]...))
+	}
+	return sum[:]
+}
+
+// setPassword 生成新的盐并保存密码哈希
+func (ad *AccountData) setPassword(password string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	ad.Salt = hex.EncodeToString(salt)
+	ad.Password = hex.EncodeToString(hashPassword(salt, password))
+	return nil
+}
+
+// checkPassword 校验密码，没有设置密码的旧账号无法登录
+func (ad *AccountData) checkPassword(password string) bool {
+	salt, err := hex.DecodeString(ad.Salt)
+	if err != nil || ad.Password == "" {
+		return false
+	}
+	want, err := hex.DecodeString(ad.Password)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(hashPassword(salt, password), want) == 1
+}
+
+// resultCodeOf 将错误转换为返回给客户端的结果码
+func resultCodeOf(err error) mmopb.ResultCode {
+	switch err {
+	case nil:
+		return mmopb.ResultCode_Result_Ok
+	case ErrNotLogin:
+		return mmopb.ResultCode_Result_Not_Login
+	case ErrServerClosing:
+		return mmopb.ResultCode_Result_Server_Closing
+	case ErrAccountInvalid:
+		return mmopb.ResultCode_Result_Account_Invalid
+	case ErrNameLength, ErrNameCharacter:
+		return mmopb.ResultCode_Result_Name_Invalid
+	case ErrNameDuplicate:
+		return mmopb.ResultCode_Result_Name_Duplicate
+	case ErrCharacterLimit:
+		return mmopb.ResultCode_Result_Character_Limit
+	case ErrCharacterNotFound:
+		return mmopb.ResultCode_Result_Character_Not_Found
+	case ErrCharacterOnline:
+		return mmopb.ResultCode_Result_Character_Online
+	case ErrBagFull:
+		return mmopb.ResultCode_Result_Bag_Full
+	case ErrItemNotFound:
+		return mmopb.ResultCode_Result_Item_Not_Found
+	case ErrItemNotEnough, ErrItemCountInvalid:
+		return mmopb.ResultCode_Result_Item_Not_Enough
+	case ErrSlotInvalid:
+		return mmopb.ResultCode_Result_Slot_Invalid
+	case ErrItemCannotUse:
+		return mmopb.ResultCode_Result_Item_Cannot_Use
+	case ErrCannotEquip:
+		return mmopb.ResultCode_Result_Cannot_Equip
+	case ErrPlayerDead:
+		return mmopb.ResultCode_Result_Dead
+	case ErrOutOfRange:
+		return mmopb.ResultCode_Result_Out_Of_Range
+	case ErrNotOwner:
+		return mmopb.ResultCode_Result_Not_Owner
+	case ErrGoldNotEnough:
+		return mmopb.ResultCode_Result_Gold_Not_Enough
+	case ErrItemCannotTrade:
+		return mmopb.ResultCode_Result_Item_Cannot_Trade
+	case ErrTargetBusy:
+		return mmopb.ResultCode_Result_Target_Busy
+	case ErrNotInTrade:
+		return mmopb.ResultCode_Result_Not_In_Trade
+	case ErrTradeNotLocked:
+		return mmopb.ResultCode_Result_Trade_Not_Locked
+	case ErrTargetNotFound:
+		return mmopb.ResultCode_Result_Target_Not_Found
+	case ErrQuestNotFound:
+		return mmopb.ResultCode_Result_Quest_Not_Found
+	case ErrQuestUnavailable:
+		return mmopb.ResultCode_Result_Quest_Unavailable
+	case ErrQuestNotReady:
+		return mmopb.ResultCode_Result_Quest_Not_Ready
+	case ErrChannelUnavailable:
+		return mmopb.ResultCode_Result_Channel_Unavailable
+	case ErrChatTooFast:
+		return mmopb.ResultCode_Result_Chat_Too_Fast
+	case ErrChatDuplicate:
+		return mmopb.ResultCode_Result_Chat_Duplicate
+	case ErrChatMuted:
+		return mmopb.ResultCode_Result_Chat_Muted
+	case ErrChatBannedWord:
+		return mmopb.ResultCode_Result_Chat_Banned_Word
+	case ErrChatTooLong:
+		return mmopb.ResultCode_Result_Chat_Too_Long
+	case ErrBlocked:
+		return mmopb.ResultCode_Result_Blocked
+	case ErrBlockListFull:
+		return mmopb.ResultCode_Result_Block_List_Full
+	case ErrGmUnknownCommand:
+		return mmopb.ResultCode_Result_Gm_Unknown_Command
+	case ErrGmBadArgs:
+		return mmopb.ResultCode_Result_Gm_Bad_Args
+	case ErrGmNoPermission:
+		return mmopb.ResultCode_Result_Gm_No_Permission
+	case ErrActionNotFound:
+		return mmopb.ResultCode_Result_Action_Not_Found
+	case ErrActionTooFast:
+		return mmopb.ResultCode_Result_Action_Too_Fast
+	case ErrPartyFull:
+		return mmopb.ResultCode_Result_Party_Full
+	case ErrNotInParty:
+		return mmopb.ResultCode_Result_Not_In_Party
+	case ErrNotPartyLeader:
+		return mmopb.ResultCode_Result_Not_Party_Leader
+	case ErrAlreadyInParty:
+		return mmopb.ResultCode_Result_Already_In_Party
+	case ErrInviteDeclined:
+		return mmopb.ResultCode_Result_Invite_Declined
+	case ErrFriendListFull:
+		return mmopb.ResultCode_Result_Friend_List_Full
+	case ErrAlreadyFriend:
+		return mmopb.ResultCode_Result_Already_Friend
+	case ErrNotFriend:
+		return mmopb.ResultCode_Result_Not_Friend
+	case ErrLoginFailed:
+		return mmopb.ResultCode_Result_Login_Failed
+	case ErrAccountExists:
+		return mmopb.ResultCode_Result_Account_Exists
+	case ErrPasswordInvalid:
+		return mmopb.ResultCode_Result_Password_Invalid
+	default:
+		return mmopb.ResultCode_Result_Failed
+	}
+}
+
+// characterBriefs 加载账号下全部角色的简要信息
+func characterBriefs(account *AccountData) []*mmopb.CharacterBrief {
+	accountLock.Lock()
+	playerIds := append([]int32{}, account.PlayerIds...)
+	accountLock.Unlock()
+
+	briefs := make([]*mmopb.CharacterBrief, 0, len(playerIds))
+	for _, playerId := range playerIds {
+		data, err := StorageObj.LoadPlayer(playerId)
+		if err != nil {
+			fmt.Println("load character id = ", playerId, " err: ", err)
+			continue
+		}
+		briefs = append(briefs, &mmopb.CharacterBrief{
+			PlayerId: data.PlayerId,
+			Profile: &mmopb.PlayerProfile{
+				Name:         data.Name,
+				Class:        data.Class,
+				AppearanceId: data.AppearanceId,
+				Level:        data.Level,
+			},
+		})
+	}
+	return briefs
+}
+
+// Login 账号登录
+func (s *Session) Login(account, password string) {
+	s.sendLoginResult(s.login(account, password))
+}
+
+// Register 注册账号，成功后直接登录
+func (s *Session) Register(account, password string) {
+	s.sendLoginResult(s.register(account, password))
+}
+
+// sendLoginResult 返回登录结果，成功时带上角色列表
+func (s *Session) sendLoginResult(err error) {
+	msg := &mmopb.LoginResult{
+		Result: resultCodeOf(err),
+	}
+	if err == nil {
+		msg.Characters = characterBriefs(s.Account)
+	}
+	s.SendMessage(mmopb.SCMsgIdLoginResult, msg)
+}
+
+func (s *Session) login(account, password string) error {
+	if IsShuttingDown() {
+		return ErrServerClosing
+	}
+	if err := CheckAccount(account); err != nil {
+		return err
+	}
+
+	accountLock.Lock()
+	defer accountLock.Unlock()
+
+	// 连接已经关闭时Close可能已经执行过，不能再占用账号
+	if s.IsStopped() {
+		return ErrNotLogin
+	}
+	if s.selected != 0 {
+		return ErrCharacterOnline
+	}
+	data, err := loadAccount(account)
+	if err == ErrDataNotFound {
+		return ErrLoginFailed
+	}
+	if err != nil {
+		return err
+	}
+	if !data.checkPassword(password) {
+		return ErrLoginFailed
+	}
+
+	s.setAccount(data)
+	fmt.Println("======> account ", account, " login, conn id = ", s.Conn.GetConnID(), " <======")
+	return nil
+}
+
+func (s *Session) register(account, password string) error {
+	if IsShuttingDown() {
+		return ErrServerClosing
+	}
+	if err := CheckAccount(account); err != nil {
+		return err
+	}
+	if err := CheckPassword(password); err != nil {
+		return err
+	}
+
+	accountLock.Lock()
+	defer accountLock.Unlock()
+
+	// 连接已经关闭时Close可能已经执行过，不能再占用账号
+	if s.IsStopped() {
+		return ErrNotLogin
+	}
+	if s.selected != 0 {
+		return ErrCharacterOnline
+	}
+	if _, err := loadAccount(account); err == nil {
+		return ErrAccountExists
+	} else if err != ErrDataNotFound {
+		return err
+	}
+
+	data := &AccountData{
+		Account: account,
+	}
+	if err := data.setPassword(password); err != nil {
+		return err
+	}
+	if err := StorageObj.SaveAccount(data); err != nil {
+		return err
+	}
+
+	s.setAccount(data)
+	fmt.Println("======> account ", account, " registered, conn id = ", s.Conn.GetConnID(), " <======")
+	return nil
+}
+
+// loadAccount 已登录的账号直接使用内存中的数据，否则从存档读取，调用方需持有accountLock
+func loadAccount(account string) (*AccountData, error) {
+	if online, ok := accounts[account]; ok {
+		return online.data, nil
+	}
+	return StorageObj.LoadAccount(account)
+}
+
+// setAccount 会话登录账号，同一个账号的会话共享同一份数据，调用方需持有accountLock
+func (s *Session) setAccount(data *AccountData) {
+	if s.Account == data {
+		return
+	}
+	s.releaseAccount()
+
+	online, ok := accounts[data.Account]
+	if !ok {
+		online = &onlineAccount{data: data}
+		accounts[data.Account] = online
+	}
+	online.refs++
+	s.Account = online.data
+}
+
+// releaseAccount 会话不再使用当前账号，没有会话使用时从内存中移除，调用方需持有accountLock
+func (s *Session) releaseAccount() {
+	if s.Account == nil {
+		return
+	}
+	if online, ok := accounts[s.Account.Account]; ok {
+		online.refs--
+		if online.refs <= 0 {
+			delete(accounts, s.Account.Account)
+		}
+	}
+	s.Account = nil
+}
+
+// Leave 连接关闭后结束会话：已经进入世界的角色走下线流程，之后释放角色和账号。
+// 角色正在进入世界时由SelectCharacter在进入完成后处理
+func (s *Session) Leave() {
+	accountLock.Lock()
+	if s.left {
+		accountLock.Unlock()
+		return
+	}
+	if s.entering {
+		// 进入世界剩下的消息不再发送，尽快走到下线
+		atomic.StoreInt32(&s.Player.leaving, 1)
+		accountLock.Unlock()
+		return
+	}
+	s.left = true
+	player := s.Player
+	accountLock.Unlock()
+
+	s.leave(player)
+}
+
+// leave 角色下线并释放会话占用的角色和账号
+func (s *Session) leave(player *Player) {
+	if player != nil {
+		player.LostConnection()
+		// 已经从世界管理器中移除，通知好友下线
+		player.NotifyFriendPresence(false)
+		fmt.Println("======> player id = ", player.PlayerId, " left <======")
+	}
+	s.Close()
+}
+
+// Close 连接关闭，下线流程保存完玩家数据之后释放选择的角色和账号，之后才能被再次选择
+func (s *Session) Close() {
+	accountLock.Lock()
+	defer accountLock.Unlock()
+
+	if s.selected != 0 {
+		delete(selectedPlayers, s.selected)
+		s.selected = 0
+	}
+	s.releaseAccount()
+}
+
+// SendCharacterList 同步账号下的角色列表
+func (s *Session) SendCharacterList() {
+	if s.Account == nil {
+		return
+	}
+	msg := &mmopb.CharacterList{
+		Characters: characterBriefs(s.Account),
+	}
+	s.SendMessage(mmopb.SCMsgIdCharacterList, msg)
+}
+
+// CreateCharacter 在当前账号下创建角色
+func (s *Session) CreateCharacter(name string, class mmopb.PlayerClass) {
+	data, err := s.createCharacter(name, class)
+
+	msg := &mmopb.CreateCharacterResult{
+		Result: resultCodeOf(err),
+	}
+	if err == nil {
+		msg.Character = &mmopb.CharacterBrief{
+			PlayerId: data.PlayerId,
+			Profile: &mmopb.PlayerProfile{
+				Name:         data.Name,
+				Class:        data.Class,
+				AppearanceId: data.AppearanceId,
+				Level:        data.Level,
+			},
+		}
+	}
+	s.SendMessage(mmopb.SCMsgIdCreateCharacterResult, msg)
+}
+
+func (s *Session) createCharacter(name string, class mmopb.PlayerClass) (*PlayerData, error) {
+	if s.Account == nil {
+		return nil, ErrNotLogin
+	}
+	if _, ok := mmopb.PlayerClass_name[int32(class)]; !ok || class == mmopb.PlayerClass_Class_Unknown {
+		return nil, errors.New("class invalid")
+	}
+
+	accountLock.Lock()
+	defer accountLock.Unlock()
+
+	if len(s.Account.PlayerIds) >= MAX_CHARACTERS_PER_ACCOUNT {
+		return nil, ErrCharacterLimit
+	}
+	if err := CheckPlayerName(name); err != nil {
+		return nil, err
+	}
+	if NameRegistryObj.IsTaken(name) {
+		return nil, ErrNameDuplicate
+	}
+
+	playerId, err := genPlayerId()
+	if err != nil {
+		return nil, err
+	}
+	// 分配id期间名字可能被其他连接抢占，以Reserve的结果为准
+	if err := NameRegistryObj.Reserve(name, playerId); err != nil {
+		return nil, err
+	}
+
+	data := &PlayerData{
+		PlayerId:     playerId,
+		X:            float32(160 + mrand.Intn(10)),
+		Y:            0,
+		Z:            float32(134 + mrand.Intn(17)),
+		V:            0,
+		Name:         name,
+		Class:        class,
+		AppearanceId: int32(class),
+		Level:        1,
+		SceneId:      DEFAULT_SCENE_ID,
+		HP:           ClassStats(class, 1).MaxHP,
+		MP:           ClassStats(class, 1).MaxMP,
+	}
+	// 新角色获得初始物品
+	bag := NewInventory(BAG_SIZE)
+	if _, err := bag.AddItems(starterItems...); err != nil {
+		fmt.Println("add starter items err: ", err)
+	}
+	data.Items = bag.Snapshot()
+	if err := StorageObj.SavePlayer(data); err != nil {
+		NameRegistryObj.Release(name)
+		return nil, err
+	}
+	if err := saveNames(); err != nil {
+		fmt.Println("save names err: ", err)
+	}
+
+	s.Account.PlayerIds = append(s.Account.PlayerIds, playerId)
+	if err := StorageObj.SaveAccount(s.Account); err != nil {
+		return nil, err
+	}
+
+	fmt.Println("======> account ", s.Account.Account, " create character ", name, " id = ", playerId, " <======")
+	return data, nil
+}
+
+// SelectCharacter 选择角色进入世界
+func (s *Session) SelectCharacter(playerId int32) {
+	err := s.selectCharacter(playerId)
+
+	msg := &mmopb.SelectCharacterResult{
+		Result:   resultCodeOf(err),
+		PlayerId: playerId,
+	}
+	s.SendMessage(mmopb.SCMsgIdSelectCharacterResult, msg)
+	if err != nil {
+		return
+	}
+
+	// 结果返回之后再进入世界，保证客户端先收到选择结果
+	s.Player.EnterWorld()
+
+	// 进入世界期间连接已经关闭的，关闭回调没有处理这个角色，在这里下线
+	accountLock.Lock()
+	s.entering = false
+	leave := s.IsStopped() && !s.left
+	if leave {
+		s.left = true
+	}
+	accountLock.Unlock()
+	if leave {
+		s.leave(s.Player)
+	}
+}
+
+func (s *Session) selectCharacter(playerId int32) error {
+	if s.Account == nil {
+		return ErrNotLogin
+	}
+	if IsShuttingDown() {
+		return ErrServerClosing
+	}
+
+	// 选择时就占用角色，防止两个会话同时把同一个角色带进世界。
+	// 连接已经关闭时Close可能已经执行过，不能再占用
+	accountLock.Lock()
+	if s.IsStopped() {
+		accountLock.Unlock()
+		return ErrNotLogin
+	}
+	if s.selected != 0 || selectedPlayers[playerId] {
+		accountLock.Unlock()
+		return ErrCharacterOnline
+	}
+	owned := false
+	for _, id := range s.Account.PlayerIds {
+		if id == playerId {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		accountLock.Unlock()
+		return ErrCharacterNotFound
+	}
+	selectedPlayers[playerId] = true
+	s.selected = playerId
+	accountLock.Unlock()
+
+	data, err := StorageObj.LoadPlayer(playerId)
+	if err != nil {
+		accountLock.Lock()
+		delete(selectedPlayers, playerId)
+		s.selected = 0
+		accountLock.Unlock()
+		if err == ErrDataNotFound {
+			return ErrCharacterNotFound
+		}
+		return err
+	}
+
+	player := NewPlayer(s.Conn, data)
+
+	// 读档期间连接已经关闭的不再进入世界，占用的角色由Close释放
+	accountLock.Lock()
+	defer accountLock.Unlock()
+	if s.IsStopped() {
+		return ErrNotLogin
+	}
+	player.account = s.Account.Account
+	player.gmLevel = GmLevelOf(s.Account.Account, s.Conn.RemoteAddr())
+	s.Player = player
+	s.entering = true
+	return nil
+}
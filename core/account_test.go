@@ -0,0 +1,64 @@
+package core
+
+import "testing"
+
+func TestAccountData_checkPassword(t *testing.T) {
+	data := &AccountData{Account: "alice"}
+	if data.checkPassword("") {
+		t.Fatal("account without password must not login")
+	}
+	if err := data.setPassword("secret123"); err != nil {
+		t.Fatal(err)
+	}
+	if !data.checkPassword("secret123") {
+		t.Fatal("right password rejected")
+	}
+	if data.checkPassword("secret124") {
+		t.Fatal("wrong password accepted")
+	}
+
+	// 相同的密码每次生成不同的盐
+	other := &AccountData{Account: "bob"}
+	other.setPassword("secret123")
+	if other.Salt == data.Salt || other.Password == data.Password {
+		t.Fatal("salt reused")
+	}
+}
+
+func TestSession_selectCharacterAfterStop(t *testing.T) {
+	s := &Session{Account: &AccountData{Account: "alice", PlayerIds: []int32{900001}}}
+	s.MarkStopped()
+
+	// 连接已经关闭时不再占用角色
+	if err := s.selectCharacter(900001); err != ErrNotLogin {
+		t.Fatalf("want ErrNotLogin, got %v", err)
+	}
+	if selectedPlayers[900001] || s.selected != 0 {
+		t.Fatal("character reserved by stopped session")
+	}
+}
+
+func TestSession_LeaveWhileEntering(t *testing.T) {
+	player := &Player{PlayerId: 900002}
+	s := &Session{Player: player, selected: 900002, entering: true}
+	selectedPlayers[900002] = true
+	defer delete(selectedPlayers, 900002)
+	s.MarkStopped()
+
+	// 进入世界期间关闭连接，角色留给选择角色的流程下线
+	s.Leave()
+	if !selectedPlayers[900002] || s.left {
+		t.Fatal("character released while entering world")
+	}
+	if !player.IsLeaving() {
+		t.Fatal("entering player still receives messages")
+	}
+
+	// 没有进入世界的会话只释放角色
+	s.entering = false
+	s.Player = nil
+	s.Leave()
+	if selectedPlayers[900002] || !s.left {
+		t.Fatal("character not released after leave")
+	}
+}
@@ -74,8 +74,8 @@ func (mgr *AOIManager) GetSurroundGridsByGid(gid int) (grids []*Grid) {
 
 	// 遍历x轴格子
 	for _, xgid := range gridsX {
-		// 计算该格子处于第几列
-		idy := xgid / mgr.CntsY
+		// 计算该格子处于第几行
+		idy := xgid / mgr.CntsX
 		// 判断当前idy上边是否有格子
 		if idy > 0 {
 			grids = append(grids, mgr.grids[xgid-mgr.CntsX])
@@ -109,6 +109,31 @@ func (mgr *AOIManager) GetPlayerIdsByPos(x, y float32) (playerIds []int) {
 	return
 }
 
+// GetPlayerIdsByRect 获取与矩形区域相交的全部格子内的playerIds，用于范围查找
+func (mgr *AOIManager) GetPlayerIdsByRect(minX, minY, maxX, maxY float32) (playerIds []int) {
+	for _, gid := range mgr.GetGidsByRect(minX, minY, maxX, maxY) {
+		playerIds = append(playerIds, mgr.grids[gid].GetPlayerIds()...)
+	}
+	return
+}
+
+// GetGidsByRect 获取与矩形区域相交的全部格子id
+func (mgr *AOIManager) GetGidsByRect(minX, minY, maxX, maxY float32) (gids []int) {
+	minGx := mgr.clampGridX((int(minX) - mgr.MinX) / mgr.gridWidth())
+	maxGx := mgr.clampGridX((int(maxX) - mgr.MinX) / mgr.gridWidth())
+	minGy := mgr.clampGridY((int(minY) - mgr.MinY) / mgr.gridLength())
+	maxGy := mgr.clampGridY((int(maxY) - mgr.MinY) / mgr.gridLength())
+
+	for gy := minGy; gy <= maxGy; gy++ {
+		for gx := minGx; gx <= maxGx; gx++ {
+			if _, ok := mgr.grids[gy*mgr.CntsX+gx]; ok {
+				gids = append(gids, gy*mgr.CntsX+gx)
+			}
+		}
+	}
+	return
+}
+
 // GetPlayerIdsByGid 通过gid获取指定格子内的全部playerIds
 func (mgr *AOIManager) GetPlayerIdsByGid(gid int) (playerIds []int) {
 	if grid, ok := mgr.grids[gid]; ok {
@@ -152,6 +177,28 @@ func (mgr *AOIManager) gridWidth() int {
 	return (mgr.MaxX - mgr.MinX) / mgr.CntsX
 }
 
+// clampGridX 将x轴格子编号限制在区域内
+func (mgr *AOIManager) clampGridX(gx int) int {
+	if gx < 0 {
+		return 0
+	}
+	if gx >= mgr.CntsX {
+		return mgr.CntsX - 1
+	}
+	return gx
+}
+
+// clampGridY 将y轴格子编号限制在区域内
+func (mgr *AOIManager) clampGridY(gy int) int {
+	if gy < 0 {
+		return 0
+	}
+	if gy >= mgr.CntsY {
+		return mgr.CntsY - 1
+	}
+	return gy
+}
+
 // gridLength 每个格子在y轴方向的长度
 func (mgr *AOIManager) gridLength() int {
 	return (mgr.MaxY - mgr.MinY) / mgr.CntsY
@@ -25,4 +25,23 @@ func TestAOIManager_GetSurroundGridsByGid(t *testing.T) {
 		fmt.Printf("grid id: %d, surrounding grid ids are %v\n", gid, gids)
 	}
 
+	// x和y方向格子数量不同时，角上的格子只有4个相邻格子
+	mgr = NewAOIManager(0, 100, 2, 0, 100, 4)
+	for _, gid := range []int{0, 1, 6, 7} {
+		if grids := mgr.GetSurroundGridsByGid(gid); len(grids) != 4 {
+			t.Fatalf("gid %d surrounding grids len = %d, want 4", gid, len(grids))
+		}
+	}
+}
+
+func TestAOIManager_GetPlayerIdsByRect(t *testing.T) {
+	mgr := NewAOIManager(0, 250, 5, 0, 250, 5)
+	mgr.AddPlayerIdToGridByPos(1, 10, 10)
+	mgr.AddPlayerIdToGridByPos(2, 60, 10)
+	mgr.AddPlayerIdToGridByPos(3, 240, 240)
+
+	ids := mgr.GetPlayerIdsByRect(-20, -20, 55, 30)
+	if len(ids) != 2 {
+		t.Fatalf("want 2 ids, got %v", ids)
+	}
 }
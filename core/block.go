@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrBlocked       = errors.New("blocked by target")
+	ErrBlockListFull = errors.New("block list full")
+)
+
+// loadBlocked 恢复存档中的屏蔽列表
+func (p *Player) loadBlocked(playerIds []int32) {
+	for _, playerId := range playerIds {
+		p.blocked[playerId] = true
+	}
+}
+
+// blockedSnapshot 屏蔽列表，按id排序，用于存档和同步
+func (p *Player) blockedSnapshot() []int32 {
+	p.blockLock.Lock()
+	defer p.blockLock.Unlock()
+
+	playerIds := make([]int32, 0, len(p.blocked))
+	for playerId := range p.blocked {
+		playerIds = append(playerIds, playerId)
+	}
+	sort.Slice(playerIds, func(i, j int) bool {
+		return playerIds[i] < playerIds[j]
+	})
+	return playerIds
+}
+
+// IsBlocking 是否屏蔽了指定玩家
+func (p *Player) IsBlocking(playerId int32) bool {
+	p.blockLock.Lock()
+	defer p.blockLock.Unlock()
+
+	return p.blocked[playerId]
+}
+
+// SendBlockList 同步屏蔽列表
+func (p *Player) SendBlockList() {
+	p.SendMessage(mmopb.SCMsgIdSyncBlockList, &mmopb.SyncBlockList{
+		PlayerIds: p.blockedSnapshot(),
+	})
+}
+
+// BlockPlayer 屏蔽或取消屏蔽玩家，目标不在线时也可以屏蔽
+func (p *Player) BlockPlayer(playerId int32, block bool) {
+	err := p.blockPlayer(playerId, block)
+	p.SendMessage(mmopb.SCMsgIdBlockResult, &mmopb.BlockResult{
+		Result:   resultCodeOf(err),
+		PlayerId: playerId,
+		Blocked:  p.IsBlocking(playerId),
+	})
+}
+
+func (p *Player) blockPlayer(playerId int32, block bool) error {
+	if !block {
+		p.blockLock.Lock()
+		delete(p.blocked, playerId)
+		p.blockLock.Unlock()
+		return nil
+	}
+
+	if playerId == p.PlayerId {
+		return ErrTargetNotFound
+	}
+	if WorldMgrObj.GetPlayerById(playerId) == nil {
+		if _, err := StorageObj.LoadPlayer(playerId); err == ErrDataNotFound {
+			return ErrTargetNotFound
+		} else if err != nil {
+			return err
+		}
+	}
+
+	p.blockLock.Lock()
+	defer p.blockLock.Unlock()
+
+	if !p.blocked[playerId] && len(p.blocked) >= MAX_BLOCKED_PLAYERS {
+		return ErrBlockListFull
+	}
+	p.blocked[playerId] = true
+	fmt.Println("======> player id = ", p.PlayerId, " block ", playerId, " <======")
+	return nil
+}
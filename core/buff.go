@@ -0,0 +1,410 @@
+package core
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// buff叠加规则
+const (
+	BUFF_STACK_REFRESH string = "refresh" // 重复施加时只刷新持续时间
+	BUFF_STACK_ADD     string = "stack"   // 重复施加时叠加一层并刷新持续时间，不超过最大层数
+	BUFF_STACK_IGNORE  string = "ignore"  // 已经存在时忽略新的施加
+)
+
+// BuffTemplate buff模板
+type BuffTemplate struct {
+	BuffId     int32  `json:"buff_id"`     // buff id
+	Name       string `json:"name"`        // buff名称
+	Debuff     bool   `json:"debuff"`      // 是否为减益效果
+	DurationMs int64  `json:"duration_ms"` // 持续时间(毫秒)
+	StackRule  string `json:"stack_rule"`  // 叠加规则
+	MaxStacks  int32  `json:"max_stacks"`  // 最大叠加层数
+	AttackAdd  int32  `json:"attack_add"`  // 每层增加的攻击力，负数为减少
+	DefenseAdd int32  `json:"defense_add"` // 每层增加的防御力，负数为减少
+	SpeedRate  int32  `json:"speed_rate"`  // 每层移动速度变化的百分比，负数为减速
+	TickMs     int64  `json:"tick_ms"`     // 周期效果的间隔(毫秒)，0表示没有周期效果
+	TickDamage int32  `json:"tick_damage"` // 每层每次周期造成的伤害
+	Stealth    bool   `json:"stealth"`     // 是否隐身
+	Persist    bool   `json:"persist"`     // 下线时是否保存
+}
+
+// BuffConfig buff数据文件
+type BuffConfig struct {
+	Buffs []*BuffTemplate `json:"buffs"`
+}
+
+// buffTemplates 全部buff模板
+var buffTemplates = make(map[int32]*BuffTemplate)
+
+// LoadBuffs 读取buff数据文件
+func LoadBuffs() error {
+	config := &BuffConfig{}
+	if err := loadConfig("buffs.json", config); err != nil {
+		return err
+	}
+
+	for _, buff := range config.Buffs {
+		switch buff.StackRule {
+		case BUFF_STACK_REFRESH, BUFF_STACK_ADD, BUFF_STACK_IGNORE:
+		default:
+			return fmt.Errorf("buff id %d stack rule %q invalid", buff.BuffId, buff.StackRule)
+		}
+		if buff.MaxStacks <= 0 {
+			buff.MaxStacks = 1
+		}
+		buffTemplates[buff.BuffId] = buff
+	}
+	return nil
+}
+
+// GetBuffTemplate 获取buff模板
+func GetBuffTemplate(buffId int32) *BuffTemplate {
+	return buffTemplates[buffId]
+}
+
+// Buff 实体身上的一个buff
+type Buff struct {
+	Template   *BuffTemplate // buff模板
+	CasterId   int32         // 施加者id
+	Stacks     int32         // 叠加层数
+	ExpireAt   int64         // 到期时间(unix毫秒)
+	nextTickAt int64         // 下一次周期效果的时间(unix毫秒)
+}
+
+// InfoMsg buff信息
+func (b *Buff) InfoMsg(now int64) *mmopb.BuffInfo {
+	remain := b.ExpireAt - now
+	if remain < 0 {
+		remain = 0
+	}
+	return &mmopb.BuffInfo{
+		BuffId:   b.Template.BuffId,
+		Stacks:   b.Stacks,
+		RemainMs: int32(remain),
+		CasterId: b.CasterId,
+	}
+}
+
+// addBuff 按叠加规则添加buff，返回变化后的buff，没有变化时返回nil
+func (cu *CombatUnit) addBuff(template *BuffTemplate, casterId int32, now int64) *Buff {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	if cu.HP <= 0 {
+		return nil
+	}
+	if cu.buffs == nil {
+		cu.buffs = make(map[int32]*Buff)
+	}
+
+	buff, ok := cu.buffs[template.BuffId]
+	if !ok {
+		buff = &Buff{
+			Template:   template,
+			CasterId:   casterId,
+			Stacks:     1,
+			ExpireAt:   now + template.DurationMs,
+			nextTickAt: now + template.TickMs,
+		}
+		cu.buffs[template.BuffId] = buff
+		cu.recalcStats()
+		return buff
+	}
+
+	switch template.StackRule {
+	case BUFF_STACK_IGNORE:
+		return nil
+	case BUFF_STACK_ADD:
+		if buff.Stacks < template.MaxStacks {
+			buff.Stacks++
+		}
+	}
+	buff.CasterId = casterId
+	buff.ExpireAt = now + template.DurationMs
+	cu.recalcStats()
+	return buff
+}
+
+// removeBuff 移除buff，返回被移除的buff
+func (cu *CombatUnit) removeBuff(buffId int32) *Buff {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	buff, ok := cu.buffs[buffId]
+	if !ok {
+		return nil
+	}
+	delete(cu.buffs, buffId)
+	cu.recalcStats()
+	return buff
+}
+
+// tickBuffs 移除到期的buff，并找出本次需要触发周期效果的buff
+func (cu *CombatUnit) tickBuffs(now int64) (expired []*Buff, ticked []*Buff) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	for buffId, buff := range cu.buffs {
+		if buff.Template.TickMs > 0 && now >= buff.nextTickAt {
+			buff.nextTickAt += buff.Template.TickMs
+			ticked = append(ticked, buff)
+		}
+		if now >= buff.ExpireAt {
+			delete(cu.buffs, buffId)
+			expired = append(expired, buff)
+		}
+	}
+	if len(expired) > 0 {
+		cu.recalcStats()
+	}
+	return
+}
+
+// buffInfos 全部buff信息
+func (cu *CombatUnit) buffInfos(now int64) []*mmopb.BuffInfo {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	infos := make([]*mmopb.BuffInfo, 0, len(cu.buffs))
+	for _, buff := range cu.buffs {
+		infos = append(infos, buff.InfoMsg(now))
+	}
+	return infos
+}
+
+// persistBuffs 需要保存的buff
+func (cu *CombatUnit) persistBuffs(now int64) []*BuffData {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	datas := make([]*BuffData, 0)
+	for _, buff := range cu.buffs {
+		if !buff.Template.Persist || buff.ExpireAt <= now {
+			continue
+		}
+		datas = append(datas, &BuffData{
+			BuffId:   buff.Template.BuffId,
+			CasterId: buff.CasterId,
+			Stacks:   buff.Stacks,
+			RemainMs: buff.ExpireAt - now,
+		})
+	}
+	return datas
+}
+
+// restoreBuffs 从存档恢复buff，不广播，用于上线前
+func (cu *CombatUnit) restoreBuffs(datas []*BuffData, now int64) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	for _, data := range datas {
+		template := GetBuffTemplate(data.BuffId)
+		if template == nil || data.RemainMs <= 0 {
+			continue
+		}
+		if cu.buffs == nil {
+			cu.buffs = make(map[int32]*Buff)
+		}
+		stacks := data.Stacks
+		if stacks < 1 {
+			stacks = 1
+		}
+		if stacks > template.MaxStacks {
+			stacks = template.MaxStacks
+		}
+		cu.buffs[data.BuffId] = &Buff{
+			Template:   template,
+			CasterId:   data.CasterId,
+			Stacks:     stacks,
+			ExpireAt:   now + data.RemainMs,
+			nextTickAt: now + template.TickMs,
+		}
+	}
+	cu.recalcStats()
+}
+
+// IsStealthed 是否处于隐身状态
+func (cu *CombatUnit) IsStealthed() bool {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	for _, buff := range cu.buffs {
+		if buff.Template.Stealth {
+			return true
+		}
+	}
+	return false
+}
+
+// recalcStats 根据基础属性、装备和buff重新计算战斗属性，调用方需持有combatLock
+func (cu *CombatUnit) recalcStats() {
+	cu.MaxHP = cu.base.MaxHP + cu.equip.MaxHP
+	cu.MaxMP = cu.base.MaxMP + cu.equip.MaxMP
+	cu.AttackRange = cu.base.AttackRange + cu.equip.AttackRange
+	if cu.HP > cu.MaxHP {
+		cu.HP = cu.MaxHP
+	}
+	if cu.MP > cu.MaxMP {
+		cu.MP = cu.MaxMP
+	}
+
+	attack := cu.base.Attack + cu.equip.Attack
+	defense := cu.base.Defense + cu.equip.Defense
+	speedRate := int32(100)
+	for _, buff := range cu.buffs {
+		attack += buff.Template.AttackAdd * buff.Stacks
+		defense += buff.Template.DefenseAdd * buff.Stacks
+		speedRate += buff.Template.SpeedRate * buff.Stacks
+	}
+	if attack < 0 {
+		attack = 0
+	}
+	if defense < 0 {
+		defense = 0
+	}
+	if speedRate < 0 {
+		speedRate = 0
+	}
+
+	cu.Attack = attack
+	cu.Defense = defense
+	cu.MoveSpeed = MOVE_SPEED * float32(speedRate) / 100
+}
+
+// AddBuff 给目标添加buff，并广播buff变化
+func AddBuff(target Combatant, casterId int32, buffId int32) {
+	template := GetBuffTemplate(buffId)
+	if template == nil {
+		fmt.Println("buff id = ", buffId, " not exist")
+		return
+	}
+
+	wasStealthed := target.GetCombatUnit().IsStealthed()
+	buff := target.GetCombatUnit().addBuff(template, casterId, nowMillis())
+	if buff == nil {
+		return
+	}
+
+	broadCastBuffChange(target, &mmopb.BuffChange{
+		EntityId: target.GetEntityId(),
+		Buff:     buff.InfoMsg(nowMillis()),
+	})
+	if template.Stealth && !wasStealthed {
+		onStealthChanged(target, true)
+	}
+	if template.SpeedRate != 0 {
+		broadCastStats(target)
+	}
+}
+
+// RemoveBuff 移除目标身上的buff，并广播buff变化
+func RemoveBuff(target Combatant, buffId int32) {
+	buff := target.GetCombatUnit().removeBuff(buffId)
+	if buff == nil {
+		return
+	}
+	onBuffRemoved(target, buff)
+}
+
+// ClearBuffs 移除目标身上的全部buff
+func ClearBuffs(target Combatant) {
+	for _, info := range target.GetCombatUnit().buffInfos(nowMillis()) {
+		RemoveBuff(target, info.BuffId)
+	}
+}
+
+// BreakStealth 主动攻击或施法时解除隐身
+func BreakStealth(target Combatant) {
+	for _, info := range target.GetCombatUnit().buffInfos(nowMillis()) {
+		if template := GetBuffTemplate(info.BuffId); template != nil && template.Stealth {
+			RemoveBuff(target, info.BuffId)
+		}
+	}
+}
+
+// TickBuffs 场景心跳中处理目标身上buff的到期和周期效果
+func TickBuffs(target Combatant, now int64) {
+	unit := target.GetCombatUnit()
+	expired, ticked := unit.tickBuffs(now)
+
+	for _, buff := range ticked {
+		if buff.Template.TickDamage <= 0 || unit.IsDead() {
+			continue
+		}
+		// 施加者已经下线或死亡时，伤害记在目标自己身上
+		var attacker Combatant = target
+		if caster := WorldMgrObj.GetCombatantById(buff.CasterId); caster != nil {
+			attacker = caster
+		}
+		ApplyDamage(attacker, target, 0, buff.Template.TickDamage*buff.Stacks, false)
+	}
+
+	for _, buff := range expired {
+		onBuffRemoved(target, buff)
+	}
+}
+
+// SendBuffs 将实体身上的buff同步给指定玩家
+func SendBuffs(entity Combatant, player *Player) {
+	infos := entity.GetCombatUnit().buffInfos(nowMillis())
+	if len(infos) == 0 {
+		return
+	}
+	player.SendMessage(mmopb.SCMsgIdSyncBuffs, &mmopb.SyncBuffs{
+		EntityId: entity.GetEntityId(),
+		Buffs:    infos,
+	})
+}
+
+// onBuffRemoved buff被移除后的广播和隐身处理
+func onBuffRemoved(target Combatant, buff *Buff) {
+	// 先重新出现在周围玩家视野中，再广播buff移除
+	if buff.Template.Stealth && !target.GetCombatUnit().IsStealthed() {
+		onStealthChanged(target, false)
+	}
+	broadCastBuffChange(target, &mmopb.BuffChange{
+		EntityId: target.GetEntityId(),
+		Buff:     buff.InfoMsg(nowMillis()),
+		Removed:  true,
+	})
+	if buff.Template.SpeedRate != 0 {
+		broadCastStats(target)
+	}
+}
+
+// broadCastBuffChange 广播buff变化，隐身的玩家只通知自己
+func broadCastBuffChange(target Combatant, msg *mmopb.BuffChange) {
+	if target.GetCombatUnit().IsStealthed() {
+		if player, ok := target.(*Player); ok {
+			player.SendMessage(mmopb.SCMsgIdBuffChange, msg)
+		}
+		return
+	}
+	x, z := target.GetPos()
+	WorldMgrObj.BroadCastAround(x, z, mmopb.SCMsgIdBuffChange, msg)
+}
+
+// broadCastStats 广播属性变化，隐身的玩家只通知自己
+func broadCastStats(target Combatant) {
+	msg := &mmopb.EntityStats{
+		EntityId: target.GetEntityId(),
+		Stats:    target.GetCombatUnit().StatsMsg(),
+	}
+	if target.GetCombatUnit().IsStealthed() {
+		if player, ok := target.(*Player); ok {
+			player.SendMessage(mmopb.SCMsgIdEntityStats, msg)
+		}
+		return
+	}
+	x, z := target.GetPos()
+	WorldMgrObj.BroadCastAround(x, z, mmopb.SCMsgIdEntityStats, msg)
+}
+
+// onStealthChanged 隐身状态变化时，让玩家在周围玩家的视野中消失或出现
+func onStealthChanged(target Combatant, stealthed bool) {
+	if player, ok := target.(*Player); ok {
+		player.OnStealthChanged(stealthed)
+	}
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var ErrChannelUnavailable = errors.New("chat channel unavailable")
+
+// ChatRecipients 找出频道消息的接收者，发送者不能使用该频道时返回错误
+type ChatRecipients func(sender *Player) ([]*Player, error)
+
+var (
+	chatChannels    = make(map[mmopb.ChatChannel]ChatRecipients) // 频道 -> 接收者
+	chatChannelLock sync.RWMutex                                 // 保护chatChannels的读写锁
+)
+
+func init() {
+	RegisterChatChannel(mmopb.ChatChannel_Chat_Channel_World, func(sender *Player) ([]*Player, error) {
+		return WorldMgrObj.GetAllPlayers(), nil
+	})
+	RegisterChatChannel(mmopb.ChatChannel_Chat_Channel_Nearby, func(sender *Player) ([]*Player, error) {
+		return WorldMgrObj.GetPlayersAround(sender.X, sender.Z), nil
+	})
+	RegisterChatChannel(mmopb.ChatChannel_Chat_Channel_Scene, func(sender *Player) ([]*Player, error) {
+		players := make([]*Player, 0)
+		for _, player := range WorldMgrObj.GetAllPlayers() {
+			if player.SceneId == sender.SceneId {
+				players = append(players, player)
+			}
+		}
+		return players, nil
+	})
+}
+
+// RegisterChatChannel 注册频道的接收者，队伍、公会等系统在初始化时注册自己的频道
+func RegisterChatChannel(channel mmopb.ChatChannel, recipients ChatRecipients) {
+	chatChannelLock.Lock()
+	chatChannels[channel] = recipients
+	chatChannelLock.Unlock()
+}
+
+// chatMsg 聊天消息
+func (p *Player) chatMsg(channel mmopb.ChatChannel, targetId int32, content string) *mmopb.ChatMessage {
+	return &mmopb.ChatMessage{
+		Channel:    channel,
+		SenderId:   p.PlayerId,
+		SenderName: p.Name,
+		TargetId:   targetId,
+		Content:    content,
+		TimeMs:     nowMillis(),
+	}
+}
+
+// sendChatResult 告知聊天失败的原因，禁言时带上剩余时间
+func (p *Player) sendChatResult(err error, channel mmopb.ChatChannel, targetId int32) {
+	if err == nil {
+		return
+	}
+	msg := &mmopb.ChatResult{
+		Result:   resultCodeOf(err),
+		Channel:  channel,
+		TargetId: targetId,
+	}
+	if err == ErrChatMuted {
+		msg.MuteRemainMs = p.chatLimit.MuteRemain(nowMillis())
+	}
+	p.SendMessage(mmopb.SCMsgIdChatResult, msg)
+}
+
+// sendWhisperResult 告知私聊的投递状态
+func (p *Player) sendWhisperResult(targetId int32, status mmopb.WhisperStatus, stored bool) {
+	msg := &mmopb.WhisperResult{
+		TargetId: targetId,
+		Status:   status,
+		Stored:   stored,
+	}
+	if status == mmopb.WhisperStatus_Whisper_Muted {
+		msg.MuteRemainMs = p.chatLimit.MuteRemain(nowMillis())
+	}
+	p.SendMessage(mmopb.SCMsgIdWhisperResult, msg)
+}
+
+// Talk 按频道发送聊天消息
+func (p *Player) Talk(channel mmopb.ChatChannel, targetId int32, content string) {
+	if len(content) == 0 {
+		return
+	}
+	// GM以/开头的消息按命令执行，不广播
+	if p.gmLevel > GM_LEVEL_NONE && strings.HasPrefix(content, GM_COMMAND_PREFIX) {
+		if err := p.chatLimit.AllowCommand(nowMillis()); err != nil {
+			p.sendChatResult(err, channel, targetId)
+			return
+		}
+		p.RunGmCommand(content)
+		return
+	}
+	// 兼容旧客户端，世界频道带目标玩家时按私聊处理
+	if channel == mmopb.ChatChannel_Chat_Channel_World && targetId > 0 {
+		channel = mmopb.ChatChannel_Chat_Channel_Whisper
+	}
+
+	// 发言太快、重复发言或禁言中的消息直接丢弃
+	if err := p.chatLimit.Allow(channel, targetId, content, nowMillis()); err != nil {
+		if err == ErrChatMuted && channel == mmopb.ChatChannel_Chat_Channel_Whisper {
+			p.sendWhisperResult(targetId, mmopb.WhisperStatus_Whisper_Muted, false)
+		} else {
+			p.sendChatResult(err, channel, targetId)
+		}
+		return
+	}
+
+	if channel == mmopb.ChatChannel_Chat_Channel_Whisper {
+		p.TalkToTargetPlayer(targetId, content)
+	} else {
+		p.BroadCastTalk(channel, content)
+	}
+}
+
+// BroadCastTalk 把聊天消息发给频道内的全部玩家
+func (p *Player) BroadCastTalk(channel mmopb.ChatChannel, content string) {
+	content, err := filterChat(content)
+	if err != nil {
+		p.sendChatResult(err, channel, 0)
+		return
+	}
+
+	chatChannelLock.RLock()
+	recipients, ok := chatChannels[channel]
+	chatChannelLock.RUnlock()
+
+	var players []*Player
+	err = ErrChannelUnavailable
+	if ok {
+		players, err = recipients(p)
+	}
+	if err != nil {
+		p.sendChatResult(err, channel, 0)
+		return
+	}
+
+	msg := p.chatMsg(channel, 0, content)
+	p.recordChat(msg)
+	for _, player := range players {
+		if !player.IsBlocking(p.PlayerId) {
+			player.SendMessage(mmopb.SCMsgIdChat, msg)
+		}
+	}
+	fmt.Println("======> player id = ", p.PlayerId, " talk in channel ", channel, " to ", len(players), " players <======")
+}
+
+// TalkToTargetPlayer 私聊，同时发回给自己用于显示，对方不在线时按配置保存，下次登录时补发
+func (p *Player) TalkToTargetPlayer(targetPlayerId int32, content string) {
+	content, err := filterChat(content)
+	if err != nil {
+		p.sendChatResult(err, mmopb.ChatChannel_Chat_Channel_Whisper, targetPlayerId)
+		return
+	}
+
+	offlineChatLock.Lock()
+	targetPlayer := WorldMgrObj.GetPlayerById(targetPlayerId)
+	if targetPlayer == nil || targetPlayer.IsLeaving() {
+		stored, err := p.storeOfflineWhisper(targetPlayerId, content, nowMillis())
+		offlineChatLock.Unlock()
+
+		if err == ErrBlocked {
+			p.sendWhisperResult(targetPlayerId, mmopb.WhisperStatus_Whisper_Blocked, false)
+			return
+		}
+		if err != nil {
+			p.sendChatResult(err, mmopb.ChatChannel_Chat_Channel_Whisper, targetPlayerId)
+			return
+		}
+		if stored {
+			msg := p.chatMsg(mmopb.ChatChannel_Chat_Channel_Whisper, targetPlayerId, content)
+			recordWhisper(msg)
+			p.SendMessage(mmopb.SCMsgIdChat, msg)
+		}
+		p.sendWhisperResult(targetPlayerId, mmopb.WhisperStatus_Whisper_Offline, stored)
+		return
+	}
+	offlineChatLock.Unlock()
+
+	if targetPlayer.IsBlocking(p.PlayerId) {
+		p.sendWhisperResult(targetPlayerId, mmopb.WhisperStatus_Whisper_Blocked, false)
+		return
+	}
+
+	msg := p.chatMsg(mmopb.ChatChannel_Chat_Channel_Whisper, targetPlayerId, content)
+	recordWhisper(msg)
+	targetPlayer.SendMessage(mmopb.SCMsgIdChat, msg)
+	if targetPlayer != p {
+		p.SendMessage(mmopb.SCMsgIdChat, msg)
+	}
+	p.sendWhisperResult(targetPlayerId, mmopb.WhisperStatus_Whisper_Delivered, false)
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrChatBannedWord = errors.New("chat contains banned word")
+	ErrChatTooLong    = errors.New("chat too long")
+)
+
+// 敏感词的处理方式
+const (
+	FILTER_MODE_MASK   string = "mask"   // 替换为*
+	FILTER_MODE_REJECT string = "reject" // 拒绝整条消息
+)
+
+// ChatFilter 聊天内容过滤，返回过滤后的内容，不能发送时返回错误
+type ChatFilter interface {
+	Filter(content string) (string, error)
+}
+
+var (
+	chatFilter     ChatFilter   // 当前使用的过滤器，为nil时不过滤
+	chatFilterLock sync.RWMutex // 保护chatFilter的读写锁
+)
+
+// SetChatFilter 替换聊天内容过滤器
+func SetChatFilter(filter ChatFilter) {
+	chatFilterLock.Lock()
+	chatFilter = filter
+	chatFilterLock.Unlock()
+}
+
+// filterChat 用当前的过滤器过滤聊天内容
+func filterChat(content string) (string, error) {
+	chatFilterLock.RLock()
+	filter := chatFilter
+	chatFilterLock.RUnlock()
+
+	if filter == nil {
+		return content, nil
+	}
+	return filter.Filter(content)
+}
+
+// ChatFilterConfig 聊天过滤配置
+type ChatFilterConfig struct {
+	Mode      string `json:"mode"`       // 敏感词的处理方式
+	MaxLength int    `json:"max_length"` // 消息的最大字符数，0表示不限制
+	Wordlist  string `json:"wordlist"`   // 敏感词文件，在配置目录下，每行一个词
+}
+
+// loadChatFilter 按配置创建本地词库过滤器
+func loadChatFilter(config *ChatFilterConfig) (*WordlistFilter, error) {
+	if config.Mode != FILTER_MODE_MASK && config.Mode != FILTER_MODE_REJECT {
+		return nil, fmt.Errorf("chat filter mode %q invalid", config.Mode)
+	}
+
+	words := make([]string, 0)
+	if config.Wordlist != "" {
+		file, err := os.Open(filepath.Join(CONF_DIR, config.Wordlist))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+				words = append(words, word)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return NewWordlistFilter(words, config.Mode, config.MaxLength), nil
+}
+
+// wordNode 敏感词前缀树的节点
+type wordNode struct {
+	children map[rune]*wordNode // 下一个字符 -> 节点
+	end      bool               // 是否是一个敏感词的结尾
+}
+
+// WordlistFilter 基于本地词库的过滤器。
+// 匹配前把全角字符转为半角、字母转为小写，并跳过空白和标点，
+// 因此"ＡＢＣ"、"a b c"和"a.b.c"都能匹配到敏感词"abc"。
+// 字母和数字开头或结尾的敏感词需要在单词边界上，"xa bc"和"abcd"不会匹配，中日韩文字没有单词边界
+type WordlistFilter struct {
+	root      *wordNode // 敏感词前缀树
+	mode      string    // 敏感词的处理方式
+	maxLength int       // 消息的最大字符数，0表示不限制
+}
+
+// NewWordlistFilter 创建本地词库过滤器
+func NewWordlistFilter(words []string, mode string, maxLength int) *WordlistFilter {
+	filter := &WordlistFilter{
+		root:      &wordNode{children: make(map[rune]*wordNode)},
+		mode:      mode,
+		maxLength: maxLength,
+	}
+	for _, word := range words {
+		node := filter.root
+		count := 0
+		for _, r := range word {
+			r, ok := normalizeRune(r)
+			if !ok {
+				continue
+			}
+			child, exist := node.children[r]
+			if !exist {
+				child = &wordNode{children: make(map[rune]*wordNode)}
+				node.children[r] = child
+			}
+			node = child
+			count++
+		}
+		if count > 0 {
+			node.end = true
+		}
+	}
+	return filter
+}
+
+// Filter 检查长度并处理敏感词
+func (wf *WordlistFilter) Filter(content string) (string, error) {
+	if wf.maxLength > 0 && utf8.RuneCountInString(content) > wf.maxLength {
+		return "", ErrChatTooLong
+	}
+
+	// 只保留参与匹配的字符，并记录在原内容中的位置
+	runes := []rune(content)
+	normalized := make([]rune, 0, len(runes))
+	positions := make([]int, 0, len(runes))
+	for i, r := range runes {
+		if n, ok := normalizeRune(r); ok {
+			normalized = append(normalized, n)
+			positions = append(positions, i)
+		}
+	}
+
+	masked := false
+	for start := 0; start < len(normalized); start++ {
+		if p := positions[start]; p > 0 && isWordRune(runes[p]) && isWordRune(runes[p-1]) {
+			continue
+		}
+		// 从start开始找最长的敏感词
+		end := -1
+		node := wf.root
+		for i := start; i < len(normalized); i++ {
+			node = node.children[normalized[i]]
+			if node == nil {
+				break
+			}
+			if p := positions[i]; node.end && !(p+1 < len(runes) && isWordRune(runes[p]) && isWordRune(runes[p+1])) {
+				end = i
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		if wf.mode == FILTER_MODE_REJECT {
+			return "", ErrChatBannedWord
+		}
+		// 敏感词中间夹带的空白和标点一起替换
+		for i := positions[start]; i <= positions[end]; i++ {
+			runes[i] = '*'
+		}
+		masked = true
+		start = end
+	}
+
+	if !masked {
+		return content, nil
+	}
+	return string(runes), nil
+}
+
+// isWordRune 是否是组成单词的字符，即字母和数字，中日韩文字除外
+func isWordRune(r rune) bool {
+	r, ok := normalizeRune(r)
+	return ok && !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// normalizeRune 全角转半角、字母转小写，空白和标点不参与匹配
+func normalizeRune(r rune) (rune, bool) {
+	switch {
+	case r == '　':
+		r = ' '
+	case r >= '！' && r <= '～':
+		r -= 0xFEE0
+	}
+	if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+		return r, false
+	}
+	return unicode.ToLower(r), true
+}
@@ -0,0 +1,42 @@
+package core
+
+import "testing"
+
+func TestWordlistFilter(t *testing.T) {
+	filter := NewWordlistFilter([]string{"外挂", "abc"}, FILTER_MODE_MASK, 10)
+
+	cases := []struct {
+		content string
+		want    string
+	}{
+		{"卖外挂了", "卖**了"},
+		{"外 挂", "***"},
+		{"ＡＢＣ!", "***!"},
+		{"a.b.c", "*****"},
+		{"ab", "ab"},
+		// 字母组成的敏感词只在单词边界上匹配
+		{"xa bc", "xa bc"},
+		{"abcd", "abcd"},
+		{"1abc", "1abc"},
+		{"x abc!", "x ***!"},
+		{"外挂abc", "*****"},
+	}
+	for _, c := range cases {
+		got, err := filter.Filter(c.content)
+		if err != nil || got != c.want {
+			t.Fatalf("filter %q = %q, %v, want %q", c.content, got, err, c.want)
+		}
+	}
+
+	if _, err := filter.Filter("一二三四五六七八九十一"); err != ErrChatTooLong {
+		t.Fatalf("want ErrChatTooLong, got %v", err)
+	}
+
+	filter = NewWordlistFilter([]string{"外挂"}, FILTER_MODE_REJECT, 0)
+	if _, err := filter.Filter("有外挂吗"); err != ErrChatBannedWord {
+		t.Fatalf("want ErrChatBannedWord, got %v", err)
+	}
+	if got, err := filter.Filter("你好"); err != nil || got != "你好" {
+		t.Fatalf("filter 你好 = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// chatRing 固定容量的聊天记录环形缓冲区，满了之后覆盖最早的消息
+type chatRing struct {
+	msgs     []*mmopb.ChatMessage // 缓冲区
+	start    int                  // 最早一条消息的位置
+	count    int                  // 当前消息数量
+	activeAt int64                // 最近一条私聊的时间(unix毫秒)，用于清除空闲的私聊记录
+}
+
+// newChatRing 创建指定容量的环形缓冲区
+func newChatRing(size int) *chatRing {
+	return &chatRing{
+		msgs: make([]*mmopb.ChatMessage, size),
+	}
+}
+
+// push 追加一条消息
+func (r *chatRing) push(msg *mmopb.ChatMessage) {
+	if len(r.msgs) == 0 {
+		return
+	}
+	if r.count < len(r.msgs) {
+		r.msgs[(r.start+r.count)%len(r.msgs)] = msg
+		r.count++
+		return
+	}
+	r.msgs[r.start] = msg
+	r.start = (r.start + 1) % len(r.msgs)
+}
+
+// list 按时间顺序返回全部消息
+func (r *chatRing) list() []*mmopb.ChatMessage {
+	msgs := make([]*mmopb.ChatMessage, 0, r.count)
+	for i := 0; i < r.count; i++ {
+		msgs = append(msgs, r.msgs[(r.start+i)%len(r.msgs)])
+	}
+	return msgs
+}
+
+// ChatHistoryScope 找出玩家能看到的频道记录，例如场景频道按场景id区分，
+// 玩家不属于该频道时返回false
+type ChatHistoryScope func(p *Player) (int32, bool)
+
+// chatHistoryKey 频道记录的索引
+type chatHistoryKey struct {
+	channel mmopb.ChatChannel // 频道
+	scopeId int32             // 场景id、公会id等，世界频道为0
+}
+
+// whisperKey 两个玩家之间的私聊记录的索引，小的id在前
+type whisperKey struct {
+	low  int32
+	high int32
+}
+
+var (
+	chatHistoryScopes = make(map[mmopb.ChatChannel]ChatHistoryScope) // 保存记录的频道 -> 记录范围
+	chatHistories     = make(map[chatHistoryKey]*chatRing)           // 频道记录
+	whisperHistories  = make(map[whisperKey]*chatRing)               // 私聊记录
+	whisperSweepAt    int64                                          // 上次清除空闲私聊记录的时间(unix毫秒)
+	chatHistoryLock   sync.Mutex                                     // 保护聊天记录的锁
+)
+
+func init() {
+	RegisterChatHistory(mmopb.ChatChannel_Chat_Channel_World, func(p *Player) (int32, bool) {
+		return 0, true
+	})
+	RegisterChatHistory(mmopb.ChatChannel_Chat_Channel_Scene, func(p *Player) (int32, bool) {
+		return p.SceneId, true
+	})
+}
+
+// RegisterChatHistory 为频道保存最近的消息，公会等系统在初始化时注册自己的记录范围
+func RegisterChatHistory(channel mmopb.ChatChannel, scope ChatHistoryScope) {
+	chatHistoryLock.Lock()
+	chatHistoryScopes[channel] = scope
+	chatHistoryLock.Unlock()
+}
+
+// newWhisperKey 私聊记录的索引，与发送方向无关
+func newWhisperKey(a, b int32) whisperKey {
+	if a > b {
+		a, b = b, a
+	}
+	return whisperKey{low: a, high: b}
+}
+
+// recordChat 保存频道消息，没有注册记录范围的频道不保存
+func (p *Player) recordChat(msg *mmopb.ChatMessage) {
+	chatHistoryLock.Lock()
+	defer chatHistoryLock.Unlock()
+
+	scope, ok := chatHistoryScopes[msg.Channel]
+	if !ok {
+		return
+	}
+	scopeId, ok := scope(p)
+	if !ok {
+		return
+	}
+	key := chatHistoryKey{channel: msg.Channel, scopeId: scopeId}
+	ring, ok := chatHistories[key]
+	if !ok {
+		ring = newChatRing(chatConfig.HistorySize)
+		chatHistories[key] = ring
+	}
+	ring.push(msg)
+}
+
+// recordWhisper 保存两个玩家之间的私聊，顺便清除空闲的私聊记录
+func recordWhisper(msg *mmopb.ChatMessage) {
+	now := nowMillis()
+	chatHistoryLock.Lock()
+	defer chatHistoryLock.Unlock()
+
+	key := newWhisperKey(msg.SenderId, msg.TargetId)
+	ring, ok := whisperHistories[key]
+	if !ok {
+		ring = newChatRing(chatConfig.WhisperHistorySize)
+		whisperHistories[key] = ring
+	}
+	ring.push(msg)
+	ring.activeAt = now
+
+	if idle := chatConfig.WhisperHistoryIdleMs; idle > 0 && now-whisperSweepAt >= idle {
+		evictIdleWhispers(now)
+	}
+}
+
+// evictIdleWhispers 清除超过空闲时间没有新消息的私聊记录，每个空闲时间最多清除一次，
+// 因此记录最多保留两倍的空闲时间。调用方需持有chatHistoryLock
+func evictIdleWhispers(now int64) {
+	whisperSweepAt = now
+	for key, ring := range whisperHistories {
+		if now-ring.activeAt >= chatConfig.WhisperHistoryIdleMs {
+			delete(whisperHistories, key)
+		}
+	}
+}
+
+// SendChatHistory 进入世界时发送各频道最近的消息，不包含被屏蔽玩家的发言
+func (p *Player) SendChatHistory() {
+	chatHistoryLock.Lock()
+	histories := make([]*mmopb.ChatHistory, 0, len(chatHistoryScopes))
+	for channel, scope := range chatHistoryScopes {
+		scopeId, ok := scope(p)
+		if !ok {
+			continue
+		}
+		if ring, ok := chatHistories[chatHistoryKey{channel: channel, scopeId: scopeId}]; ok {
+			histories = append(histories, &mmopb.ChatHistory{
+				Channel:  channel,
+				Messages: ring.list(),
+			})
+		}
+	}
+	chatHistoryLock.Unlock()
+
+	for _, history := range histories {
+		history.Messages = p.visibleChats(history.Messages)
+		if len(history.Messages) > 0 {
+			p.SendMessage(mmopb.SCMsgIdChatHistory, history)
+		}
+	}
+}
+
+// SendWhisperHistory 发送和指定玩家之间的私聊记录
+func (p *Player) SendWhisperHistory(playerId int32) {
+	chatHistoryLock.Lock()
+	var msgs []*mmopb.ChatMessage
+	if ring, ok := whisperHistories[newWhisperKey(p.PlayerId, playerId)]; ok {
+		msgs = ring.list()
+	}
+	chatHistoryLock.Unlock()
+
+	p.SendMessage(mmopb.SCMsgIdChatHistory, &mmopb.ChatHistory{
+		Channel:  mmopb.ChatChannel_Chat_Channel_Whisper,
+		TargetId: playerId,
+		Messages: p.visibleChats(msgs),
+	})
+}
+
+// visibleChats 去掉被屏蔽玩家的发言
+func (p *Player) visibleChats(msgs []*mmopb.ChatMessage) []*mmopb.ChatMessage {
+	visible := make([]*mmopb.ChatMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		if !p.IsBlocking(msg.SenderId) {
+			visible = append(visible, msg)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"aoi_mmo_game/mmopb"
+)
+
+func TestChatRing(t *testing.T) {
+	ring := newChatRing(3)
+	for i := int64(1); i <= 5; i++ {
+		ring.push(&mmopb.ChatMessage{TimeMs: i})
+	}
+
+	// 只保留最近的3条，按时间顺序
+	msgs := ring.list()
+	if len(msgs) != 3 {
+		t.Fatalf("ring len = %d, want 3", len(msgs))
+	}
+	for i, msg := range msgs {
+		if msg.TimeMs != int64(i+3) {
+			t.Fatalf("msg %d time = %d, want %d", i, msg.TimeMs, i+3)
+		}
+	}
+
+	empty := newChatRing(0)
+	empty.push(&mmopb.ChatMessage{})
+	if len(empty.list()) != 0 {
+		t.Fatal("zero size ring should not keep messages")
+	}
+}
+
+func TestRecordWhisper_EvictIdle(t *testing.T) {
+	chatConfig = &ChatConfig{WhisperHistorySize: 5, WhisperHistoryIdleMs: 1000}
+	whisperHistories = make(map[whisperKey]*chatRing)
+	defer func() {
+		chatConfig = &ChatConfig{}
+		whisperHistories = make(map[whisperKey]*chatRing)
+		whisperSweepAt = 0
+	}()
+
+	recordWhisper(&mmopb.ChatMessage{SenderId: 1, TargetId: 2})
+	recordWhisper(&mmopb.ChatMessage{SenderId: 3, TargetId: 1})
+	// 1和2之间的私聊已经空闲超过1秒，下一次清除时删除
+	whisperHistories[newWhisperKey(1, 2)].activeAt -= 2000
+	whisperSweepAt -= 2000
+	recordWhisper(&mmopb.ChatMessage{SenderId: 1, TargetId: 4})
+
+	if _, ok := whisperHistories[newWhisperKey(1, 2)]; ok {
+		t.Fatal("idle whisper history should be evicted")
+	}
+	if len(whisperHistories) != 2 {
+		t.Fatalf("whisper histories = %d, want 2", len(whisperHistories))
+	}
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrChatTooFast   = errors.New("chat too fast")
+	ErrChatDuplicate = errors.New("chat duplicate")
+	ErrChatMuted     = errors.New("chat muted")
+)
+
+// ChannelLimit 频道的发言频率限制，令牌桶最多存burst条，每refill_ms恢复一条
+type ChannelLimit struct {
+	Channel  mmopb.ChatChannel `json:"channel"`   // 频道
+	Burst    int32             `json:"burst"`     // 最多连续发言的条数
+	RefillMs int64             `json:"refill_ms"` // 恢复一条需要的时间(毫秒)
+}
+
+// ChatConfig 聊天数据文件
+type ChatConfig struct {
+	Channels          []*ChannelLimit `json:"channels"`            // 各频道的限制，没有配置的频道不限制
+	DuplicateWindowMs int64           `json:"duplicate_window_ms"` // 同一频道相同内容的间隔小于该时间视为重复(毫秒)
+	ViolationWindowMs int64           `json:"violation_window_ms"` // 统计违规次数的时间窗口(毫秒)
+	ViolationsPerMute int             `json:"violations_per_mute"` // 时间窗口内违规达到该次数时禁言
+	MuteStepsMs       []int64         `json:"mute_steps_ms"`       // 逐次加重的禁言时长(毫秒)，超出后保持最后一档
+	MuteResetMs       int64           `json:"mute_reset_ms"`       // 距上次禁言超过该时间后禁言档位清零(毫秒)
+
+	Filter *ChatFilterConfig `json:"filter"` // 内容过滤，没有配置时不过滤
+
+	OfflineWhisperLimit    int   `json:"offline_whisper_limit"`     // 每个玩家最多保存的离线私聊条数，0表示不保存
+	OfflineWhisperExpireMs int64 `json:"offline_whisper_expire_ms"` // 离线私聊的保存时间(毫秒)
+
+	HistorySize          int   `json:"history_size"`            // 每个频道保存的最近消息条数
+	WhisperHistorySize   int   `json:"whisper_history_size"`    // 每两个玩家之间保存的最近私聊条数
+	WhisperHistoryIdleMs int64 `json:"whisper_history_idle_ms"` // 私聊记录超过该时间没有新消息时清除(毫秒)，0表示不清除
+}
+
+// chatConfig 聊天限制配置
+var chatConfig = &ChatConfig{}
+
+// channelLimits 频道 -> 频率限制
+var channelLimits = make(map[mmopb.ChatChannel]*ChannelLimit)
+
+// LoadChat 读取聊天数据文件
+func LoadChat() error {
+	config := &ChatConfig{}
+	if err := loadConfig("chat.json", config); err != nil {
+		return err
+	}
+
+	for _, limit := range config.Channels {
+		if _, ok := mmopb.ChatChannel_name[int32(limit.Channel)]; !ok {
+			return fmt.Errorf("chat channel %d invalid", limit.Channel)
+		}
+		if limit.Burst <= 0 || limit.RefillMs <= 0 {
+			return fmt.Errorf("chat channel %d burst %d refill ms %d invalid", limit.Channel, limit.Burst, limit.RefillMs)
+		}
+		channelLimits[limit.Channel] = limit
+	}
+	if config.ViolationsPerMute > 0 && len(config.MuteStepsMs) == 0 {
+		return fmt.Errorf("chat mute steps is empty")
+	}
+	if config.Filter != nil {
+		filter, err := loadChatFilter(config.Filter)
+		if err != nil {
+			return err
+		}
+		SetChatFilter(filter)
+	}
+	chatConfig = config
+	return nil
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	tokens float64 // 当前令牌数
+	last   int64   // 上次计算令牌的时间(unix毫秒)
+}
+
+// take 按时间补充令牌后取出一个，令牌不足时返回false
+func (b *tokenBucket) take(burst int32, refillMs int64, now int64) bool {
+	b.tokens += float64(now-b.last) / float64(refillMs)
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// recentChat 频道内最近一次发言
+type recentChat struct {
+	content  string // 去掉首尾空白并转为小写的内容
+	targetId int32  // 私聊对象，其他频道为0
+	at       int64  // 发言时间(unix毫秒)
+}
+
+// ChatLimiter 玩家的发言限制
+type ChatLimiter struct {
+	buckets    map[mmopb.ChatChannel]*tokenBucket // 各频道的令牌桶
+	recent     map[mmopb.ChatChannel]*recentChat  // 各频道最近一次发言
+	commands   *tokenBucket                       // GM命令的令牌桶
+	violations []int64                            // 时间窗口内的违规时间
+	mutedUntil int64                              // 禁言结束时间(unix毫秒)
+	muteLevel  int                                // 已经被禁言的次数，决定下次禁言的时长
+	lastMuteAt int64                              // 上次禁言的时间(unix毫秒)
+	limitLock  sync.Mutex                         // 保护发言限制的锁
+}
+
+// NewChatLimiter 创建发言限制，恢复存档中的禁言状态
+func NewChatLimiter(mutedUntil int64, muteLevel int) *ChatLimiter {
+	return &ChatLimiter{
+		buckets:    make(map[mmopb.ChatChannel]*tokenBucket),
+		recent:     make(map[mmopb.ChatChannel]*recentChat),
+		mutedUntil: mutedUntil,
+		muteLevel:  muteLevel,
+		lastMuteAt: mutedUntil,
+	}
+}
+
+// Allow 检查能否发言，能发言时扣除令牌并记录内容，私聊只有发给同一个玩家的相同内容才算重复
+func (cl *ChatLimiter) Allow(channel mmopb.ChatChannel, targetId int32, content string, now int64) error {
+	cl.limitLock.Lock()
+	defer cl.limitLock.Unlock()
+
+	if now < cl.mutedUntil {
+		return ErrChatMuted
+	}
+	if cl.muteLevel > 0 && now-cl.lastMuteAt > chatConfig.MuteResetMs {
+		cl.muteLevel = 0
+	}
+
+	if channel != mmopb.ChatChannel_Chat_Channel_Whisper {
+		targetId = 0
+	}
+	content = strings.ToLower(strings.TrimSpace(content))
+	if last, ok := cl.recent[channel]; ok && last.content == content && last.targetId == targetId && now-last.at < chatConfig.DuplicateWindowMs {
+		return cl.violate(ErrChatDuplicate, now)
+	}
+
+	if limit, ok := channelLimits[channel]; ok {
+		bucket, ok := cl.buckets[channel]
+		if !ok {
+			bucket = &tokenBucket{tokens: float64(limit.Burst), last: now}
+			cl.buckets[channel] = bucket
+		}
+		if !bucket.take(limit.Burst, limit.RefillMs, now) {
+			return cl.violate(ErrChatTooFast, now)
+		}
+	}
+
+	cl.recent[channel] = &recentChat{content: content, targetId: targetId, at: now}
+	return nil
+}
+
+// AllowCommand 检查能否执行GM命令，GM命令单独限速，不计入违规也不受禁言影响
+func (cl *ChatLimiter) AllowCommand(now int64) error {
+	cl.limitLock.Lock()
+	defer cl.limitLock.Unlock()
+
+	if cl.commands == nil {
+		cl.commands = &tokenBucket{tokens: float64(GM_COMMAND_BURST), last: now}
+	}
+	if !cl.commands.take(GM_COMMAND_BURST, GM_COMMAND_REFILL_MS, now) {
+		return ErrChatTooFast
+	}
+	return nil
+}
+
+// violate 记录一次违规，时间窗口内违规次数达到上限时按档位禁言，调用方需持有limitLock
+func (cl *ChatLimiter) violate(err error, now int64) error {
+	if chatConfig.ViolationsPerMute <= 0 {
+		return err
+	}
+
+	violations := cl.violations[:0]
+	for _, at := range cl.violations {
+		if now-at < chatConfig.ViolationWindowMs {
+			violations = append(violations, at)
+		}
+	}
+	cl.violations = append(violations, now)
+	if len(cl.violations) < chatConfig.ViolationsPerMute {
+		return err
+	}
+
+	step := cl.muteLevel
+	if step >= len(chatConfig.MuteStepsMs) {
+		step = len(chatConfig.MuteStepsMs) - 1
+	}
+	cl.mute(now+chatConfig.MuteStepsMs[step], now)
+	cl.muteLevel++
+	cl.violations = nil
+	return ErrChatMuted
+}
+
+// mute 禁言到指定时间，调用方需持有limitLock
+func (cl *ChatLimiter) mute(until int64, now int64) {
+	cl.mutedUntil = until
+	cl.lastMuteAt = now
+}
+
+// Mute 禁言到指定时间，until不晚于now时解除禁言
+func (cl *ChatLimiter) Mute(until int64, now int64) {
+	cl.limitLock.Lock()
+	defer cl.limitLock.Unlock()
+
+	cl.mute(until, now)
+}
+
+// MuteRemain 禁言剩余时间(毫秒)
+func (cl *ChatLimiter) MuteRemain(now int64) int64 {
+	cl.limitLock.Lock()
+	defer cl.limitLock.Unlock()
+
+	if now >= cl.mutedUntil {
+		return 0
+	}
+	return cl.mutedUntil - now
+}
+
+// MuteState 禁言状态，用于存档
+func (cl *ChatLimiter) MuteState() (int64, int) {
+	cl.limitLock.Lock()
+	defer cl.limitLock.Unlock()
+
+	return cl.mutedUntil, cl.muteLevel
+}
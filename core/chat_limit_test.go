@@ -0,0 +1,97 @@
+package core
+
+import (
+	"testing"
+
+	"aoi_mmo_game/mmopb"
+)
+
+func TestChatLimiter_Allow(t *testing.T) {
+	world := mmopb.ChatChannel_Chat_Channel_World
+	channelLimits[world] = &ChannelLimit{Channel: world, Burst: 2, RefillMs: 1000}
+	chatConfig = &ChatConfig{
+		DuplicateWindowMs: 5000,
+		ViolationWindowMs: 10000,
+		ViolationsPerMute: 3,
+		MuteStepsMs:       []int64{1000, 5000},
+		MuteResetMs:       60000,
+	}
+	defer func() {
+		delete(channelLimits, world)
+		chatConfig = &ChatConfig{}
+	}()
+
+	cl := NewChatLimiter(0, 0)
+	steps := []struct {
+		now     int64
+		content string
+		want    error
+	}{
+		{0, "a", nil},
+		{0, "b", nil},
+		// 令牌用完
+		{0, "c", ErrChatTooFast},
+		// 忽略大小写和首尾空白后与上一条相同
+		{0, " B ", ErrChatDuplicate},
+		// 第三次违规，禁言第一档
+		{0, "d", ErrChatMuted},
+		{500, "e", ErrChatMuted},
+		// 禁言结束时恢复了一个令牌
+		{1000, "e", nil},
+		{1000, "f", ErrChatTooFast},
+		{1000, "e", ErrChatDuplicate},
+		// 第二次禁言使用第二档
+		{1000, "g", ErrChatMuted},
+		{5999, "h", ErrChatMuted},
+		{6000, "h", nil},
+	}
+	for i, s := range steps {
+		if err := cl.Allow(world, 0, s.content, s.now); err != s.want {
+			t.Fatalf("step %d: err = %v, want %v", i, err, s.want)
+		}
+	}
+	if until, level := cl.MuteState(); until != 6000 || level != 2 {
+		t.Fatalf("mute state = %d %d, want 6000 2", until, level)
+	}
+}
+
+func TestChatLimiter_AllowWhisper(t *testing.T) {
+	whisper := mmopb.ChatChannel_Chat_Channel_Whisper
+	chatConfig = &ChatConfig{DuplicateWindowMs: 5000}
+	defer func() {
+		chatConfig = &ChatConfig{}
+	}()
+
+	cl := NewChatLimiter(0, 0)
+	steps := []struct {
+		targetId int32
+		content  string
+		want     error
+	}{
+		{1, "hi", nil},
+		// 相同内容发给不同的玩家不算重复
+		{2, "hi", nil},
+		{2, "hi", ErrChatDuplicate},
+		{1, "hi", nil},
+	}
+	for i, s := range steps {
+		if err := cl.Allow(whisper, s.targetId, s.content, 0); err != s.want {
+			t.Fatalf("step %d: err = %v, want %v", i, err, s.want)
+		}
+	}
+}
+
+func TestChatLimiter_AllowCommand(t *testing.T) {
+	cl := NewChatLimiter(0, 0)
+	for i := int32(0); i < GM_COMMAND_BURST; i++ {
+		if err := cl.AllowCommand(0); err != nil {
+			t.Fatalf("command %d: %v", i, err)
+		}
+	}
+	if err := cl.AllowCommand(0); err != ErrChatTooFast {
+		t.Fatalf("want ErrChatTooFast, got %v", err)
+	}
+	if err := cl.AllowCommand(GM_COMMAND_REFILL_MS); err != nil {
+		t.Fatalf("command after refill: %v", err)
+	}
+}
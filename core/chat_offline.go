@@ -0,0 +1,96 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// offlineChatLock 保证玩家上线和保存离线私聊不会交错，避免消息在上线瞬间丢失
+var offlineChatLock sync.Mutex
+
+// storeOfflineWhisper 保存发给离线玩家的私聊，调用方需持有offlineChatLock。
+// 目标角色不存在时返回ErrTargetNotFound，被屏蔽时返回ErrBlocked，不保存或信箱已满时返回false
+func (p *Player) storeOfflineWhisper(targetPlayerId int32, content string, now int64) (bool, error) {
+	data, err := StorageObj.LoadPlayer(targetPlayerId)
+	if err == ErrDataNotFound {
+		return false, ErrTargetNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, playerId := range data.Blocked {
+		if playerId == p.PlayerId {
+			return false, ErrBlocked
+		}
+	}
+	if chatConfig.OfflineWhisperLimit <= 0 {
+		return false, nil
+	}
+
+	chats, err := StorageObj.LoadOfflineChats(targetPlayerId)
+	if err != nil && err != ErrDataNotFound {
+		return false, err
+	}
+	chats = dropExpiredChats(chats, now)
+	if len(chats) >= chatConfig.OfflineWhisperLimit {
+		return false, nil
+	}
+
+	chats = append(chats, &OfflineChatData{
+		SenderId:   p.PlayerId,
+		SenderName: p.Name,
+		Content:    content,
+		TimeMs:     now,
+	})
+	if err := StorageObj.SaveOfflineChats(targetPlayerId, chats); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SendOfflineChats 上线时补发离线期间收到的私聊，需要在加入世界管理器之后调用
+func (p *Player) SendOfflineChats() {
+	offlineChatLock.Lock()
+	chats, err := StorageObj.LoadOfflineChats(p.PlayerId)
+	if err == nil && len(chats) > 0 {
+		// 先清空再发送，存档失败时留到下次登录，避免重复收到
+		err = StorageObj.SaveOfflineChats(p.PlayerId, make([]*OfflineChatData, 0))
+	}
+	offlineChatLock.Unlock()
+
+	if err == ErrDataNotFound {
+		return
+	}
+	if err != nil {
+		fmt.Println("======> player id = ", p.PlayerId, " load offline chats error: ", err, " <======")
+		return
+	}
+
+	for _, chat := range dropExpiredChats(chats, nowMillis()) {
+		p.SendMessage(mmopb.SCMsgIdChat, &mmopb.ChatMessage{
+			Channel:    mmopb.ChatChannel_Chat_Channel_Whisper,
+			SenderId:   chat.SenderId,
+			SenderName: chat.SenderName,
+			TargetId:   p.PlayerId,
+			Content:    chat.Content,
+			TimeMs:     chat.TimeMs,
+			Offline:    true,
+		})
+	}
+}
+
+// dropExpiredChats 去掉超过保存时间的离线私聊
+func dropExpiredChats(chats []*OfflineChatData, now int64) []*OfflineChatData {
+	if chatConfig.OfflineWhisperExpireMs <= 0 {
+		return chats
+	}
+	kept := chats[:0]
+	for _, chat := range chats {
+		if now-chat.TimeMs < chatConfig.OfflineWhisperExpireMs {
+			kept = append(kept, chat)
+		}
+	}
+	return kept
+}
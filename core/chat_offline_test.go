@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestPlayer_storeOfflineWhisper(t *testing.T) {
+	storage := StorageObj
+	StorageObj = NewFileStorage(t.TempDir())
+	chatConfig = &ChatConfig{OfflineWhisperLimit: 2, OfflineWhisperExpireMs: 1000}
+	defer func() {
+		StorageObj = storage
+		chatConfig = &ChatConfig{}
+	}()
+
+	p := &Player{PlayerId: 1, Name: "Alice"}
+	if _, err := p.storeOfflineWhisper(2, "hi", 0); err != ErrTargetNotFound {
+		t.Fatalf("want ErrTargetNotFound, got %v", err)
+	}
+	if err := StorageObj.SavePlayer(&PlayerData{PlayerId: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := StorageObj.SavePlayer(&PlayerData{PlayerId: 3, Blocked: []int32{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.storeOfflineWhisper(3, "hi", 0); err != ErrBlocked {
+		t.Fatalf("want ErrBlocked, got %v", err)
+	}
+
+	// 信箱满了之后不再保存，过期的消息会腾出位置
+	for i, want := range []bool{true, true, false} {
+		if stored, err := p.storeOfflineWhisper(2, "hi", int64(i)); err != nil || stored != want {
+			t.Fatalf("store %d = %v, %v, want %v", i, stored, err, want)
+		}
+	}
+	if stored, err := p.storeOfflineWhisper(2, "later", 1000); err != nil || !stored {
+		t.Fatalf("store after expire = %v, %v", stored, err)
+	}
+
+	chats, err := StorageObj.LoadOfflineChats(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chats) != 2 || chats[1].Content != "later" || chats[1].SenderName != "Alice" {
+		t.Fatalf("offline chats = %v", chats)
+	}
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrPlayerDead     = errors.New("player is dead")
+	ErrOutOfRange     = errors.New("out of range")
+	ErrTargetNotFound = errors.New("target not found")
+)
+
+// Combatant 可参与战斗的实体，玩家和怪物共用同一套战斗逻辑
+type Combatant interface {
+	// GetEntityId 实体id，玩家为playerId，怪物为monsterId
+	GetEntityId() int32
+	// GetPos 实体在平面上的坐标
+	GetPos() (x float32, z float32)
+	// GetCombatUnit 实体的战斗属性
+	GetCombatUnit() *CombatUnit
+	// OnDeath 实体死亡时的回调
+	OnDeath(killer Combatant)
+}
+
+// BaseStats 基础战斗属性，来自职业或怪物模板
+type BaseStats struct {
+	MaxHP       int32   `json:"max_hp"`
+	MaxMP       int32   `json:"max_mp"`
+	Attack      int32   `json:"attack"`
+	Defense     int32   `json:"defense"`
+	AttackRange float32 `json:"attack_range"`
+}
+
+// CombatUnit 战斗单元，保存实体的战斗属性
+type CombatUnit struct {
+	HP          int32           // 当前血量
+	MaxHP       int32           // 最大血量
+	MP          int32           // 当前法力
+	MaxMP       int32           // 最大法力
+	Attack      int32           // 攻击力，包含buff加成
+	Defense     int32           // 防御力，包含buff加成
+	AttackRange float32         // 普通攻击距离
+	MoveSpeed   float32         // 移动速度，包含buff加成
+	base        BaseStats       // 基础战斗属性
+	equip       BaseStats       // 装备提供的属性
+	buffs       map[int32]*Buff // buff id -> 身上的buff
+	nextAttack  int64           // 下一次可以普通攻击的时间(unix毫秒)
+	casting     *castState      // 正在施放的技能，没有时为nil
+	cooldowns   map[int32]int64 // 技能id -> 冷却结束时间(unix毫秒)
+	combatLock  sync.Mutex      // 保护战斗属性的锁
+}
+
+// SetBaseStats 设置基础战斗属性，当前血量和法力不超过新的上限
+func (cu *CombatUnit) SetBaseStats(bs BaseStats) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	cu.base = bs
+	cu.recalcStats()
+}
+
+// SetEquipStats 设置装备提供的属性，当前血量和法力不超过新的上限
+func (cu *CombatUnit) SetEquipStats(bs BaseStats) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	cu.equip = bs
+	cu.recalcStats()
+}
+
+// IsDead 是否已死亡
+func (cu *CombatUnit) IsDead() bool {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+	return cu.HP <= 0
+}
+
+// Revive 复活，恢复满血满法力
+func (cu *CombatUnit) Revive() {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	cu.HP = cu.MaxHP
+	cu.MP = cu.MaxMP
+	cu.nextAttack = 0
+}
+
+// Heal 恢复血量和法力，不超过上限，已经死亡时无效
+func (cu *CombatUnit) Heal(hp, mp int32) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	if cu.HP <= 0 {
+		return
+	}
+	cu.HP += hp
+	if cu.HP > cu.MaxHP {
+		cu.HP = cu.MaxHP
+	}
+	cu.MP += mp
+	if cu.MP > cu.MaxMP {
+		cu.MP = cu.MaxMP
+	}
+}
+
+// StatsMsg 战斗属性消息
+func (cu *CombatUnit) StatsMsg() *mmopb.CombatStats {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+	return &mmopb.CombatStats{
+		Hp:        cu.HP,
+		MaxHp:     cu.MaxHP,
+		Mp:        cu.MP,
+		MaxMp:     cu.MaxMP,
+		MoveSpeed: cu.MoveSpeed,
+	}
+}
+
+// GetMoveSpeed 当前移动速度，包含buff加成
+func (cu *CombatUnit) GetMoveSpeed() float32 {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+	return cu.MoveSpeed
+}
+
+// TakeDamage 扣除血量，返回剩余血量以及是否因本次伤害死亡
+func (cu *CombatUnit) TakeDamage(damage int32) (hp int32, killed bool) {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	// 已经死亡的实体不再重复结算
+	if cu.HP <= 0 {
+		return 0, false
+	}
+	cu.HP -= damage
+	if cu.HP <= 0 {
+		cu.HP = 0
+		return 0, true
+	}
+	return cu.HP, false
+}
+
+// tryStartAttack 检查普通攻击间隔，可以攻击时记录下一次攻击时间
+func (cu *CombatUnit) tryStartAttack() bool {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	now := nowMillis()
+	if now < cu.nextAttack {
+		return false
+	}
+	cu.nextAttack = now + ATTACK_INTERVAL_MS
+	return true
+}
+
+// CalcDamage 伤害公式：攻击减去一半防御，上下浮动10%，有一定概率暴击，最少造成1点伤害
+func CalcDamage(attacker, defender *CombatUnit) (damage int32, critical bool) {
+	base := float64(attacker.Attack) - float64(defender.Defense)/2
+	base *= 0.9 + rand.Float64()*0.2
+	if rand.Float64() < CRITICAL_RATE {
+		base *= CRITICAL_MULTIPLE
+		critical = true
+	}
+	damage = int32(math.Round(base))
+	if damage < 1 {
+		damage = 1
+	}
+	return
+}
+
+// Distance 两个实体在平面上的距离
+func Distance(a, b Combatant) float32 {
+	ax, az := a.GetPos()
+	bx, bz := b.GetPos()
+	return distance(ax, az, bx, bz)
+}
+
+// Attack 普通攻击，校验通过后结算伤害并广播给目标周围的玩家
+func Attack(attacker Combatant, targetId int32) mmopb.ResultCode {
+	target := WorldMgrObj.GetCombatantById(targetId)
+	if target == nil || target.GetEntityId() == attacker.GetEntityId() || target.GetCombatUnit().IsStealthed() {
+		return mmopb.ResultCode_Result_Target_Not_Found
+	}
+
+	attackerUnit := attacker.GetCombatUnit()
+	if attackerUnit.IsDead() {
+		return mmopb.ResultCode_Result_Dead
+	}
+	if target.GetCombatUnit().IsDead() {
+		return mmopb.ResultCode_Result_Target_Dead
+	}
+	if !CanAttack(attacker, target) {
+		return mmopb.ResultCode_Result_Zone_No_Combat
+	}
+	// 坐标以服务器AOI中记录的为准，不信任客户端
+	if Distance(attacker, target) > attackerUnit.AttackRange {
+		return mmopb.ResultCode_Result_Out_Of_Range
+	}
+	if !attackerUnit.tryStartAttack() {
+		return mmopb.ResultCode_Result_Cooldown
+	}
+
+	// 主动攻击解除隐身
+	BreakStealth(attacker)
+
+	damage, critical := CalcDamage(attackerUnit, target.GetCombatUnit())
+	ApplyDamage(attacker, target, 0, damage, critical)
+	return mmopb.ResultCode_Result_Ok
+}
+
+// ApplyDamage 对目标造成伤害，广播伤害事件，目标死亡时广播死亡事件，skillId为0表示普通攻击
+func ApplyDamage(attacker, target Combatant, skillId int32, damage int32, critical bool) {
+	hp, killed := target.GetCombatUnit().TakeDamage(damage)
+
+	x, z := target.GetPos()
+	WorldMgrObj.BroadCastAround(x, z, mmopb.SCMsgIdDamage, &mmopb.Damage{
+		AttackerId: attacker.GetEntityId(),
+		TargetId:   target.GetEntityId(),
+		Damage:     damage,
+		Critical:   critical,
+		Hp:         hp,
+		SkillId:    skillId,
+	})
+
+	if killed {
+		// 死亡打断施法，清除身上的buff
+		CancelCast(target)
+		ClearBuffs(target)
+
+		WorldMgrObj.BroadCastAround(x, z, mmopb.SCMsgIdDeath, &mmopb.Death{
+			EntityId: target.GetEntityId(),
+			KillerId: attacker.GetEntityId(),
+		})
+		target.OnDeath(attacker)
+	}
+}
@@ -0,0 +1,16 @@
+package core
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// loadConfig 从策划数据目录读取json数据文件
+func loadConfig(name string, v interface{}) error {
+	data, err := ioutil.ReadFile(filepath.Join(CONF_DIR, name))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 const (
 	AOI_MIN_X  int = 85
 	AOI_MAX_X  int = 410
@@ -8,3 +10,88 @@ const (
 	AOI_MAX_Y  int = 400
 	AOI_CNTS_Y int = 20
 )
+
+const (
+	HEARTBEAT_INTERVAL time.Duration = 10 * time.Second // 服务器心跳探测间隔
+	HEARTBEAT_TIMEOUT  time.Duration = 30 * time.Second // 连接空闲超时时间，超时未收到任何消息则踢下线
+)
+
+const (
+	SAVE_DIR            string        = "./save"        // 玩家存档目录
+	SHUTDOWN_COUNTDOWN  int           = 30              // 停服倒计时秒数
+	SHUTDOWN_DRAIN_TIME time.Duration = 2 * time.Second // 停服前等待发送队列排空的最长时间
+)
+
+const (
+	MAX_CHARACTERS_PER_ACCOUNT int = 4     // 每个账号最多可创建的角色数量
+	PASSWORD_MIN_LEN           int = 6     // 密码最短长度
+	PASSWORD_MAX_LEN           int = 64    // 密码最长长度
+	PASSWORD_HASH_ROUNDS       int = 10000 // 密码哈希的迭代次数
+)
+
+const (
+	CONF_DIR string = "./conf" // 策划数据文件目录
+)
+
+const (
+	ATTACK_INTERVAL_MS int64   = 1000    // 普通攻击间隔(毫秒)
+	CRITICAL_RATE      float64 = 0.1     // 暴击概率
+	CRITICAL_MULTIPLE  float64 = 1.5     // 暴击伤害倍数
+	MONSTER_ID_BASE    int32   = 1 << 24 // 怪物id起始值，与playerId不重叠
+
+	SKILL_RANGE_TOLERANCE float32 = 2 // 单体技能施法结束时允许目标超出施法距离的容差
+	MOVE_SPEED            float32 = 6 // 基础移动速度(每秒)
+
+	MOVE_TOLERANCE       float32 = 1    // 移动距离校验允许的误差
+	MOVE_MAX_INTERVAL_MS int64   = 1000 // 移动距离校验最多按这么长的时间计算，停下再走时不能瞬移
+)
+
+const (
+	SCENE_TICK_INTERVAL time.Duration = 100 * time.Millisecond // 场景心跳间隔
+)
+
+const (
+	DEFAULT_SCENE_ID   int32 = 1     // 新角色所在的默认场景
+	MONSTER_RESPAWN_MS int64 = 30000 // 刷新点未配置时怪物的默认刷新间隔(毫秒)
+)
+
+const (
+	BAG_SIZE int = 30 // 背包格子数量
+
+	LOOT_ID_BASE      int32   = 1 << 28 // 掉落物id起始值，与playerId和monsterId不重叠
+	LOOT_PICKUP_RANGE float32 = 5       // 拾取距离
+	LOOT_SCATTER      float32 = 1.5     // 掉落物在怪物周围散开的范围
+	LOOT_OWNER_MS     int64   = 30000   // 击杀者归属保护时间(毫秒)
+	LOOT_DESPAWN_MS   int64   = 120000  // 掉落物在地面上保留的时间(毫秒)
+)
+
+const (
+	TRADE_RANGE             float32 = 10    // 交易双方的最大距离
+	TRADE_INVITE_TIMEOUT_MS int64   = 30000 // 交易邀请的有效时间(毫秒)
+)
+
+const (
+	NPC_TALK_RANGE    float32 = 5  // 和NPC对话、接取和交付任务的距离
+	MAX_ACTIVE_QUESTS int     = 20 // 同时进行的任务数量上限
+)
+
+const (
+	MAX_BLOCKED_PLAYERS int = 100 // 屏蔽列表的人数上限
+)
+
+const (
+	ACTION_INTERVAL_MS int64 = 1000 // 两次表情动作的最小间隔(毫秒)
+)
+
+const (
+	MAX_PARTY_MEMBERS        int           = 5                       // 队伍人数上限
+	PARTY_INVITE_TIMEOUT_MS  int64         = 30000                   // 组队邀请的有效时间(毫秒)
+	PARTY_SHARE_RANGE        float32       = 50                      // 分享经验和掉落的最大距离
+	PARTY_RECONNECT_GRACE_MS int64         = 60000                   // 断线后保留队伍位置的时间(毫秒)
+	PARTY_SYNC_INTERVAL      time.Duration = 1000 * time.Millisecond // 同步队伍成员状态的间隔
+)
+
+const (
+	MAX_FRIENDS               int   = 100   // 好友人数上限
+	FRIEND_REQUEST_TIMEOUT_MS int64 = 60000 // 好友请求的有效时间(毫秒)
+)
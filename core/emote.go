@@ -0,0 +1,135 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrActionNotFound = errors.New("action not found")
+	ErrActionTooFast  = errors.New("action too fast")
+)
+
+// ACTION_STOP 停止当前的循环动作
+const ACTION_STOP int32 = 0
+
+// EmoteTemplate 表情动作模板
+type EmoteTemplate struct {
+	EmoteId int32  `json:"emote_id"` // 动作id
+	Name    string `json:"name"`     // 动作名称
+	Loop    bool   `json:"loop"`     // 是否循环播放，例如坐下，移动、传送或死亡时结束
+}
+
+// EmoteConfig 表情动作数据文件
+type EmoteConfig struct {
+	Emotes []*EmoteTemplate `json:"emotes"`
+}
+
+// emoteTemplates 全部表情动作
+var emoteTemplates = make(map[int32]*EmoteTemplate)
+
+// LoadEmotes 读取表情动作数据文件
+func LoadEmotes() error {
+	config := &EmoteConfig{}
+	if err := loadConfig("emotes.json", config); err != nil {
+		return err
+	}
+
+	for _, emote := range config.Emotes {
+		if emote.EmoteId == ACTION_STOP {
+			return fmt.Errorf("emote id %d is reserved", emote.EmoteId)
+		}
+		emoteTemplates[emote.EmoteId] = emote
+	}
+	return nil
+}
+
+// PlayAction 播放表情动作并广播给周围玩家，失败时告知原因
+func (p *Player) PlayAction(action int32) {
+	if err := p.playAction(action, nowMillis()); err != nil {
+		p.SendMessage(mmopb.SCMsgIdActionResult, &mmopb.ActionResult{
+			Result: resultCodeOf(err),
+			Action: action,
+		})
+		return
+	}
+	fmt.Println("======> player id = ", p.PlayerId, " play action ", action, " <======")
+	p.broadCastAction(action)
+}
+
+func (p *Player) playAction(action int32, now int64) error {
+	if p.IsDead() {
+		return ErrPlayerDead
+	}
+
+	p.actionLock.Lock()
+	defer p.actionLock.Unlock()
+
+	// 停止循环动作不受频率限制
+	if action == ACTION_STOP {
+		if p.loopAction == ACTION_STOP {
+			return ErrActionNotFound
+		}
+		p.loopAction = ACTION_STOP
+		return nil
+	}
+
+	emote, ok := emoteTemplates[action]
+	if !ok {
+		return ErrActionNotFound
+	}
+	if now-p.lastActionAt < ACTION_INTERVAL_MS {
+		return ErrActionTooFast
+	}
+	p.lastActionAt = now
+	p.loopAction = ACTION_STOP
+	if emote.Loop {
+		p.loopAction = action
+	}
+	return nil
+}
+
+// actionMsg 动作广播消息
+func (p *Player) actionMsg(action int32) *mmopb.BroadCast {
+	return &mmopb.BroadCast{
+		PlayerId: p.PlayerId,
+		Type:     mmopb.BroadCastType_Player_Action,
+		Data: &mmopb.BroadCast_Action{
+			Action: action,
+		},
+	}
+}
+
+// broadCastAction 向周围玩家广播动作，隐身时只发给自己
+func (p *Player) broadCastAction(action int32) {
+	msg := p.actionMsg(action)
+	if p.IsStealthed() {
+		p.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+		return
+	}
+	for _, player := range p.GetSurroundingPlayers() {
+		if player != nil {
+			player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+		}
+	}
+}
+
+// stopLoopAction 移动、传送或死亡时结束循环动作，客户端根据这些事件自行停止播放
+func (p *Player) stopLoopAction() {
+	p.actionLock.Lock()
+	p.loopAction = ACTION_STOP
+	p.actionLock.Unlock()
+}
+
+// SendLoopAction 把玩家正在播放的循环动作同步给刚看到他的玩家
+func SendLoopAction(entity *Player, player *Player) {
+	entity.actionLock.Lock()
+	action := entity.loopAction
+	entity.actionLock.Unlock()
+
+	if action != ACTION_STOP {
+		player.SendMessage(mmopb.SCMsgIdBroadCast, entity.actionMsg(action))
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import "testing"
+
+func TestPlayer_playAction(t *testing.T) {
+	emoteTemplates[901] = &EmoteTemplate{EmoteId: 901}
+	emoteTemplates[902] = &EmoteTemplate{EmoteId: 902, Loop: true}
+	defer delete(emoteTemplates, 901)
+	defer delete(emoteTemplates, 902)
+
+	p := &Player{}
+	p.MaxHP = 100
+	p.HP = 100
+
+	if err := p.playAction(999, 0); err != ErrActionNotFound {
+		t.Fatalf("want ErrActionNotFound, got %v", err)
+	}
+	if err := p.playAction(902, 1000); err != nil || p.loopAction != 902 {
+		t.Fatalf("loop action = %d, %v", p.loopAction, err)
+	}
+	if err := p.playAction(901, 1500); err != ErrActionTooFast {
+		t.Fatalf("want ErrActionTooFast, got %v", err)
+	}
+
+	// 播放非循环动作会结束循环动作
+	if err := p.playAction(901, 2000); err != nil || p.loopAction != ACTION_STOP {
+		t.Fatalf("loop action = %d, %v", p.loopAction, err)
+	}
+	if err := p.playAction(ACTION_STOP, 2000); err != ErrActionNotFound {
+		t.Fatalf("stop without loop action: want ErrActionNotFound, got %v", err)
+	}
+
+	p.HP = 0
+	if err := p.playAction(901, 5000); err != ErrPlayerDead {
+		t.Fatalf("want ErrPlayerDead, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var ErrCannotEquip = errors.New("cannot equip")
+
+// EquipData 装备存档数据
+type EquipData struct {
+	Slot   mmopb.EquipSlot `json:"slot"`    // 装备部位
+	ItemId int32           `json:"item_id"` // 物品id
+}
+
+// loadEquips 从存档恢复身上的装备，不广播，用于上线前
+func (p *Player) loadEquips(datas []*EquipData) {
+	p.equipLock.Lock()
+	for _, data := range datas {
+		template := GetItemTemplate(data.ItemId)
+		if template == nil || template.Type != ITEM_TYPE_EQUIPMENT || template.EquipSlot != data.Slot {
+			fmt.Println("load equip slot = ", data.Slot, " item id = ", data.ItemId, " invalid")
+			continue
+		}
+		p.equips[data.Slot] = data.ItemId
+	}
+	p.equipLock.Unlock()
+
+	p.SetEquipStats(p.equipStats())
+}
+
+// equipSnapshot 装备存档数据
+func (p *Player) equipSnapshot() []*EquipData {
+	p.equipLock.Lock()
+	defer p.equipLock.Unlock()
+
+	datas := make([]*EquipData, 0, len(p.equips))
+	for slot, itemId := range p.equips {
+		datas = append(datas, &EquipData{Slot: slot, ItemId: itemId})
+	}
+	return datas
+}
+
+// equipVisuals 身上装备的外观，按部位排序
+func (p *Player) equipVisuals() []*mmopb.EquipVisual {
+	p.equipLock.Lock()
+	defer p.equipLock.Unlock()
+
+	visuals := make([]*mmopb.EquipVisual, 0, len(p.equips))
+	for slot, itemId := range p.equips {
+		visuals = append(visuals, &mmopb.EquipVisual{
+			Slot:    slot,
+			ItemId:  itemId,
+			ModelId: GetItemTemplate(itemId).ModelId,
+		})
+	}
+	sort.Slice(visuals, func(i, j int) bool {
+		return visuals[i].Slot < visuals[j].Slot
+	})
+	return visuals
+}
+
+// equipStats 身上全部装备提供的属性之和
+func (p *Player) equipStats() BaseStats {
+	p.equipLock.Lock()
+	defer p.equipLock.Unlock()
+
+	var stats BaseStats
+	for _, itemId := range p.equips {
+		s := GetItemTemplate(itemId).Stats
+		stats.MaxHP += s.MaxHP
+		stats.MaxMP += s.MaxMP
+		stats.Attack += s.Attack
+		stats.Defense += s.Defense
+		stats.AttackRange += s.AttackRange
+	}
+	return stats
+}
+
+// EquipItem 穿戴背包格子中的装备，部位上已有的装备放回背包
+func (p *Player) EquipItem(slot int32) {
+	if err := p.equipItem(slot); err != nil {
+		p.SendMessage(mmopb.SCMsgIdEquipResult, &mmopb.EquipResult{
+			Result: resultCodeOf(err),
+			Slot:   slot,
+		})
+	}
+}
+
+func (p *Player) equipItem(slot int32) error {
+	stack := p.Bag.GetSlot(slot)
+	if stack == nil {
+		return ErrItemNotFound
+	}
+	template := GetItemTemplate(stack.ItemId)
+	if !template.CanEquip(p.Class, p.Level) {
+		return ErrCannotEquip
+	}
+
+	p.equipLock.Lock()
+	var old *ItemStack
+	if itemId, ok := p.equips[template.EquipSlot]; ok {
+		old = &ItemStack{ItemId: itemId, Count: 1}
+	}
+	changed, err := p.Bag.ReplaceAt(slot, template.ItemId, old)
+	if err == nil {
+		p.equips[template.EquipSlot] = template.ItemId
+	}
+	p.equipLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.SendInventoryChange(changed)
+	p.onEquipChanged()
+	return nil
+}
+
+// UnequipItem 卸下装备放回背包
+func (p *Player) UnequipItem(equipSlot mmopb.EquipSlot) {
+	if err := p.unequipItem(equipSlot); err != nil {
+		p.SendMessage(mmopb.SCMsgIdEquipResult, &mmopb.EquipResult{
+			Result:    resultCodeOf(err),
+			EquipSlot: equipSlot,
+		})
+	}
+}
+
+func (p *Player) unequipItem(equipSlot mmopb.EquipSlot) error {
+	p.equipLock.Lock()
+	itemId, ok := p.equips[equipSlot]
+	if !ok {
+		p.equipLock.Unlock()
+		return ErrItemNotFound
+	}
+	changed, err := p.Bag.AddItems(&ItemStack{ItemId: itemId, Count: 1})
+	if err == nil {
+		delete(p.equips, equipSlot)
+	}
+	p.equipLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.SendInventoryChange(changed)
+	p.onEquipChanged()
+	return nil
+}
+
+// onEquipChanged 装备变化后重新计算属性，并通过显示数据广播让周围玩家看到新的外观
+func (p *Player) onEquipChanged() {
+	p.SetEquipStats(p.equipStats())
+	broadCastStats(p)
+	p.BroadCastProfile()
+}
@@ -0,0 +1,282 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrFriendListFull = errors.New("friend list full")
+	ErrAlreadyFriend  = errors.New("already friend")
+	ErrNotFriend      = errors.New("not friend")
+)
+
+// friendLock 保护全部玩家的好友列表和好友请求，好友关系总是同时修改双方
+var friendLock sync.Mutex
+
+// loadFriends 恢复存档中的好友列表
+func (p *Player) loadFriends(playerIds []int32) {
+	for _, playerId := range playerIds {
+		p.friends[playerId] = true
+	}
+}
+
+// friendsSnapshot 好友列表，按id排序，用于存档和同步
+func (p *Player) friendsSnapshot() []int32 {
+	friendLock.Lock()
+	defer friendLock.Unlock()
+
+	playerIds := make([]int32, 0, len(p.friends))
+	for playerId := range p.friends {
+		playerIds = append(playerIds, playerId)
+	}
+	sort.Slice(playerIds, func(i, j int) bool {
+		return playerIds[i] < playerIds[j]
+	})
+	return playerIds
+}
+
+// isFriend 是否是好友
+func (p *Player) isFriend(playerId int32) bool {
+	friendLock.Lock()
+	defer friendLock.Unlock()
+
+	return p.friends[playerId]
+}
+
+// friendInfoMsg 自己在好友列表中显示的信息
+func (p *Player) friendInfoMsg(online bool) *mmopb.FriendInfo {
+	return &mmopb.FriendInfo{
+		PlayerId: p.PlayerId,
+		Name:     p.Name,
+		Class:    p.Class,
+		Level:    p.Level,
+		Online:   online,
+		SceneId:  p.SceneId,
+	}
+}
+
+// friendInfo 好友的信息，离线时优先使用缓存，没有缓存时从存档读取。对方不存在或者已经删除了自己时返回false
+func (p *Player) friendInfo(friendId int32) (*mmopb.FriendInfo, bool) {
+	if friend := WorldMgrObj.GetPlayerById(friendId); friend != nil {
+		return friend.friendInfoMsg(!friend.IsLeaving()), friend.isFriend(p.PlayerId)
+	}
+	// 离线的玩家不能修改好友列表，缓存的好友一定还是互为好友
+	friendLock.Lock()
+	info, ok := p.friendInfos[friendId]
+	friendLock.Unlock()
+	if ok {
+		return info, true
+	}
+
+	data, err := StorageObj.LoadPlayer(friendId)
+	if err != nil {
+		// 读档失败时先保留好友，只有确定对方不存在才移除
+		return &mmopb.FriendInfo{PlayerId: friendId}, err != ErrDataNotFound
+	}
+	mutual := false
+	for _, playerId := range data.Friends {
+		if playerId == p.PlayerId {
+			mutual = true
+			break
+		}
+	}
+	info = &mmopb.FriendInfo{
+		PlayerId: data.PlayerId,
+		Name:     data.Name,
+		Class:    data.Class,
+		Level:    data.Level,
+		SceneId:  data.SceneId,
+	}
+	if mutual {
+		p.cacheFriendInfo(info)
+	}
+	return info, mutual
+}
+
+// cacheFriendInfo 缓存离线好友的显示信息，之后同步列表时不用再读档
+func (p *Player) cacheFriendInfo(info *mmopb.FriendInfo) {
+	friendLock.Lock()
+	defer friendLock.Unlock()
+
+	if p.friends[info.PlayerId] {
+		p.friendInfos[info.PlayerId] = info
+	}
+}
+
+// SendFriendList 同步好友列表。离线好友的信息只在上线后第一次同步时读档，之后使用缓存。
+// 对方离线时删除好友只会修改自己的列表，所以这里顺便移除已经不再互为好友的玩家
+func (p *Player) SendFriendList() {
+	msg := &mmopb.SyncFriendList{}
+	for _, friendId := range p.friendsSnapshot() {
+		info, ok := p.friendInfo(friendId)
+		if !ok {
+			friendLock.Lock()
+			delete(p.friends, friendId)
+			delete(p.friendInfos, friendId)
+			friendLock.Unlock()
+			fmt.Println("======> player id = ", p.PlayerId, " drop friend ", friendId, " <======")
+			continue
+		}
+		msg.Friends = append(msg.Friends, info)
+	}
+	p.SendMessage(mmopb.SCMsgIdSyncFriendList, msg)
+}
+
+// NotifyFriendPresence 通知在线好友自己上线、下线或者显示信息变化，等级和所在场景变化时也需要调用。
+// 下线时在好友那里缓存自己的信息
+func (p *Player) NotifyFriendPresence(online bool) {
+	msg := &mmopb.FriendPresence{
+		Friend: p.friendInfoMsg(online),
+	}
+	for _, friendId := range p.friendsSnapshot() {
+		friend := WorldMgrObj.GetPlayerById(friendId)
+		if friend == nil || !friend.isFriend(p.PlayerId) {
+			continue
+		}
+		if !online {
+			friend.cacheFriendInfo(msg.Friend)
+		}
+		friend.SendMessage(mmopb.SCMsgIdFriendPresence, msg)
+	}
+}
+
+// sendFriendResult 告知好友操作失败的原因
+func (p *Player) sendFriendResult(err error, targetId int32) {
+	if err == nil {
+		return
+	}
+	p.SendMessage(mmopb.SCMsgIdFriendResult, &mmopb.FriendResult{
+		Result:   resultCodeOf(err),
+		TargetId: targetId,
+	})
+}
+
+// addFriends 双方互相加为好友，调用方需持有friendLock
+func addFriends(a, b *Player) error {
+	delete(a.friendReqs, b.PlayerId)
+	delete(b.friendReqs, a.PlayerId)
+	if len(a.friends) >= MAX_FRIENDS || len(b.friends) >= MAX_FRIENDS {
+		return ErrFriendListFull
+	}
+	a.friends[b.PlayerId] = true
+	b.friends[a.PlayerId] = true
+	fmt.Println("======> player id = ", a.PlayerId, " and ", b.PlayerId, " become friends <======")
+	return nil
+}
+
+// RequestFriend 请求添加好友，对方需要在线
+func (p *Player) RequestFriend(targetId int32) {
+	p.sendFriendResult(p.requestFriend(targetId), targetId)
+}
+
+func (p *Player) requestFriend(targetId int32) error {
+	target := WorldMgrObj.GetPlayerById(targetId)
+	if target == nil || target == p || target.IsLeaving() {
+		return ErrTargetNotFound
+	}
+	// 被对方屏蔽时自动拒绝
+	if target.IsBlocking(p.PlayerId) {
+		return ErrBlocked
+	}
+
+	friendLock.Lock()
+	if p.friends[targetId] {
+		friendLock.Unlock()
+		return ErrAlreadyFriend
+	}
+	// 对方也请求过添加自己时直接成为好友
+	if at, ok := p.friendReqs[targetId]; ok && nowMillis()-at <= FRIEND_REQUEST_TIMEOUT_MS {
+		err := addFriends(p, target)
+		friendLock.Unlock()
+		if err != nil {
+			return err
+		}
+		p.SendFriendList()
+		target.SendFriendList()
+		return nil
+	}
+	if len(p.friends) >= MAX_FRIENDS {
+		friendLock.Unlock()
+		return ErrFriendListFull
+	}
+	target.friendReqs[p.PlayerId] = nowMillis()
+	friendLock.Unlock()
+
+	target.SendMessage(mmopb.SCMsgIdFriendInvitation, &mmopb.FriendInvitation{
+		RequesterId:   p.PlayerId,
+		RequesterName: p.Name,
+	})
+	return nil
+}
+
+// RespondFriend 回应好友请求，拒绝时通知请求者
+func (p *Player) RespondFriend(requesterId int32, accept bool) {
+	p.sendFriendResult(p.respondFriend(requesterId, accept), requesterId)
+}
+
+func (p *Player) respondFriend(requesterId int32, accept bool) error {
+	friendLock.Lock()
+	at, ok := p.friendReqs[requesterId]
+	delete(p.friendReqs, requesterId)
+	if !ok || nowMillis()-at > FRIEND_REQUEST_TIMEOUT_MS {
+		friendLock.Unlock()
+		return ErrTargetNotFound
+	}
+
+	requester := WorldMgrObj.GetPlayerById(requesterId)
+	if requester == nil || requester.IsLeaving() {
+		friendLock.Unlock()
+		return ErrTargetNotFound
+	}
+	if !accept {
+		friendLock.Unlock()
+		requester.sendFriendResult(ErrInviteDeclined, p.PlayerId)
+		return nil
+	}
+	if p.friends[requesterId] {
+		friendLock.Unlock()
+		return ErrAlreadyFriend
+	}
+	err := addFriends(p, requester)
+	friendLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.SendFriendList()
+	requester.SendFriendList()
+	return nil
+}
+
+// RemoveFriend 删除好友，对方不在线时等对方上线同步列表时再从对方的列表中移除
+func (p *Player) RemoveFriend(friendId int32) {
+	p.sendFriendResult(p.removeFriend(friendId), friendId)
+}
+
+func (p *Player) removeFriend(friendId int32) error {
+	friendLock.Lock()
+	if !p.friends[friendId] {
+		friendLock.Unlock()
+		return ErrNotFriend
+	}
+	delete(p.friends, friendId)
+	delete(p.friendInfos, friendId)
+	friend := WorldMgrObj.GetPlayerById(friendId)
+	if friend != nil {
+		delete(friend.friends, p.PlayerId)
+		delete(friend.friendInfos, p.PlayerId)
+	}
+	friendLock.Unlock()
+
+	fmt.Println("======> player id = ", p.PlayerId, " remove friend ", friendId, " <======")
+	p.SendFriendList()
+	if friend != nil {
+		friend.SendFriendList()
+	}
+	return nil
+}
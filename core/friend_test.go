@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"aoi_mmo_game/mmopb"
+)
+
+func newFriendTestPlayer(playerId int32) *Player {
+	return &Player{
+		PlayerId:    playerId,
+		friends:     make(map[int32]bool),
+		friendReqs:  make(map[int32]int64),
+		friendInfos: make(map[int32]*mmopb.FriendInfo),
+	}
+}
+
+func TestAddFriends(t *testing.T) {
+	a, b := newFriendTestPlayer(1), newFriendTestPlayer(2)
+	a.friendReqs[2] = 1000
+	b.friendReqs[1] = 1000
+
+	if err := addFriends(a, b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.isFriend(2) || !b.isFriend(1) {
+		t.Fatal("friendship not mutual")
+	}
+	if len(a.friendReqs) != 0 || len(b.friendReqs) != 0 {
+		t.Fatal("pending requests not cleared")
+	}
+
+	// 任意一方好友已满时不能添加
+	c := newFriendTestPlayer(3)
+	for i := 0; i < MAX_FRIENDS; i++ {
+		c.friends[int32(100+i)] = true
+	}
+	if err := addFriends(a, c); err != ErrFriendListFull {
+		t.Fatalf("want ErrFriendListFull, got %v", err)
+	}
+	if a.isFriend(3) {
+		t.Fatal("friend added with full list")
+	}
+}
+
+func TestPlayer_friendsSnapshot(t *testing.T) {
+	p := newFriendTestPlayer(1)
+	p.loadFriends([]int32{9, 3, 5})
+
+	got := p.friendsSnapshot()
+	want := []int32{3, 5, 9}
+	if len(got) != len(want) {
+		t.Fatalf("snapshot = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("snapshot = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPlayer_friendInfoCache(t *testing.T) {
+	p := newFriendTestPlayer(900001)
+	p.friends[900002] = true
+
+	// 不是好友的信息不缓存
+	p.cacheFriendInfo(&mmopb.FriendInfo{PlayerId: 900003, Name: "stranger"})
+	if _, ok := p.friendInfos[900003]; ok {
+		t.Fatal("stranger info cached")
+	}
+
+	// 有缓存时不读档
+	p.cacheFriendInfo(&mmopb.FriendInfo{PlayerId: 900002, Name: "friend", Level: 7})
+	info, ok := p.friendInfo(900002)
+	if !ok || info.Name != "friend" || info.Level != 7 || info.Online {
+		t.Fatalf("friend info = %+v, %v", info, ok)
+	}
+}
@@ -0,0 +1,311 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrGmUnknownCommand = errors.New("gm command not found")
+	ErrGmBadArgs        = errors.New("gm command bad args")
+	ErrGmNoPermission   = errors.New("gm no permission")
+)
+
+// GM权限等级，高等级可以使用低等级的全部命令
+const (
+	GM_LEVEL_NONE   int32 = 0 // 普通玩家
+	GM_LEVEL_HELPER int32 = 1 // 客服：公告、禁言、踢人
+	GM_LEVEL_ADMIN  int32 = 2 // 管理员：传送、刷怪、改等级
+)
+
+// GM_COMMAND_PREFIX GM在聊天中以该前缀开头的消息按命令执行
+const GM_COMMAND_PREFIX = "/"
+
+const (
+	GM_COMMAND_BURST     int32 = 5    // GM命令连续执行的次数上限
+	GM_COMMAND_REFILL_MS int64 = 1000 // 每隔多久恢复一次GM命令次数(毫秒)
+)
+
+// GmAccount GM账号的权限，只有从允许的地址登录时才有GM权限
+type GmAccount struct {
+	Level int32    `json:"level"` // 权限等级
+	Ips   []string `json:"ips"`   // 允许的来源IP或网段，如"127.0.0.1"、"10.0.0.0/8"，为空时没有GM权限
+}
+
+// GmConfig GM数据文件
+type GmConfig struct {
+	Accounts map[string]*GmAccount `json:"accounts"`  // 账号 -> 权限
+	AuditLog string                `json:"audit_log"` // 审计日志文件，为空时输出到标准输出
+}
+
+// gmAccount 解析后的GM账号权限
+type gmAccount struct {
+	level int32        // 权限等级
+	nets  []*net.IPNet // 允许的来源网段
+}
+
+var (
+	gmAccounts = make(map[string]*gmAccount) // 账号 -> 权限
+	gmAudit    = log.New(os.Stdout, "[GM] ", log.LstdFlags)
+)
+
+// LoadGm 读取GM数据文件，打开审计日志
+func LoadGm() error {
+	config := &GmConfig{}
+	if err := loadConfig("gm.json", config); err != nil {
+		return err
+	}
+
+	accounts := make(map[string]*gmAccount, len(config.Accounts))
+	for account, gm := range config.Accounts {
+		if gm.Level < GM_LEVEL_NONE || gm.Level > GM_LEVEL_ADMIN {
+			return fmt.Errorf("gm account %s level %d invalid", account, gm.Level)
+		}
+		nets, err := parseGmIps(gm.Ips)
+		if err != nil {
+			return fmt.Errorf("gm account %s: %v", account, err)
+		}
+		accounts[account] = &gmAccount{level: gm.Level, nets: nets}
+	}
+	gmAccounts = accounts
+
+	if config.AuditLog != "" {
+		if err := os.MkdirAll(filepath.Dir(config.AuditLog), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(config.AuditLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		gmAudit = log.New(file, "", log.LstdFlags)
+	}
+	return nil
+}
+
+// parseGmIps 解析允许的来源IP或网段，单个IP按只包含它自己的网段处理
+func parseGmIps(ips []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(ips))
+	for _, ip := range ips {
+		if !strings.Contains(ip, "/") {
+			parsed := net.ParseIP(ip)
+			if parsed == nil {
+				return nil, fmt.Errorf("ip %q invalid", ip)
+			}
+			bits := 8 * net.IPv6len
+			if parsed.To4() != nil {
+				parsed, bits = parsed.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: parsed, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(ip)
+		if err != nil {
+			return nil, fmt.Errorf("ip %q invalid", ip)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// GmLevelOf 账号的GM权限等级，登录地址不在账号允许的来源中时没有GM权限。
+// 账号需要先通过密码验证，GM权限不能只凭账号名获得
+func GmLevelOf(account string, addr net.Addr) int32 {
+	gm, ok := gmAccounts[account]
+	if !ok || addr == nil {
+		return GM_LEVEL_NONE
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return GM_LEVEL_NONE
+	}
+	for _, ipNet := range gm.nets {
+		if ipNet.Contains(ip) {
+			return gm.level
+		}
+	}
+	return GM_LEVEL_NONE
+}
+
+// GmCommand GM命令
+type GmCommand struct {
+	Name    string                                         // 命令名，不含前缀
+	Level   int32                                          // 需要的权限等级
+	Usage   string                                         // 参数说明
+	MinArgs int                                            // 最少参数个数
+	Handler func(gm *Player, args *GmArgs) (string, error) // 执行命令，返回给GM的输出
+}
+
+var (
+	gmCommands    = make(map[string]*GmCommand) // 命令名 -> 命令
+	gmCommandLock sync.RWMutex                  // 保护gmCommands的读写锁
+)
+
+// RegisterGmCommand 注册GM命令，各系统可以在初始化时注册自己的命令
+func RegisterGmCommand(cmd *GmCommand) {
+	gmCommandLock.Lock()
+	gmCommands[cmd.Name] = cmd
+	gmCommandLock.Unlock()
+}
+
+// gmCommandsFor 权限等级能使用的命令，按名称排序
+func gmCommandsFor(level int32) []*GmCommand {
+	gmCommandLock.RLock()
+	defer gmCommandLock.RUnlock()
+
+	cmds := make([]*GmCommand, 0, len(gmCommands))
+	for _, cmd := range gmCommands {
+		if cmd.Level <= level {
+			cmds = append(cmds, cmd)
+		}
+	}
+	sort.Slice(cmds, func(i, j int) bool {
+		return cmds[i].Name < cmds[j].Name
+	})
+	return cmds
+}
+
+// GmArgs 命令参数，解析失败时记录第一个错误，由命令在执行前检查
+type GmArgs struct {
+	args []string
+	err  error
+}
+
+// Len 参数个数
+func (a *GmArgs) Len() int {
+	return len(a.args)
+}
+
+// Err 解析参数时的第一个错误
+func (a *GmArgs) Err() error {
+	return a.err
+}
+
+// fail 记录解析错误
+func (a *GmArgs) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// Int 第i个参数按整数解析
+func (a *GmArgs) Int(i int) int32 {
+	if i >= len(a.args) {
+		a.fail(ErrGmBadArgs)
+		return 0
+	}
+	v, err := strconv.ParseInt(a.args[i], 10, 32)
+	if err != nil {
+		a.fail(ErrGmBadArgs)
+	}
+	return int32(v)
+}
+
+// IntOr 第i个参数按整数解析，没有时返回默认值
+func (a *GmArgs) IntOr(i int, def int32) int32 {
+	if i >= len(a.args) {
+		return def
+	}
+	return a.Int(i)
+}
+
+// Float 第i个参数按小数解析
+func (a *GmArgs) Float(i int) float32 {
+	if i >= len(a.args) {
+		a.fail(ErrGmBadArgs)
+		return 0
+	}
+	v, err := strconv.ParseFloat(a.args[i], 32)
+	if err != nil {
+		a.fail(ErrGmBadArgs)
+	}
+	return float32(v)
+}
+
+// Rest 从第i个参数开始的剩余内容，用空格连接
+func (a *GmArgs) Rest(i int) string {
+	if i >= len(a.args) {
+		a.fail(ErrGmBadArgs)
+		return ""
+	}
+	return strings.Join(a.args[i:], " ")
+}
+
+// Player 第i个参数作为在线玩家的id
+func (a *GmArgs) Player(i int) *Player {
+	playerId := a.Int(i)
+	if a.err != nil {
+		return nil
+	}
+	player := WorldMgrObj.GetPlayerById(playerId)
+	if player == nil {
+		a.fail(ErrTargetNotFound)
+	}
+	return player
+}
+
+// PlayerOr 第i个参数作为在线玩家的id，没有时返回默认玩家
+func (a *GmArgs) PlayerOr(i int, def *Player) *Player {
+	if i >= len(a.args) {
+		return def
+	}
+	return a.Player(i)
+}
+
+// RunGmCommand 执行GM命令，结果发回给GM，无论成功与否都写入审计日志
+func (p *Player) RunGmCommand(line string) {
+	fields := strings.Fields(strings.TrimPrefix(line, GM_COMMAND_PREFIX))
+	name := ""
+	if len(fields) > 0 {
+		name = strings.ToLower(fields[0])
+	}
+	output, err := p.runGmCommand(name, fields)
+
+	gmAudit.Printf("account=%s player_id=%d name=%s level=%d command=%q result=%s output=%q",
+		p.account, p.PlayerId, p.Name, p.gmLevel, line, resultCodeOf(err), output)
+	p.SendMessage(mmopb.SCMsgIdGmResult, &mmopb.GmCommandResult{
+		Result:  resultCodeOf(err),
+		Command: name,
+		Output:  output,
+	})
+}
+
+func (p *Player) runGmCommand(name string, fields []string) (string, error) {
+	gmCommandLock.RLock()
+	cmd, ok := gmCommands[name]
+	gmCommandLock.RUnlock()
+
+	if !ok {
+		return "", ErrGmUnknownCommand
+	}
+	if p.gmLevel < cmd.Level {
+		return "", ErrGmNoPermission
+	}
+	args := &GmArgs{args: fields[1:]}
+	if args.Len() < cmd.MinArgs {
+		return cmd.usage(), ErrGmBadArgs
+	}
+	output, err := cmd.Handler(p, args)
+	if err == ErrGmBadArgs {
+		output = cmd.usage()
+	}
+	return output, err
+}
+
+// usage 命令的用法说明
+func (cmd *GmCommand) usage() string {
+	return strings.TrimSpace(GM_COMMAND_PREFIX + cmd.Name + " " + cmd.Usage)
+}
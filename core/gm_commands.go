@@ -0,0 +1,170 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"aoi_mmo_game/mmopb"
+)
+
+const (
+	GM_SPAWN_MAX_COUNT int32 = 20 // 一次最多刷出的怪物数量
+)
+
+func init() {
+	RegisterGmCommand(&GmCommand{
+		Name:    "help",
+		Level:   GM_LEVEL_HELPER,
+		Handler: gmHelp,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "announce",
+		Level:   GM_LEVEL_HELPER,
+		Usage:   "<内容>",
+		MinArgs: 1,
+		Handler: gmAnnounce,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "kick",
+		Level:   GM_LEVEL_HELPER,
+		Usage:   "<玩家id>",
+		MinArgs: 1,
+		Handler: gmKick,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "mute",
+		Level:   GM_LEVEL_HELPER,
+		Usage:   "<玩家id> <分钟，0解除禁言>",
+		MinArgs: 2,
+		Handler: gmMute,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "teleport",
+		Level:   GM_LEVEL_ADMIN,
+		Usage:   "<x> <z> [玩家id]",
+		MinArgs: 2,
+		Handler: gmTeleport,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "spawn",
+		Level:   GM_LEVEL_ADMIN,
+		Usage:   "<怪物模板id> [数量]",
+		MinArgs: 1,
+		Handler: gmSpawn,
+	})
+	RegisterGmCommand(&GmCommand{
+		Name:    "level",
+		Level:   GM_LEVEL_ADMIN,
+		Usage:   "<等级> [玩家id]",
+		MinArgs: 1,
+		Handler: gmLevel,
+	})
+}
+
+// gmHelp 列出可以使用的命令
+func gmHelp(gm *Player, args *GmArgs) (string, error) {
+	lines := make([]string, 0)
+	for _, cmd := range gmCommandsFor(gm.gmLevel) {
+		lines = append(lines, cmd.usage())
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// gmAnnounce 向全服发送系统公告
+func gmAnnounce(gm *Player, args *GmArgs) (string, error) {
+	content := args.Rest(0)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+
+	msg := &mmopb.ChatMessage{
+		Channel: mmopb.ChatChannel_Chat_Channel_System,
+		Content: content,
+		TimeMs:  nowMillis(),
+	}
+	players := WorldMgrObj.GetAllPlayers()
+	for _, player := range players {
+		player.SendMessage(mmopb.SCMsgIdChat, msg)
+	}
+	return fmt.Sprintf("announced to %d players", len(players)), nil
+}
+
+// gmKick 踢玩家下线
+func gmKick(gm *Player, args *GmArgs) (string, error) {
+	target := args.Player(0)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+
+	target.Conn.Stop()
+	return fmt.Sprintf("kicked %d", target.PlayerId), nil
+}
+
+// gmMute 禁言在线玩家，下线时随存档保存
+func gmMute(gm *Player, args *GmArgs) (string, error) {
+	target := args.Player(0)
+	minutes := args.Int(1)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+	if minutes < 0 {
+		return "", ErrGmBadArgs
+	}
+
+	now := nowMillis()
+	target.chatLimit.Mute(now+int64(minutes)*60*1000, now)
+	if minutes > 0 {
+		target.sendChatResult(ErrChatMuted, mmopb.ChatChannel_Chat_Channel_System, 0)
+		return fmt.Sprintf("muted %d for %d minutes", target.PlayerId, minutes), nil
+	}
+	return fmt.Sprintf("unmuted %d", target.PlayerId), nil
+}
+
+// gmTeleport 传送自己或其他玩家到同场景的坐标
+func gmTeleport(gm *Player, args *GmArgs) (string, error) {
+	x := args.Float(0)
+	z := args.Float(1)
+	target := args.PlayerOr(2, gm)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+	if x < float32(AOI_MIN_X) || x >= float32(AOI_MAX_X) || z < float32(AOI_MIN_Y) || z >= float32(AOI_MAX_Y) {
+		return "", ErrGmBadArgs
+	}
+
+	target.Teleport(x, z)
+	return fmt.Sprintf("teleported %d to (%.1f, %.1f)", target.PlayerId, x, z), nil
+}
+
+// gmSpawn 在GM的位置刷出怪物，死亡后不会重新刷新
+func gmSpawn(gm *Player, args *GmArgs) (string, error) {
+	templateId := args.Int(0)
+	count := args.IntOr(1, 1)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+	template, ok := monsterTemplates[templateId]
+	if !ok || count <= 0 || count > GM_SPAWN_MAX_COUNT {
+		return "", ErrGmBadArgs
+	}
+
+	for i := int32(0); i < count; i++ {
+		WorldMgrObj.AddMonster(NewMonster(template, gm.X, gm.Z))
+	}
+	return fmt.Sprintf("spawned %d %s", count, template.Name), nil
+}
+
+// gmLevel 设置自己或其他玩家的等级
+func gmLevel(gm *Player, args *GmArgs) (string, error) {
+	level := args.Int(0)
+	target := args.PlayerOr(1, gm)
+	if err := args.Err(); err != nil {
+		return "", err
+	}
+	if level <= 0 || level > MaxLevel() {
+		return "", ErrGmBadArgs
+	}
+
+	target.SetLevel(level)
+	return fmt.Sprintf("set %d level to %d", target.PlayerId, level), nil
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"net"
+	"testing"
+)
+
+func TestGmArgs(t *testing.T) {
+	args := &GmArgs{args: []string{"12", "3.5", "hello", "world"}}
+	if v := args.Int(0); v != 12 {
+		t.Fatalf("int = %d, want 12", v)
+	}
+	if v := args.Float(1); v != 3.5 {
+		t.Fatalf("float = %v, want 3.5", v)
+	}
+	if v := args.Rest(2); v != "hello world" {
+		t.Fatalf("rest = %q", v)
+	}
+	if v := args.IntOr(9, 7); v != 7 || args.Err() != nil {
+		t.Fatalf("int or = %d, %v", v, args.Err())
+	}
+
+	// 第一个解析错误会保留下来
+	args.Int(2)
+	args.Int(9)
+	if args.Err() != ErrGmBadArgs {
+		t.Fatalf("want ErrGmBadArgs, got %v", args.Err())
+	}
+}
+
+func TestGmLevelOf(t *testing.T) {
+	nets, err := parseGmIps([]string{"127.0.0.1", "10.1.0.0/16", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gmAccounts = map[string]*gmAccount{
+		"admin":  {level: GM_LEVEL_ADMIN, nets: nets},
+		"nobody": {level: GM_LEVEL_ADMIN},
+	}
+	defer func() {
+		gmAccounts = make(map[string]*gmAccount)
+	}()
+
+	cases := []struct {
+		account string
+		ip      string
+		want    int32
+	}{
+		{"admin", "127.0.0.1", GM_LEVEL_ADMIN},
+		{"admin", "10.1.2.3", GM_LEVEL_ADMIN},
+		{"admin", "::1", GM_LEVEL_ADMIN},
+		{"admin", "10.2.0.1", GM_LEVEL_NONE},
+		// 没有配置来源地址的账号没有GM权限
+		{"nobody", "127.0.0.1", GM_LEVEL_NONE},
+		{"player", "127.0.0.1", GM_LEVEL_NONE},
+	}
+	for _, c := range cases {
+		addr := &net.TCPAddr{IP: net.ParseIP(c.ip), Port: 50000}
+		if got := GmLevelOf(c.account, addr); got != c.want {
+			t.Errorf("gm level of %s from %s = %d, want %d", c.account, c.ip, got, c.want)
+		}
+	}
+
+	if _, err := parseGmIps([]string{"localhost"}); err == nil {
+		t.Fatal("host name should be rejected")
+	}
+}
+
+func TestPlayer_runGmCommand(t *testing.T) {
+	called := false
+	RegisterGmCommand(&GmCommand{
+		Name:    "test",
+		Level:   GM_LEVEL_ADMIN,
+		MinArgs: 1,
+		Handler: func(gm *Player, args *GmArgs) (string, error) {
+			called = true
+			return "", args.Err()
+		},
+	})
+	defer delete(gmCommands, "test")
+
+	helper := &Player{gmLevel: GM_LEVEL_HELPER}
+	admin := &Player{gmLevel: GM_LEVEL_ADMIN}
+	if _, err := helper.runGmCommand("test", []string{"test", "1"}); err != ErrGmNoPermission {
+		t.Fatalf("want ErrGmNoPermission, got %v", err)
+	}
+	if _, err := admin.runGmCommand("nope", []string{"nope"}); err != ErrGmUnknownCommand {
+		t.Fatalf("want ErrGmUnknownCommand, got %v", err)
+	}
+	if output, err := admin.runGmCommand("test", []string{"test"}); err != ErrGmBadArgs || output != "/test" || called {
+		t.Fatalf("missing args = %q, %v, called = %v", output, err, called)
+	}
+	if _, err := admin.runGmCommand("test", []string{"test", "1"}); err != nil || !called {
+		t.Fatalf("run = %v, called = %v", err, called)
+	}
+}
@@ -48,7 +48,7 @@ func (g *Grid) Remove(playerId int) {
 func (g *Grid) GetPlayerIds() []int {
 	g.playerIdIdLock.RLock()
 	defer g.playerIdIdLock.RUnlock()
-	playerIds := make([]int, len(g.playerIds))
+	playerIds := make([]int, 0, len(g.playerIds))
 	for id, _ := range g.playerIds {
 		playerIds = append(playerIds, id)
 	}
@@ -0,0 +1,562 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrBagFull          = errors.New("bag is full")
+	ErrItemNotFound     = errors.New("item not found")
+	ErrItemNotEnough    = errors.New("item not enough")
+	ErrSlotInvalid      = errors.New("slot invalid")
+	ErrItemCannotUse    = errors.New("item cannot be used")
+	ErrItemCountInvalid = errors.New("item count invalid")
+	ErrGoldNotEnough    = errors.New("gold not enough")
+)
+
+// ItemData 背包格子存档数据
+type ItemData struct {
+	Slot   int32 `json:"slot"`    // 格子下标
+	ItemId int32 `json:"item_id"` // 物品id
+	Count  int32 `json:"count"`   // 数量
+}
+
+// Inventory 背包，每个格子存放一种物品，数量不超过物品的堆叠上限
+type Inventory struct {
+	slots   []*ItemStack // 格子，nil表示空格子
+	gold    int64        // 金币
+	bagLock sync.Mutex   // 保护slots和gold的锁
+}
+
+// NewInventory 创建指定格子数量的背包
+func NewInventory(size int) *Inventory {
+	return &Inventory{
+		slots: make([]*ItemStack, size),
+	}
+}
+
+// Load 从存档恢复背包，无效的格子和物品会被忽略
+func (inv *Inventory) Load(datas []*ItemData, gold int64) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if gold > 0 {
+		inv.gold = gold
+	}
+	for _, data := range datas {
+		template := GetItemTemplate(data.ItemId)
+		if template == nil || !inv.validSlot(data.Slot) || data.Count <= 0 {
+			fmt.Println("load item slot = ", data.Slot, " item id = ", data.ItemId, " invalid")
+			continue
+		}
+		count := data.Count
+		if count > template.MaxStack {
+			count = template.MaxStack
+		}
+		inv.slots[data.Slot] = &ItemStack{ItemId: data.ItemId, Count: count}
+	}
+}
+
+// Snapshot 背包存档数据
+func (inv *Inventory) Snapshot() []*ItemData {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	datas := make([]*ItemData, 0)
+	for i, stack := range inv.slots {
+		if stack != nil {
+			datas = append(datas, &ItemData{Slot: int32(i), ItemId: stack.ItemId, Count: stack.Count})
+		}
+	}
+	return datas
+}
+
+// SyncMsg 整个背包的同步消息
+func (inv *Inventory) SyncMsg() *mmopb.SyncInventory {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	msg := &mmopb.SyncInventory{
+		Size:  int32(len(inv.slots)),
+		Slots: make([]*mmopb.ItemSlot, 0),
+		Gold:  inv.gold,
+	}
+	for i, stack := range inv.slots {
+		if stack != nil {
+			msg.Slots = append(msg.Slots, slotMsg(int32(i), stack))
+		}
+	}
+	return msg
+}
+
+// Gold 当前金币数量
+func (inv *Inventory) Gold() int64 {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+	return inv.gold
+}
+
+// AddGold 增加金币，gold为负数时扣除，不足时不扣除
+func (inv *Inventory) AddGold(gold int64) error {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if inv.gold+gold < 0 {
+		return ErrGoldNotEnough
+	}
+	inv.gold += gold
+	return nil
+}
+
+// GetSlot 获取格子中的物品，空格子返回nil
+func (inv *Inventory) GetSlot(slot int32) *ItemStack {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if !inv.validSlot(slot) || inv.slots[slot] == nil {
+		return nil
+	}
+	stack := *inv.slots[slot]
+	return &stack
+}
+
+// CountItem 背包中某种物品的总数量
+func (inv *Inventory) CountItem(itemId int32) int32 {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	var count int32
+	for _, stack := range inv.slots {
+		if stack != nil && stack.ItemId == itemId {
+			count += stack.Count
+		}
+	}
+	return count
+}
+
+// CanAddItems 背包是否放得下全部物品
+func (inv *Inventory) CanAddItems(stacks ...*ItemStack) bool {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	slots := cloneSlots(inv.slots)
+	for _, stack := range stacks {
+		if err := addToSlots(slots, stack.ItemId, stack.Count); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// AddItems 添加物品，优先堆叠到已有的格子，全部放得下才会添加，返回变化的格子
+func (inv *Inventory) AddItems(stacks ...*ItemStack) ([]*mmopb.ItemSlot, error) {
+	return inv.Exchange(nil, stacks)
+}
+
+// RemoveItems 扣除物品，全部足够才会扣除，返回变化的格子
+func (inv *Inventory) RemoveItems(stacks ...*ItemStack) ([]*mmopb.ItemSlot, error) {
+	return inv.Exchange(stacks, nil)
+}
+
+// Exchange 先扣除再添加物品，任何一步失败时背包不变，返回变化的格子
+func (inv *Inventory) Exchange(remove []*ItemStack, add []*ItemStack) ([]*mmopb.ItemSlot, error) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	slots := cloneSlots(inv.slots)
+	for _, stack := range remove {
+		if err := removeFromSlots(slots, stack.ItemId, stack.Count); err != nil {
+			return nil, err
+		}
+	}
+	for _, stack := range add {
+		if err := addToSlots(slots, stack.ItemId, stack.Count); err != nil {
+			return nil, err
+		}
+	}
+	return inv.commit(slots), nil
+}
+
+// swapInventories 两个背包互相交换物品和金币，任何一步失败时两个背包都不变。
+// 调用方需持有tradeLock，保证同时锁定两个背包时不会死锁
+func swapInventories(a *Inventory, aGive []*ItemStack, aGold int64, b *Inventory, bGive []*ItemStack, bGold int64) ([]*mmopb.ItemSlot, []*mmopb.ItemSlot, error) {
+	a.bagLock.Lock()
+	defer a.bagLock.Unlock()
+	b.bagLock.Lock()
+	defer b.bagLock.Unlock()
+
+	if a.gold < aGold || b.gold < bGold {
+		return nil, nil, ErrGoldNotEnough
+	}
+	aSlots, bSlots := cloneSlots(a.slots), cloneSlots(b.slots)
+	for _, stack := range aGive {
+		if err := removeFromSlots(aSlots, stack.ItemId, stack.Count); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, stack := range bGive {
+		if err := removeFromSlots(bSlots, stack.ItemId, stack.Count); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, stack := range bGive {
+		if err := addToSlots(aSlots, stack.ItemId, stack.Count); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, stack := range aGive {
+		if err := addToSlots(bSlots, stack.ItemId, stack.Count); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	a.gold += bGold - aGold
+	b.gold += aGold - bGold
+	return a.commit(aSlots), b.commit(bSlots), nil
+}
+
+// TakeAt 从指定格子扣除物品，返回被扣除的物品
+func (inv *Inventory) TakeAt(slot int32, count int32) (*ItemStack, []*mmopb.ItemSlot, error) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if !inv.validSlot(slot) {
+		return nil, nil, ErrSlotInvalid
+	}
+	stack := inv.slots[slot]
+	if stack == nil {
+		return nil, nil, ErrItemNotFound
+	}
+	if count <= 0 {
+		return nil, nil, ErrItemCountInvalid
+	}
+	if stack.Count < count {
+		return nil, nil, ErrItemNotEnough
+	}
+
+	slots := cloneSlots(inv.slots)
+	slots[slot].Count -= count
+	if slots[slot].Count == 0 {
+		slots[slot] = nil
+	}
+	return &ItemStack{ItemId: stack.ItemId, Count: count}, inv.commit(slots), nil
+}
+
+// ReplaceAt 从指定格子扣除一个物品，再放入另一个物品，优先放回同一个格子
+func (inv *Inventory) ReplaceAt(slot int32, itemId int32, replacement *ItemStack) ([]*mmopb.ItemSlot, error) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if !inv.validSlot(slot) {
+		return nil, ErrSlotInvalid
+	}
+	if inv.slots[slot] == nil || inv.slots[slot].ItemId != itemId {
+		return nil, ErrItemNotFound
+	}
+
+	slots := cloneSlots(inv.slots)
+	slots[slot].Count--
+	if slots[slot].Count == 0 {
+		slots[slot] = nil
+		if replacement != nil {
+			slots[slot] = &ItemStack{ItemId: replacement.ItemId, Count: replacement.Count}
+			replacement = nil
+		}
+	}
+	if replacement != nil {
+		if err := addToSlots(slots, replacement.ItemId, replacement.Count); err != nil {
+			return nil, err
+		}
+	}
+	return inv.commit(slots), nil
+}
+
+// Move 移动物品，目标格子有相同物品时尽量合并，否则交换两个格子
+func (inv *Inventory) Move(from, to int32) ([]*mmopb.ItemSlot, error) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if !inv.validSlot(from) || !inv.validSlot(to) || from == to {
+		return nil, ErrSlotInvalid
+	}
+	if inv.slots[from] == nil {
+		return nil, ErrItemNotFound
+	}
+
+	slots := cloneSlots(inv.slots)
+	src, dst := slots[from], slots[to]
+	if dst != nil && dst.ItemId == src.ItemId {
+		maxStack := GetItemTemplate(src.ItemId).MaxStack
+		n := maxStack - dst.Count
+		if n > src.Count {
+			n = src.Count
+		}
+		if n > 0 {
+			dst.Count += n
+			src.Count -= n
+			if src.Count == 0 {
+				slots[from] = nil
+			}
+			return inv.commit(slots), nil
+		}
+	}
+	slots[from], slots[to] = dst, src
+	return inv.commit(slots), nil
+}
+
+// Split 把格子中的一部分物品拆分到空格子
+func (inv *Inventory) Split(from, to, count int32) ([]*mmopb.ItemSlot, error) {
+	inv.bagLock.Lock()
+	defer inv.bagLock.Unlock()
+
+	if !inv.validSlot(from) || !inv.validSlot(to) || from == to || inv.slots[to] != nil {
+		return nil, ErrSlotInvalid
+	}
+	src := inv.slots[from]
+	if src == nil {
+		return nil, ErrItemNotFound
+	}
+	// 拆分后原格子至少保留一个
+	if count <= 0 || count >= src.Count {
+		return nil, ErrItemCountInvalid
+	}
+
+	slots := cloneSlots(inv.slots)
+	slots[from].Count -= count
+	slots[to] = &ItemStack{ItemId: src.ItemId, Count: count}
+	return inv.commit(slots), nil
+}
+
+// validSlot 格子下标是否有效，调用方需持有bagLock
+func (inv *Inventory) validSlot(slot int32) bool {
+	return slot >= 0 && int(slot) < len(inv.slots)
+}
+
+// commit 用新的格子替换背包，返回变化的格子，调用方需持有bagLock
+func (inv *Inventory) commit(slots []*ItemStack) []*mmopb.ItemSlot {
+	changed := make([]*mmopb.ItemSlot, 0)
+	for i := range slots {
+		old, cur := inv.slots[i], slots[i]
+		if old == nil && cur == nil {
+			continue
+		}
+		if old != nil && cur != nil && *old == *cur {
+			continue
+		}
+		changed = append(changed, slotMsg(int32(i), cur))
+	}
+	inv.slots = slots
+	return changed
+}
+
+// cloneSlots 复制格子，修改副本不影响原背包
+func cloneSlots(slots []*ItemStack) []*ItemStack {
+	clone := make([]*ItemStack, len(slots))
+	for i, stack := range slots {
+		if stack != nil {
+			s := *stack
+			clone[i] = &s
+		}
+	}
+	return clone
+}
+
+// addToSlots 先堆叠到已有的格子，再放入空格子
+func addToSlots(slots []*ItemStack, itemId int32, count int32) error {
+	template := GetItemTemplate(itemId)
+	if template == nil {
+		return ErrItemNotFound
+	}
+	if count <= 0 {
+		return ErrItemCountInvalid
+	}
+
+	for _, stack := range slots {
+		if count == 0 {
+			return nil
+		}
+		if stack == nil || stack.ItemId != itemId || stack.Count >= template.MaxStack {
+			continue
+		}
+		n := template.MaxStack - stack.Count
+		if n > count {
+			n = count
+		}
+		stack.Count += n
+		count -= n
+	}
+	for i := range slots {
+		if count == 0 {
+			return nil
+		}
+		if slots[i] != nil {
+			continue
+		}
+		n := template.MaxStack
+		if n > count {
+			n = count
+		}
+		slots[i] = &ItemStack{ItemId: itemId, Count: n}
+		count -= n
+	}
+	if count > 0 {
+		return ErrBagFull
+	}
+	return nil
+}
+
+// removeFromSlots 从后往前扣除物品
+func removeFromSlots(slots []*ItemStack, itemId int32, count int32) error {
+	if count <= 0 {
+		return ErrItemCountInvalid
+	}
+	for i := len(slots) - 1; i >= 0 && count > 0; i-- {
+		stack := slots[i]
+		if stack == nil || stack.ItemId != itemId {
+			continue
+		}
+		n := stack.Count
+		if n > count {
+			n = count
+		}
+		stack.Count -= n
+		count -= n
+		if stack.Count == 0 {
+			slots[i] = nil
+		}
+	}
+	if count > 0 {
+		return ErrItemNotEnough
+	}
+	return nil
+}
+
+// slotMsg 格子消息，空格子的数量为0
+func slotMsg(slot int32, stack *ItemStack) *mmopb.ItemSlot {
+	msg := &mmopb.ItemSlot{Slot: slot}
+	if stack != nil {
+		msg.ItemId = stack.ItemId
+		msg.Count = stack.Count
+	}
+	return msg
+}
+
+// GiveItems 给玩家添加物品，背包放不下时一个都不添加
+func (p *Player) GiveItems(stacks ...*ItemStack) error {
+	changed, err := p.Bag.AddItems(stacks...)
+	if err != nil {
+		return err
+	}
+	p.SendInventoryChange(changed)
+	return nil
+}
+
+// TakeItems 扣除玩家的物品，数量不足时一个都不扣除
+func (p *Player) TakeItems(stacks ...*ItemStack) error {
+	changed, err := p.Bag.RemoveItems(stacks...)
+	if err != nil {
+		return err
+	}
+	p.SendInventoryChange(changed)
+	return nil
+}
+
+// GiveGold 给玩家增加金币
+func (p *Player) GiveGold(gold int64) {
+	if gold <= 0 {
+		return
+	}
+	if err := p.Bag.AddGold(gold); err == nil {
+		p.sendInventoryDelta(nil)
+	}
+}
+
+// SendInventory 同步整个背包给自己
+func (p *Player) SendInventory() {
+	p.SendMessage(mmopb.SCMsgIdSyncInventory, p.Bag.SyncMsg())
+}
+
+// SendInventoryChange 同步背包变化给自己
+func (p *Player) SendInventoryChange(changed []*mmopb.ItemSlot) {
+	if len(changed) == 0 {
+		return
+	}
+	p.sendInventoryDelta(changed)
+}
+
+// sendInventoryDelta 同步变化的格子和当前金币给自己
+func (p *Player) sendInventoryDelta(changed []*mmopb.ItemSlot) {
+	p.SendMessage(mmopb.SCMsgIdInventoryChange, &mmopb.InventoryChange{
+		Slots: changed,
+		Gold:  p.Bag.Gold(),
+	})
+	if len(changed) > 0 {
+		p.onItemsChanged()
+	}
+}
+
+// MoveItem 玩家移动背包中的物品
+func (p *Player) MoveItem(from, to int32) {
+	changed, err := p.Bag.Move(from, to)
+	p.onInventoryOperation(from, changed, err)
+}
+
+// SplitItem 玩家拆分背包中的物品
+func (p *Player) SplitItem(from, to, count int32) {
+	changed, err := p.Bag.Split(from, to, count)
+	p.onInventoryOperation(from, changed, err)
+}
+
+// UseItem 玩家使用背包中的物品，每次消耗一个
+func (p *Player) UseItem(slot int32) {
+	if p.IsDead() {
+		p.sendInventoryResult(mmopb.ResultCode_Result_Dead, slot)
+		return
+	}
+	stack := p.Bag.GetSlot(slot)
+	if stack == nil {
+		p.onInventoryOperation(slot, nil, ErrItemNotFound)
+		return
+	}
+	template := GetItemTemplate(stack.ItemId)
+	if !template.CanUse() {
+		p.onInventoryOperation(slot, nil, ErrItemCannotUse)
+		return
+	}
+
+	// 检查物品后格子可能被交易换掉，扣除时核对物品id，保证效果和消耗的是同一个物品
+	changed, err := p.Bag.ReplaceAt(slot, stack.ItemId, nil)
+	p.onInventoryOperation(slot, changed, err)
+	if err != nil {
+		return
+	}
+
+	if template.UseHP > 0 || template.UseMP > 0 {
+		p.Heal(template.UseHP, template.UseMP)
+		broadCastStats(p)
+	}
+	if template.UseBuff > 0 {
+		AddBuff(p, p.PlayerId, template.UseBuff)
+	}
+}
+
+// onInventoryOperation 背包操作成功时同步变化，失败时告知原因
+func (p *Player) onInventoryOperation(slot int32, changed []*mmopb.ItemSlot, err error) {
+	if err != nil {
+		p.sendInventoryResult(resultCodeOf(err), slot)
+		return
+	}
+	p.SendInventoryChange(changed)
+}
+
+// sendInventoryResult 告知背包操作失败的原因
+func (p *Player) sendInventoryResult(result mmopb.ResultCode, slot int32) {
+	p.SendMessage(mmopb.SCMsgIdInventoryResult, &mmopb.InventoryResult{
+		Result: result,
+		Slot:   slot,
+	})
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	itemTemplates[9001] = &ItemTemplate{ItemId: 9001, Type: ITEM_TYPE_MATERIAL, MaxStack: 10}
+	itemTemplates[9002] = &ItemTemplate{ItemId: 9002, Type: ITEM_TYPE_QUEST, MaxStack: 1}
+	defer func() {
+		delete(itemTemplates, 9001)
+		delete(itemTemplates, 9002)
+	}()
+
+	inv := NewInventory(3)
+
+	// 超过堆叠上限时占用多个格子
+	if _, err := inv.AddItems(&ItemStack{ItemId: 9001, Count: 15}); err != nil {
+		t.Fatal(err)
+	}
+	if got := inv.CountItem(9001); got != 15 {
+		t.Fatalf("count = %d, want 15", got)
+	}
+
+	// 放不下时一个都不添加
+	if _, err := inv.AddItems(&ItemStack{ItemId: 9002, Count: 1}, &ItemStack{ItemId: 9002, Count: 1}); err != ErrBagFull {
+		t.Fatalf("err = %v, want %v", err, ErrBagFull)
+	}
+	if got := inv.CountItem(9002); got != 0 {
+		t.Fatalf("count = %d, want 0", got)
+	}
+
+	// 合并到已有的格子，超出上限的部分留在原格子
+	if _, err := inv.Split(0, 2, 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inv.Move(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if s := inv.GetSlot(1); s == nil || s.Count != 9 {
+		t.Fatalf("slot 1 = %+v, want 9", s)
+	}
+	if s := inv.GetSlot(0); s == nil || s.Count != 6 {
+		t.Fatalf("slot 0 = %+v, want 6", s)
+	}
+	if s := inv.GetSlot(2); s != nil {
+		t.Fatalf("slot 2 = %+v, want empty", s)
+	}
+
+	// 数量不足时不扣除
+	if _, err := inv.RemoveItems(&ItemStack{ItemId: 9001, Count: 16}); err != ErrItemNotEnough {
+		t.Fatalf("err = %v, want %v", err, ErrItemNotEnough)
+	}
+	changed, err := inv.Exchange([]*ItemStack{{ItemId: 9001, Count: 15}}, []*ItemStack{{ItemId: 9002, Count: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 || inv.CountItem(9001) != 0 || inv.CountItem(9002) != 1 {
+		t.Fatalf("changed = %v, bag = %+v", changed, inv.Snapshot())
+	}
+
+	// 格子里的物品已经换掉时，按旧物品id扣除失败
+	slot := int32(0)
+	for inv.GetSlot(slot) == nil {
+		slot++
+	}
+	if _, err := inv.ReplaceAt(slot, 9001, nil); err != ErrItemNotFound {
+		t.Fatalf("err = %v, want %v", err, ErrItemNotFound)
+	}
+	if _, err := inv.ReplaceAt(slot, 9002, nil); err != nil || inv.CountItem(9002) != 0 {
+		t.Fatalf("err = %v, bag = %+v", err, inv.Snapshot())
+	}
+}
+
+func TestSwapInventories(t *testing.T) {
+	itemTemplates[9001] = &ItemTemplate{ItemId: 9001, Type: ITEM_TYPE_MATERIAL, MaxStack: 10}
+	itemTemplates[9003] = &ItemTemplate{ItemId: 9003, Type: ITEM_TYPE_EQUIPMENT, MaxStack: 1}
+	defer func() {
+		delete(itemTemplates, 9001)
+		delete(itemTemplates, 9003)
+	}()
+
+	a := NewInventory(1)
+	a.Load([]*ItemData{{Slot: 0, ItemId: 9001, Count: 5}}, 100)
+	b := NewInventory(1)
+	b.Load([]*ItemData{{Slot: 0, ItemId: 9003, Count: 1}}, 0)
+
+	// 对方背包放不下时两边都不变
+	if _, _, err := swapInventories(a, []*ItemStack{{ItemId: 9001, Count: 2}}, 50, b, nil, 0); err != ErrBagFull {
+		t.Fatalf("err = %v, want %v", err, ErrBagFull)
+	}
+	if a.CountItem(9001) != 5 || a.Gold() != 100 || b.CountItem(9003) != 1 || b.Gold() != 0 {
+		t.Fatal("inventories changed after failed swap")
+	}
+
+	// 交出全部物品后腾出的格子可以放入对方的物品
+	if _, _, err := swapInventories(a, []*ItemStack{{ItemId: 9001, Count: 5}}, 30, b, []*ItemStack{{ItemId: 9003, Count: 1}}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if a.CountItem(9003) != 1 || a.Gold() != 70 || b.CountItem(9001) != 5 || b.Gold() != 30 {
+		t.Fatal("swap result wrong")
+	}
+}
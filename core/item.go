@@ -0,0 +1,107 @@
+package core
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// 物品类型
+const (
+	ITEM_TYPE_CONSUMABLE string = "consumable" // 消耗品，可以使用
+	ITEM_TYPE_MATERIAL   string = "material"   // 材料
+	ITEM_TYPE_QUEST      string = "quest"      // 任务物品
+	ITEM_TYPE_EQUIPMENT  string = "equipment"  // 装备，不能堆叠
+)
+
+// ItemTemplate 物品模板
+type ItemTemplate struct {
+	ItemId   int32  `json:"item_id"`   // 物品id
+	Name     string `json:"name"`      // 物品名称
+	Type     string `json:"type"`      // 物品类型
+	MaxStack int32  `json:"max_stack"` // 每个格子最多堆叠的数量
+	UseHP    int32  `json:"use_hp"`    // 使用后恢复的血量
+	UseMP    int32  `json:"use_mp"`    // 使用后恢复的法力
+	UseBuff  int32  `json:"use_buff"`  // 使用后获得的buff，0表示没有
+
+	EquipSlot mmopb.EquipSlot   `json:"equip_slot"` // 装备部位
+	Class     mmopb.PlayerClass `json:"class"`      // 可以装备的职业，0表示不限
+	Level     int32             `json:"level"`      // 装备需要的等级
+	Stats     BaseStats         `json:"stats"`      // 装备提供的属性
+	ModelId   int32             `json:"model_id"`   // 装备的模型id，周围玩家看到的外观
+}
+
+// ItemStack 物品和数量
+type ItemStack struct {
+	ItemId int32 `json:"item_id"` // 物品id
+	Count  int32 `json:"count"`   // 数量
+}
+
+// ItemConfig 物品数据文件
+type ItemConfig struct {
+	Items        []*ItemTemplate `json:"items"`
+	StarterItems []*ItemStack    `json:"starter_items"` // 新角色获得的物品
+}
+
+// itemTemplates 全部物品模板
+var itemTemplates = make(map[int32]*ItemTemplate)
+
+// starterItems 新角色获得的物品
+var starterItems []*ItemStack
+
+// LoadItems 读取物品数据文件，buff数据需要先加载
+func LoadItems() error {
+	config := &ItemConfig{}
+	if err := loadConfig("items.json", config); err != nil {
+		return err
+	}
+
+	for _, item := range config.Items {
+		switch item.Type {
+		case ITEM_TYPE_CONSUMABLE, ITEM_TYPE_MATERIAL, ITEM_TYPE_QUEST:
+		case ITEM_TYPE_EQUIPMENT:
+			if _, ok := mmopb.EquipSlot_name[int32(item.EquipSlot)]; !ok || item.EquipSlot == mmopb.EquipSlot_Equip_Slot_None {
+				return fmt.Errorf("item id %d equip slot %d invalid", item.ItemId, item.EquipSlot)
+			}
+			item.MaxStack = 1
+		default:
+			return fmt.Errorf("item id %d type %q invalid", item.ItemId, item.Type)
+		}
+		if item.MaxStack <= 0 {
+			item.MaxStack = 1
+		}
+		if item.UseBuff > 0 && GetBuffTemplate(item.UseBuff) == nil {
+			return fmt.Errorf("item id %d buff id %d not exist", item.ItemId, item.UseBuff)
+		}
+		itemTemplates[item.ItemId] = item
+	}
+
+	for _, stack := range config.StarterItems {
+		if GetItemTemplate(stack.ItemId) == nil {
+			return fmt.Errorf("starter item id %d not exist", stack.ItemId)
+		}
+	}
+	starterItems = config.StarterItems
+	return nil
+}
+
+// GetItemTemplate 获取物品模板
+func GetItemTemplate(itemId int32) *ItemTemplate {
+	return itemTemplates[itemId]
+}
+
+// CanUse 物品是否可以使用
+func (it *ItemTemplate) CanUse() bool {
+	return it.Type == ITEM_TYPE_CONSUMABLE
+}
+
+// CanEquip 指定职业和等级的玩家能否装备
+func (it *ItemTemplate) CanEquip(class mmopb.PlayerClass, level int32) bool {
+	if it.Type != ITEM_TYPE_EQUIPMENT {
+		return false
+	}
+	if it.Class != mmopb.PlayerClass_Class_Unknown && it.Class != class {
+		return false
+	}
+	return level >= it.Level
+}
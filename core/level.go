@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// LevelTemplate 等级数据
+type LevelTemplate struct {
+	Level int32 `json:"level"` // 等级
+	Exp   int64 `json:"exp"`   // 从该等级升到下一级需要的经验，满级为0
+}
+
+// ClassGrowth 职业的基础属性和每级成长
+type ClassGrowth struct {
+	Class  mmopb.PlayerClass `json:"class"`  // 职业
+	Base   BaseStats         `json:"base"`   // 1级时的基础属性
+	Growth BaseStats         `json:"growth"` // 每升一级增加的属性
+}
+
+// LevelConfig 等级数据文件
+type LevelConfig struct {
+	Levels  []*LevelTemplate `json:"levels"`
+	Classes []*ClassGrowth   `json:"classes"`
+}
+
+// levelTemplates 按等级排列的等级数据，下标为等级减1
+var levelTemplates []*LevelTemplate
+
+// classGrowths 各职业的属性成长
+var classGrowths = make(map[mmopb.PlayerClass]*ClassGrowth)
+
+// LoadLevels 读取等级和职业成长数据文件
+func LoadLevels() error {
+	config := &LevelConfig{}
+	if err := loadConfig("levels.json", config); err != nil {
+		return err
+	}
+
+	if len(config.Levels) == 0 {
+		return fmt.Errorf("level config is empty")
+	}
+	for i, level := range config.Levels {
+		if level.Level != int32(i+1) {
+			return fmt.Errorf("level %d out of order", level.Level)
+		}
+	}
+	for _, growth := range config.Classes {
+		classGrowths[growth.Class] = growth
+	}
+	for class := range mmopb.PlayerClass_name {
+		if class == int32(mmopb.PlayerClass_Class_Unknown) {
+			continue
+		}
+		if _, ok := classGrowths[mmopb.PlayerClass(class)]; !ok {
+			return fmt.Errorf("class %d has no growth config", class)
+		}
+	}
+	levelTemplates = config.Levels
+	return nil
+}
+
+// MaxLevel 满级等级
+func MaxLevel() int32 {
+	return int32(len(levelTemplates))
+}
+
+// ClampLevel 把等级限制在1到满级之间
+func ClampLevel(level int32) int32 {
+	if level < 1 {
+		return 1
+	}
+	if max := MaxLevel(); level > max {
+		return max
+	}
+	return level
+}
+
+// ExpToNextLevel 从该等级升到下一级需要的经验，满级时返回0
+func ExpToNextLevel(level int32) int64 {
+	if level < 1 || level >= MaxLevel() {
+		return 0
+	}
+	return levelTemplates[level-1].Exp
+}
+
+// ClassStats 职业在指定等级的基础属性
+func ClassStats(class mmopb.PlayerClass, level int32) BaseStats {
+	growth, ok := classGrowths[class]
+	if !ok {
+		return BaseStats{}
+	}
+	n := ClampLevel(level) - 1
+	return BaseStats{
+		MaxHP:       growth.Base.MaxHP + growth.Growth.MaxHP*n,
+		MaxMP:       growth.Base.MaxMP + growth.Growth.MaxMP*n,
+		Attack:      growth.Base.Attack + growth.Growth.Attack*n,
+		Defense:     growth.Base.Defense + growth.Growth.Defense*n,
+		AttackRange: growth.Base.AttackRange + growth.Growth.AttackRange*float32(n),
+	}
+}
+
+// addExp 增加经验并计算升级，返回是否升级，满级后不再积累经验
+func (p *Player) addExp(exp int64) (levelUp bool) {
+	p.levelLock.Lock()
+	defer p.levelLock.Unlock()
+
+	if ExpToNextLevel(p.Level) == 0 {
+		p.Exp = 0
+		return false
+	}
+	p.Exp += exp
+	for next := ExpToNextLevel(p.Level); next > 0 && p.Exp >= next; next = ExpToNextLevel(p.Level) {
+		p.Exp -= next
+		p.Level++
+		levelUp = true
+	}
+	if ExpToNextLevel(p.Level) == 0 {
+		p.Exp = 0
+	}
+	return
+}
+
+// AddExp 玩家获得经验，升级时重新计算属性并通知周围玩家
+func (p *Player) AddExp(exp int64, source mmopb.ExpSource) {
+	if exp <= 0 {
+		return
+	}
+	levelUp := p.addExp(exp)
+	p.SendExp(exp, source)
+	if levelUp {
+		p.onLevelChanged()
+	}
+}
+
+// SetLevel 直接设置等级，经验清零，并通知周围玩家
+func (p *Player) SetLevel(level int32) {
+	p.levelLock.Lock()
+	p.Level = ClampLevel(level)
+	p.Exp = 0
+	p.levelLock.Unlock()
+
+	p.SendExp(0, mmopb.ExpSource_Exp_Source_Unknown)
+	p.onLevelChanged()
+}
+
+// SendExp 把当前经验同步给自己
+func (p *Player) SendExp(gained int64, source mmopb.ExpSource) {
+	p.levelLock.Lock()
+	msg := &mmopb.ExpChange{
+		Level:   p.Level,
+		Exp:     p.Exp,
+		NextExp: ExpToNextLevel(p.Level),
+		Gained:  gained,
+		Source:  source,
+	}
+	p.levelLock.Unlock()
+
+	p.SendMessage(mmopb.SCMsgIdExpChange, msg)
+}
+
+// onLevelChanged 等级变化后按成长表重新计算属性，存活时回满血量和法力，并广播给周围玩家
+func (p *Player) onLevelChanged() {
+	p.SetBaseStats(ClassStats(p.Class, p.Level))
+	if !p.IsDead() {
+		p.Revive()
+	}
+
+	fmt.Println("======> player id = ", p.PlayerId, " level up to ", p.Level, " <======")
+	p.BroadCastVisible(mmopb.SCMsgIdLevelUp, &mmopb.LevelUp{
+		PlayerId: p.PlayerId,
+		Level:    p.Level,
+		Stats:    p.StatsMsg(),
+	})
+	// 通过显示数据广播更新周围玩家看到的名牌
+	p.BroadCastProfile()
+	// 好友列表中显示等级
+	p.NotifyFriendPresence(true)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestPlayer_addExp(t *testing.T) {
+	levelTemplates = []*LevelTemplate{
+		{Level: 1, Exp: 100},
+		{Level: 2, Exp: 200},
+		{Level: 3, Exp: 0},
+	}
+	defer func() { levelTemplates = nil }()
+
+	cases := []struct {
+		level     int32
+		exp       int64
+		gain      int64
+		wantLevel int32
+		wantExp   int64
+		wantUp    bool
+	}{
+		{1, 0, 50, 1, 50, false},
+		{1, 50, 50, 2, 0, true},
+		// 一次获得的经验足够连升两级，满级后经验清零
+		{1, 0, 350, 3, 0, true},
+		{3, 0, 100, 3, 0, false},
+	}
+	for i, c := range cases {
+		p := &Player{Level: c.level, Exp: c.exp}
+		up := p.addExp(c.gain)
+		if p.Level != c.wantLevel || p.Exp != c.wantExp || up != c.wantUp {
+			t.Errorf("case %d: got level %d exp %d up %v, want level %d exp %d up %v",
+				i, p.Level, p.Exp, up, c.wantLevel, c.wantExp, c.wantUp)
+		}
+	}
+}
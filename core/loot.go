@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var ErrNotOwner = errors.New("not loot owner")
+
+// DropEntry 怪物掉落表中的一项
+type DropEntry struct {
+	ItemId int32   `json:"item_id"` // 掉落的物品id
+	Min    int32   `json:"min"`     // 最少数量
+	Max    int32   `json:"max"`     // 最多数量
+	Rate   float64 `json:"rate"`    // 掉落概率0-1
+}
+
+// lootIdGen lootId生成器
+var lootIdGen = LOOT_ID_BASE
+var lootIdLock sync.Mutex
+
+// GroundLoot 地面掉落物
+type GroundLoot struct {
+	LootId        int32       // 掉落物id
+	Item          ItemStack   // 掉落的物品
+	X             float32     // 平面x坐标
+	Z             float32     // 平面y坐标
+	Owners        []int32     // 归属者，为空表示所有人都可以拾取
+	OwnerExpireAt int64       // 归属保护结束时间(unix毫秒)
+	despawnTimer  *time.Timer // 超时消失的定时器
+	expired       bool        // 超时时正被拾取认领，取消认领时直接消失，由lootLock保护
+}
+
+// NewGroundLoot 创建掉落物
+func NewGroundLoot(item ItemStack, x, z float32, owners []int32) *GroundLoot {
+	lootIdLock.Lock()
+	lootId := lootIdGen
+	lootIdGen++
+	lootIdLock.Unlock()
+
+	return &GroundLoot{
+		LootId:        lootId,
+		Item:          item,
+		X:             x,
+		Z:             z,
+		Owners:        owners,
+		OwnerExpireAt: nowMillis() + LOOT_OWNER_MS,
+	}
+}
+
+// CanPickup 玩家能否拾取，归属保护结束后所有人都可以拾取
+func (gl *GroundLoot) CanPickup(playerId int32, now int64) bool {
+	if len(gl.Owners) == 0 || now >= gl.OwnerExpireAt {
+		return true
+	}
+	for _, owner := range gl.Owners {
+		if owner == playerId {
+			return true
+		}
+	}
+	return false
+}
+
+// LootMsg 掉落物显示数据
+func (gl *GroundLoot) LootMsg() *mmopb.GroundLoot {
+	remain := gl.OwnerExpireAt - nowMillis()
+	if remain < 0 || len(gl.Owners) == 0 {
+		remain = 0
+	}
+	return &mmopb.GroundLoot{
+		LootId: gl.LootId,
+		ItemId: gl.Item.ItemId,
+		Count:  gl.Item.Count,
+		Pos: &mmopb.Position{
+			X: gl.X,
+			Z: gl.Z,
+		},
+		OwnerIds:      gl.Owners,
+		OwnerRemainMs: int32(remain),
+	}
+}
+
+// lootOwners 击杀者和怪物附近的队友获得掉落物的归属，击杀者不是玩家时所有人都可以拾取
+func lootOwners(monster *Monster, killer Combatant) []int32 {
+	player, ok := killer.(*Player)
+	if !ok {
+		return nil
+	}
+	members := player.PartyMembersNear(monster.X, monster.Z)
+	owners := make([]int32, 0, len(members))
+	for _, member := range members {
+		owners = append(owners, member.PlayerId)
+	}
+	return owners
+}
+
+// DropLoot 怪物死亡时按掉落表在周围生成掉落物
+func DropLoot(monster *Monster, killer Combatant) {
+	owners := lootOwners(monster, killer)
+	for _, drop := range monster.Template.Drops {
+		if rand.Float64() >= drop.Rate {
+			continue
+		}
+		count := drop.Min
+		if drop.Max > drop.Min {
+			count += rand.Int31n(drop.Max - drop.Min + 1)
+		}
+		if count <= 0 {
+			continue
+		}
+
+		// 在怪物周围散开，避免叠在一起
+		x := monster.X + (rand.Float32()*2-1)*LOOT_SCATTER
+		z := monster.Z + (rand.Float32()*2-1)*LOOT_SCATTER
+		loot := NewGroundLoot(ItemStack{ItemId: drop.ItemId, Count: count}, x, z, owners)
+		loot.despawnTimer = time.AfterFunc(time.Duration(LOOT_DESPAWN_MS)*time.Millisecond, func() {
+			if WorldMgrObj.ExpireLoot(loot) {
+				WorldMgrObj.RemoveLoot(loot, 0)
+			}
+		})
+		WorldMgrObj.AddLoot(loot)
+	}
+}
+
+// PickupLoot 玩家拾取掉落物，失败时告知原因
+func (p *Player) PickupLoot(lootId int32) {
+	if err := p.pickupLoot(lootId); err != nil {
+		p.SendMessage(mmopb.SCMsgIdPickupResult, &mmopb.PickupResult{
+			Result: resultCodeOf(err),
+			LootId: lootId,
+		})
+	}
+}
+
+func (p *Player) pickupLoot(lootId int32) error {
+	if p.IsDead() {
+		return ErrPlayerDead
+	}
+	loot := WorldMgrObj.GetLootById(lootId)
+	if loot == nil {
+		return ErrItemNotFound
+	}
+	if distance(p.X, p.Z, loot.X, loot.Z) > LOOT_PICKUP_RANGE {
+		return ErrOutOfRange
+	}
+	if !loot.CanPickup(p.PlayerId, nowMillis()) {
+		return ErrNotOwner
+	}
+	if !p.Bag.CanAddItems(&loot.Item) {
+		return ErrBagFull
+	}
+
+	// 先从世界中认领，保证同一个掉落物只会被一个玩家拿到
+	if WorldMgrObj.ClaimLoot(lootId) == nil {
+		return ErrItemNotFound
+	}
+	if err := p.GiveItems(&loot.Item); err != nil {
+		// 认领之后背包被其他操作占满，放回世界中，认领期间已经超时的直接消失
+		if !WorldMgrObj.UnclaimLoot(loot) {
+			WorldMgrObj.RemoveLoot(loot, 0)
+		}
+		return err
+	}
+	if loot.despawnTimer != nil {
+		loot.despawnTimer.Stop()
+	}
+	WorldMgrObj.RemoveLoot(loot, p.PlayerId)
+	fmt.Println("======> player id = ", p.PlayerId, " pickup loot ", lootId, " item ", loot.Item.ItemId, " x", loot.Item.Count, " <======")
+	return nil
+}
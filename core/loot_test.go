@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestWorldManager_ExpireClaimedLoot(t *testing.T) {
+	loot := &GroundLoot{LootId: LOOT_ID_BASE - 1}
+	WorldMgrObj.lootLock.Lock()
+	WorldMgrObj.Loots[loot.LootId] = loot
+	WorldMgrObj.lootLock.Unlock()
+	defer func() {
+		WorldMgrObj.lootLock.Lock()
+		delete(WorldMgrObj.Loots, loot.LootId)
+		WorldMgrObj.lootLock.Unlock()
+	}()
+
+	// 拾取认领期间超时，放回时由拾取的一方移除
+	if WorldMgrObj.ClaimLoot(loot.LootId) == nil {
+		t.Fatal("claim failed")
+	}
+	if WorldMgrObj.ExpireLoot(loot) {
+		t.Fatal("claimed loot expired by timer")
+	}
+	if WorldMgrObj.UnclaimLoot(loot) {
+		t.Fatal("expired loot put back")
+	}
+	if WorldMgrObj.GetLootById(loot.LootId) != nil {
+		t.Fatal("expired loot still on ground")
+	}
+}
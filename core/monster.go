@@ -0,0 +1,177 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// MonsterTemplate 怪物模板
+type MonsterTemplate struct {
+	TemplateId int32        `json:"template_id"` // 模板id
+	Name       string       `json:"name"`        // 怪物名称
+	Exp        int64        `json:"exp"`         // 击杀获得的经验
+	Gold       int64        `json:"gold"`        // 击杀获得的金币
+	Drops      []*DropEntry `json:"drops"`       // 掉落表
+	BaseStats
+}
+
+// MonsterSpawn 怪物刷新点
+type MonsterSpawn struct {
+	TemplateId int32   `json:"template_id"` // 刷新的怪物模板id
+	X          float32 `json:"x"`           // 平面x坐标
+	Z          float32 `json:"z"`           // 平面y坐标
+	RespawnMs  int64   `json:"respawn_ms"`  // 怪物死亡后重新刷新的间隔(毫秒)
+}
+
+// Spawn 在刷新点生成怪物
+func (ms *MonsterSpawn) Spawn() {
+	template, ok := monsterTemplates[ms.TemplateId]
+	if !ok {
+		fmt.Println("monster spawn template id = ", ms.TemplateId, " not exist")
+		return
+	}
+	monster := NewMonster(template, ms.X, ms.Z)
+	monster.Spawner = ms
+	WorldMgrObj.AddMonster(monster)
+}
+
+// ScheduleRespawn 刷新点的怪物死亡后，定时重新生成
+func (ms *MonsterSpawn) ScheduleRespawn() {
+	respawnMs := ms.RespawnMs
+	if respawnMs <= 0 {
+		respawnMs = MONSTER_RESPAWN_MS
+	}
+	time.AfterFunc(time.Duration(respawnMs)*time.Millisecond, func() {
+		if IsShuttingDown() {
+			return
+		}
+		ms.Spawn()
+	})
+}
+
+// MonsterConfig 怪物数据文件
+type MonsterConfig struct {
+	Templates []*MonsterTemplate `json:"templates"`
+	Spawns    []*MonsterSpawn    `json:"spawns"`
+}
+
+// monsterTemplates 全部怪物模板
+var monsterTemplates = make(map[int32]*MonsterTemplate)
+
+// monsterIdGen monsterId生成器
+var monsterIdGen = MONSTER_ID_BASE
+var monsterIdLock sync.Mutex
+
+// Monster 怪物对象
+type Monster struct {
+	MonsterId int32            // 怪物id
+	Template  *MonsterTemplate // 怪物模板
+	X         float32          // 平面x坐标
+	Y         float32          // 高度
+	Z         float32          // 平面y坐标
+	V         float32          // 旋转0-360度
+	Spawner   *MonsterSpawn    // 生成怪物的刷新点，没有时不会重新刷新
+	CombatUnit
+}
+
+// NewMonster 根据模板创建怪物
+func NewMonster(template *MonsterTemplate, x, z float32) *Monster {
+	monsterIdLock.Lock()
+	monsterId := monsterIdGen
+	monsterIdGen++
+	monsterIdLock.Unlock()
+
+	monster := &Monster{
+		MonsterId: monsterId,
+		Template:  template,
+		X:         x,
+		Z:         z,
+	}
+	monster.SetBaseStats(template.BaseStats)
+	monster.HP = monster.MaxHP
+	monster.MP = monster.MaxMP
+	return monster
+}
+
+// LoadMonsters 读取怪物数据文件，并在刷新点生成怪物
+func LoadMonsters() error {
+	config := &MonsterConfig{}
+	if err := loadConfig("monsters.json", config); err != nil {
+		return err
+	}
+
+	for _, template := range config.Templates {
+		for _, drop := range template.Drops {
+			if GetItemTemplate(drop.ItemId) == nil {
+				return fmt.Errorf("monster template id %d drop item id %d not exist", template.TemplateId, drop.ItemId)
+			}
+		}
+		monsterTemplates[template.TemplateId] = template
+	}
+
+	for _, spawn := range config.Spawns {
+		if _, ok := monsterTemplates[spawn.TemplateId]; !ok {
+			return fmt.Errorf("monster spawn template id %d not exist", spawn.TemplateId)
+		}
+		spawn.Spawn()
+	}
+	return nil
+}
+
+// GetEntityId 实体id
+func (m *Monster) GetEntityId() int32 {
+	return m.MonsterId
+}
+
+// GetPos 实体平面坐标
+func (m *Monster) GetPos() (float32, float32) {
+	return m.X, m.Z
+}
+
+// GetCombatUnit 实体战斗属性
+func (m *Monster) GetCombatUnit() *CombatUnit {
+	return &m.CombatUnit
+}
+
+// OnDeath 怪物死亡，从世界中移除，由刷新点定时重新生成
+func (m *Monster) OnDeath(killer Combatant) {
+	fmt.Println("======> monster id = ", m.MonsterId, " killed by ", killer.GetEntityId(), " <======")
+	WorldMgrObj.RemoveMonster(m)
+	DropLoot(m, killer)
+	if player, ok := killer.(*Player); ok {
+		// 附近的队友平分经验
+		members := player.PartyMembersNear(m.X, m.Z)
+		exp := shareExp(m.Template.Exp, len(members))
+		for _, member := range members {
+			if member == player {
+				member.AddExp(exp, mmopb.ExpSource_Exp_Source_Kill)
+			} else {
+				member.AddExp(exp, mmopb.ExpSource_Exp_Source_Party)
+			}
+		}
+		player.GiveGold(m.Template.Gold)
+		player.OnMonsterKilled(m.Template.TemplateId)
+	}
+	if m.Spawner != nil {
+		m.Spawner.ScheduleRespawn()
+	}
+}
+
+// MonsterMsg 怪物显示数据
+func (m *Monster) MonsterMsg() *mmopb.Monster {
+	return &mmopb.Monster{
+		MonsterId:  m.MonsterId,
+		TemplateId: m.Template.TemplateId,
+		Name:       m.Template.Name,
+		Pos: &mmopb.Position{
+			X: m.X,
+			Y: m.Y,
+			Z: m.Z,
+			V: m.V,
+		},
+		Stats: m.StatsMsg(),
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	PLAYER_NAME_MIN_LEN int = 2  // 玩家名字最少字符数
+	PLAYER_NAME_MAX_LEN int = 12 // 玩家名字最多字符数
+)
+
+var (
+	ErrNameLength    = errors.New("name length invalid")
+	ErrNameCharacter = errors.New("name contains invalid character")
+	ErrNameDuplicate = errors.New("name already exists")
+)
+
+// CheckPlayerName 校验玩家名字的长度和字符，只允许字母、数字、汉字和下划线
+func CheckPlayerName(name string) error {
+	n := utf8.RuneCountInString(name)
+	if n < PLAYER_NAME_MIN_LEN || n > PLAYER_NAME_MAX_LEN {
+		return ErrNameLength
+	}
+	for _, r := range name {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		return ErrNameCharacter
+	}
+	return nil
+}
+
+// NameRegistry 名字注册表，保证玩家名字全服唯一
+type NameRegistry struct {
+	names    map[string]int32 // 名字(不区分大小写) -> playerId
+	nameLock sync.RWMutex     // 保护names的读写锁
+}
+
+// NameRegistryObj 提供一个对外的句柄
+var NameRegistryObj *NameRegistry
+
+func init() {
+	NameRegistryObj = NewNameRegistry()
+}
+
+// NewNameRegistry 创建一个名字注册表
+func NewNameRegistry() *NameRegistry {
+	return &NameRegistry{
+		names: make(map[string]int32),
+	}
+}
+
+// Reserve 校验并占用名字
+func (nr *NameRegistry) Reserve(name string, playerId int32) error {
+	if err := CheckPlayerName(name); err != nil {
+		return err
+	}
+
+	key := strings.ToLower(name)
+	nr.nameLock.Lock()
+	defer nr.nameLock.Unlock()
+
+	if owner, ok := nr.names[key]; ok && owner != playerId {
+		return ErrNameDuplicate
+	}
+	nr.names[key] = playerId
+	return nil
+}
+
+// Release 释放名字
+func (nr *NameRegistry) Release(name string) {
+	nr.nameLock.Lock()
+	delete(nr.names, strings.ToLower(name))
+	nr.nameLock.Unlock()
+}
+
+// IsTaken 名字是否已被占用
+func (nr *NameRegistry) IsTaken(name string) bool {
+	nr.nameLock.RLock()
+	defer nr.nameLock.RUnlock()
+	_, ok := nr.names[strings.ToLower(name)]
+	return ok
+}
+
+// Load 从存档加载已占用的名字
+func (nr *NameRegistry) Load(names map[string]int32) {
+	nr.nameLock.Lock()
+	defer nr.nameLock.Unlock()
+
+	for name, playerId := range names {
+		nr.names[strings.ToLower(name)] = playerId
+	}
+}
+
+// Snapshot 获取全部已占用名字的拷贝，用于存档
+func (nr *NameRegistry) Snapshot() map[string]int32 {
+	nr.nameLock.RLock()
+	defer nr.nameLock.RUnlock()
+
+	names := make(map[string]int32, len(nr.names))
+	for name, playerId := range nr.names {
+		names[name] = playerId
+	}
+	return names
+}
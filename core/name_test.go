@@ -0,0 +1,40 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestCheckPlayerName(t *testing.T) {
+	cases := map[string]error{
+		"a":             ErrNameLength,
+		"Mason":         nil,
+		"梅森_01":         nil,
+		"abcdefghijklm": ErrNameLength,
+		"bad name":      ErrNameCharacter,
+		"<script>":      ErrNameCharacter,
+	}
+	for name, want := range cases {
+		if got := CheckPlayerName(name); got != want {
+			t.Errorf("CheckPlayerName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestNameRegistry_Reserve(t *testing.T) {
+	nr := NewNameRegistry()
+	if err := nr.Reserve("Mason", 1); err != nil {
+		t.Fatal(err)
+	}
+	// 同一个玩家重复占用不报错
+	if err := nr.Reserve("Mason", 1); err != nil {
+		t.Fatal(err)
+	}
+	// 不区分大小写
+	if err := nr.Reserve("mason", 2); err != ErrNameDuplicate {
+		t.Fatalf("want ErrNameDuplicate, got %v", err)
+	}
+	nr.Release("MASON")
+	if err := nr.Reserve("mason", 2); err != nil {
+		t.Fatal(err)
+	}
+}
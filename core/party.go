@@ -0,0 +1,531 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrPartyFull      = errors.New("party full")
+	ErrNotInParty     = errors.New("not in party")
+	ErrNotPartyLeader = errors.New("not party leader")
+	ErrAlreadyInParty = errors.New("already in party")
+	ErrInviteDeclined = errors.New("invite declined")
+)
+
+// PartyMember 队伍成员
+type PartyMember struct {
+	PlayerId   int32             // 玩家id
+	Name       string            // 显示名称
+	Class      mmopb.PlayerClass // 职业
+	player     *Player           // 在线时的玩家对象，断线等待重连期间为nil
+	graceTimer *time.Timer       // 断线后移出队伍的定时器
+}
+
+// Party 队伍
+type Party struct {
+	PartyId  int32          // 队伍id
+	LeaderId int32          // 队长id
+	Members  []*PartyMember // 成员，按入队顺序
+}
+
+var (
+	parties    = make(map[int32]*Party) // playerId -> 所在的队伍，包括断线等待重连的成员
+	partyIdGen int32                    // 队伍id生成器
+	partyLock  sync.Mutex               // 保护全部队伍和组队邀请的锁
+)
+
+func init() {
+	RegisterChatChannel(mmopb.ChatChannel_Chat_Channel_Party, func(sender *Player) ([]*Player, error) {
+		partyLock.Lock()
+		defer partyLock.Unlock()
+
+		party, ok := parties[sender.PlayerId]
+		if !ok {
+			return nil, ErrChannelUnavailable
+		}
+		return party.onlinePlayers(), nil
+	})
+}
+
+// member 找到队伍成员，调用方需持有partyLock
+func (pt *Party) member(playerId int32) *PartyMember {
+	for _, member := range pt.Members {
+		if member.PlayerId == playerId {
+			return member
+		}
+	}
+	return nil
+}
+
+// onlinePlayers 在线的成员，调用方需持有partyLock
+func (pt *Party) onlinePlayers() []*Player {
+	players := make([]*Player, 0, len(pt.Members))
+	for _, member := range pt.Members {
+		if member.player != nil {
+			players = append(players, member.player)
+		}
+	}
+	return players
+}
+
+// stateMsg 成员状态，调用方需持有partyLock
+func (m *PartyMember) stateMsg() *mmopb.PartyMemberState {
+	msg := &mmopb.PartyMemberState{
+		PlayerId: m.PlayerId,
+	}
+	if p := m.player; p != nil {
+		msg.Online = true
+		msg.Level = p.Level
+		msg.Stats = p.StatsMsg()
+		msg.Pos = &mmopb.Position{
+			X: p.X,
+			Y: p.Y,
+			Z: p.Z,
+			V: p.V,
+		}
+		msg.SceneId = p.SceneId
+	}
+	return msg
+}
+
+// infoMsg 队伍信息，调用方需持有partyLock
+func (pt *Party) infoMsg() *mmopb.PartyInfo {
+	msg := &mmopb.PartyInfo{
+		PartyId:  pt.PartyId,
+		LeaderId: pt.LeaderId,
+		Members:  make([]*mmopb.PartyMemberInfo, 0, len(pt.Members)),
+	}
+	for _, member := range pt.Members {
+		msg.Members = append(msg.Members, &mmopb.PartyMemberInfo{
+			PlayerId: member.PlayerId,
+			Name:     member.Name,
+			Class:    member.Class,
+			State:    member.stateMsg(),
+		})
+	}
+	return msg
+}
+
+// partyNotice 在释放partyLock之后发送的队伍信息，避免持锁给正在断线的连接发消息
+type partyNotice struct {
+	players []*Player
+	msg     *mmopb.PartyInfo
+}
+
+// notice 通知全部在线成员，调用方需持有partyLock
+func (pt *Party) notice() *partyNotice {
+	return &partyNotice{players: pt.onlinePlayers(), msg: pt.infoMsg()}
+}
+
+// leftNotice 通知离开队伍的玩家已经不在队伍中
+func leftNotice(player *Player) *partyNotice {
+	if player == nil {
+		return nil
+	}
+	return &partyNotice{players: []*Player{player}, msg: &mmopb.PartyInfo{}}
+}
+
+// sendPartyNotices 发送队伍信息，不能持有partyLock
+func sendPartyNotices(notices ...*partyNotice) {
+	for _, notice := range notices {
+		if notice == nil {
+			continue
+		}
+		for _, player := range notice.players {
+			player.SendMessage(mmopb.SCMsgIdPartyInfo, notice.msg)
+		}
+	}
+}
+
+// removeMember 把成员移出队伍，队长离开时转让给下一个在线成员，不足两人时解散，调用方需持有partyLock。
+// 返回需要发送的通知
+func (pt *Party) removeMember(playerId int32) []*partyNotice {
+	var removed *PartyMember
+	members := make([]*PartyMember, 0, len(pt.Members))
+	for _, member := range pt.Members {
+		if member.PlayerId == playerId {
+			removed = member
+		} else {
+			members = append(members, member)
+		}
+	}
+	if removed == nil {
+		return nil
+	}
+	if removed.graceTimer != nil {
+		removed.graceTimer.Stop()
+	}
+	pt.Members = members
+	delete(parties, playerId)
+	notices := []*partyNotice{leftNotice(removed.player)}
+
+	// 只剩一个人时解散队伍
+	if len(pt.Members) < 2 {
+		for _, member := range pt.Members {
+			if member.graceTimer != nil {
+				member.graceTimer.Stop()
+			}
+			delete(parties, member.PlayerId)
+			notices = append(notices, leftNotice(member.player))
+		}
+		fmt.Println("======> party id = ", pt.PartyId, " disbanded <======")
+		return notices
+	}
+
+	pt.pickLeader()
+	return append(notices, pt.notice())
+}
+
+// pickLeader 队长离开或断线时转让给第一个在线成员，没有在线成员时断线的队长保留队长身份，
+// 离开的队长交给第一个成员，调用方需持有partyLock
+func (pt *Party) pickLeader() {
+	leader := pt.member(pt.LeaderId)
+	if leader != nil && leader.player != nil {
+		return
+	}
+	for _, member := range pt.Members {
+		if member.player != nil {
+			pt.LeaderId = member.PlayerId
+			return
+		}
+	}
+	if leader == nil {
+		pt.LeaderId = pt.Members[0].PlayerId
+	}
+}
+
+// sendPartyResult 告知队伍操作失败的原因
+func (p *Player) sendPartyResult(err error, targetId int32) {
+	if err == nil {
+		return
+	}
+	p.SendMessage(mmopb.SCMsgIdPartyResult, &mmopb.PartyResult{
+		Result:   resultCodeOf(err),
+		TargetId: targetId,
+	})
+}
+
+// InviteToParty 邀请玩家组队，没有队伍时对方接受后创建队伍
+func (p *Player) InviteToParty(targetId int32) {
+	p.sendPartyResult(p.inviteToParty(targetId), targetId)
+}
+
+func (p *Player) inviteToParty(targetId int32) error {
+	target := WorldMgrObj.GetPlayerById(targetId)
+	if target == nil || target == p {
+		return ErrTargetNotFound
+	}
+	// 被对方屏蔽时自动拒绝
+	if target.IsBlocking(p.PlayerId) {
+		return ErrBlocked
+	}
+
+	partyLock.Lock()
+	if party, ok := parties[p.PlayerId]; ok {
+		if party.LeaderId != p.PlayerId {
+			partyLock.Unlock()
+			return ErrNotPartyLeader
+		}
+		if len(party.Members) >= MAX_PARTY_MEMBERS {
+			partyLock.Unlock()
+			return ErrPartyFull
+		}
+	}
+	if _, ok := parties[targetId]; ok {
+		partyLock.Unlock()
+		return ErrAlreadyInParty
+	}
+	target.partyInviter = p.PlayerId
+	target.partyInviteAt = nowMillis()
+	partyLock.Unlock()
+
+	target.SendMessage(mmopb.SCMsgIdPartyInvitation, &mmopb.PartyInvitation{
+		InviterId:   p.PlayerId,
+		InviterName: p.Name,
+	})
+	return nil
+}
+
+// RespondParty 回应组队邀请，拒绝时通知邀请者
+func (p *Player) RespondParty(inviterId int32, accept bool) {
+	p.sendPartyResult(p.respondParty(inviterId, accept), inviterId)
+}
+
+func (p *Player) respondParty(inviterId int32, accept bool) error {
+	partyLock.Lock()
+	if p.partyInviter != inviterId || nowMillis()-p.partyInviteAt > PARTY_INVITE_TIMEOUT_MS {
+		partyLock.Unlock()
+		return ErrTargetNotFound
+	}
+	p.partyInviter = 0
+
+	inviter := WorldMgrObj.GetPlayerById(inviterId)
+	if inviter == nil || inviter.IsLeaving() {
+		partyLock.Unlock()
+		return ErrTargetNotFound
+	}
+	if !accept {
+		partyLock.Unlock()
+		inviter.sendPartyResult(ErrInviteDeclined, p.PlayerId)
+		return nil
+	}
+	if _, ok := parties[p.PlayerId]; ok {
+		partyLock.Unlock()
+		return ErrAlreadyInParty
+	}
+
+	party, ok := parties[inviterId]
+	if !ok {
+		partyIdGen++
+		party = &Party{
+			PartyId:  partyIdGen,
+			LeaderId: inviterId,
+			Members:  []*PartyMember{newPartyMember(inviter)},
+		}
+		parties[inviterId] = party
+		fmt.Println("======> party id = ", party.PartyId, " created by ", inviterId, " <======")
+	} else if party.LeaderId != inviterId {
+		partyLock.Unlock()
+		return ErrNotPartyLeader
+	} else if len(party.Members) >= MAX_PARTY_MEMBERS {
+		partyLock.Unlock()
+		return ErrPartyFull
+	}
+	party.Members = append(party.Members, newPartyMember(p))
+	parties[p.PlayerId] = party
+	notice := party.notice()
+	partyLock.Unlock()
+
+	fmt.Println("======> player id = ", p.PlayerId, " join party ", party.PartyId, " <======")
+	sendPartyNotices(notice)
+	return nil
+}
+
+// newPartyMember 在线玩家入队
+func newPartyMember(p *Player) *PartyMember {
+	return &PartyMember{
+		PlayerId: p.PlayerId,
+		Name:     p.Name,
+		Class:    p.Class,
+		player:   p,
+	}
+}
+
+// LeaveParty 离开队伍
+func (p *Player) LeaveParty() {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		p.sendPartyResult(ErrNotInParty, 0)
+		return
+	}
+	notices := party.removeMember(p.PlayerId)
+	partyLock.Unlock()
+
+	sendPartyNotices(notices...)
+}
+
+// KickFromParty 队长把成员踢出队伍
+func (p *Player) KickFromParty(playerId int32) {
+	p.sendPartyResult(p.kickFromParty(playerId), playerId)
+}
+
+func (p *Player) kickFromParty(playerId int32) error {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		return ErrNotInParty
+	}
+	if party.LeaderId != p.PlayerId {
+		partyLock.Unlock()
+		return ErrNotPartyLeader
+	}
+	if playerId == p.PlayerId || party.member(playerId) == nil {
+		partyLock.Unlock()
+		return ErrTargetNotFound
+	}
+	notices := party.removeMember(playerId)
+	partyLock.Unlock()
+
+	sendPartyNotices(notices...)
+	return nil
+}
+
+// TransferPartyLeader 队长转让给其他在线成员
+func (p *Player) TransferPartyLeader(playerId int32) {
+	p.sendPartyResult(p.transferPartyLeader(playerId), playerId)
+}
+
+func (p *Player) transferPartyLeader(playerId int32) error {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		return ErrNotInParty
+	}
+	if party.LeaderId != p.PlayerId {
+		partyLock.Unlock()
+		return ErrNotPartyLeader
+	}
+	member := party.member(playerId)
+	if member == nil || member.player == nil || playerId == p.PlayerId {
+		partyLock.Unlock()
+		return ErrTargetNotFound
+	}
+	party.LeaderId = playerId
+	notice := party.notice()
+	partyLock.Unlock()
+
+	sendPartyNotices(notice)
+	return nil
+}
+
+// onPartyOffline 断线时保留队伍位置，超过重连时间后移出队伍
+func (p *Player) onPartyOffline() {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		return
+	}
+	member := party.member(p.PlayerId)
+	member.player = nil
+	playerId := p.PlayerId
+	member.graceTimer = time.AfterFunc(time.Duration(PARTY_RECONNECT_GRACE_MS)*time.Millisecond, func() {
+		removeOfflinePartyMember(playerId)
+	})
+	// 断线的队长不能再邀请和踢人，交给在线的成员
+	party.pickLeader()
+	notice := party.notice()
+	partyLock.Unlock()
+
+	sendPartyNotices(notice)
+}
+
+// removeOfflinePartyMember 重连时间到了还没有上线的成员移出队伍
+func removeOfflinePartyMember(playerId int32) {
+	partyLock.Lock()
+	party, ok := parties[playerId]
+	if !ok {
+		partyLock.Unlock()
+		return
+	}
+	if member := party.member(playerId); member == nil || member.player != nil {
+		partyLock.Unlock()
+		return
+	}
+	notices := party.removeMember(playerId)
+	partyLock.Unlock()
+
+	fmt.Println("======> player id = ", playerId, " removed from party after reconnect grace <======")
+	sendPartyNotices(notices...)
+}
+
+// rejoinParty 重连时间内重新上线，回到原来的队伍
+func (p *Player) rejoinParty() {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		return
+	}
+	member := party.member(p.PlayerId)
+	if member.graceTimer != nil {
+		member.graceTimer.Stop()
+		member.graceTimer = nil
+	}
+	member.player = p
+	// 队长断线时没有在线成员的，由第一个回来的成员接任队长
+	party.pickLeader()
+	notice := party.notice()
+	partyLock.Unlock()
+
+	sendPartyNotices(notice)
+}
+
+// PartyMembersNear 在指定位置附近、同一场景并且存活的队友，包括自己，没有队伍时只有自己
+func (p *Player) PartyMembersNear(x, z float32) []*Player {
+	partyLock.Lock()
+	party, ok := parties[p.PlayerId]
+	if !ok {
+		partyLock.Unlock()
+		return []*Player{p}
+	}
+	players := party.onlinePlayers()
+	partyLock.Unlock()
+
+	members := []*Player{p}
+	for _, player := range players {
+		if player == p || player.SceneId != p.SceneId || player.IsDead() {
+			continue
+		}
+		if distance(x, z, player.X, player.Z) <= PARTY_SHARE_RANGE {
+			members = append(members, player)
+		}
+	}
+	return members
+}
+
+// shareExp 队友平分经验，每人至少1点
+func shareExp(exp int64, count int) int64 {
+	if exp <= 0 || count <= 1 {
+		return exp
+	}
+	share := exp / int64(count)
+	if share < 1 {
+		share = 1
+	}
+	return share
+}
+
+// StartPartySync 定时把队伍成员的血量和位置同步给队友，不受视野范围限制
+func StartPartySync() {
+	go func() {
+		ticker := time.NewTicker(PARTY_SYNC_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			syncParties()
+		}
+	}()
+}
+
+// syncParties 同步全部队伍的成员状态
+func syncParties() {
+	type partyStates struct {
+		players []*Player
+		msg     *mmopb.PartyStates
+	}
+
+	partyLock.Lock()
+	synced := make(map[int32]bool)
+	states := make([]*partyStates, 0)
+	for _, party := range parties {
+		if synced[party.PartyId] {
+			continue
+		}
+		synced[party.PartyId] = true
+
+		msg := &mmopb.PartyStates{
+			States: make([]*mmopb.PartyMemberState, 0, len(party.Members)),
+		}
+		for _, member := range party.Members {
+			msg.States = append(msg.States, member.stateMsg())
+		}
+		states = append(states, &partyStates{players: party.onlinePlayers(), msg: msg})
+	}
+	partyLock.Unlock()
+
+	for _, state := range states {
+		for _, player := range state.players {
+			player.SendMessage(mmopb.SCMsgIdPartyStates, state.msg)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package core
+
+import "testing"
+
+func TestShareExp(t *testing.T) {
+	cases := []struct {
+		exp   int64
+		count int
+		want  int64
+	}{
+		{100, 1, 100},
+		{100, 2, 50},
+		{100, 3, 33},
+		{2, 5, 1},
+		{0, 3, 0},
+	}
+	for _, c := range cases {
+		if got := shareExp(c.exp, c.count); got != c.want {
+			t.Errorf("shareExp(%d, %d) = %d, want %d", c.exp, c.count, got, c.want)
+		}
+	}
+}
+
+func TestParty_removeMember(t *testing.T) {
+	a, c := &Player{PlayerId: 901}, &Player{PlayerId: 903}
+	party := &Party{
+		PartyId:  901,
+		LeaderId: 901,
+		Members: []*PartyMember{
+			{PlayerId: 901, player: a},
+			{PlayerId: 902},
+			{PlayerId: 903, player: c},
+		},
+	}
+	for _, member := range party.Members {
+		parties[member.PlayerId] = party
+	}
+	defer func() {
+		delete(parties, 901)
+		delete(parties, 902)
+		delete(parties, 903)
+	}()
+
+	// 队长离开时转让给下一个在线成员，跳过断线的成员
+	party.removeMember(901)
+	if party.LeaderId != 903 || len(party.Members) != 2 {
+		t.Fatalf("leader = %d, members = %d", party.LeaderId, len(party.Members))
+	}
+	if _, ok := parties[901]; ok {
+		t.Fatal("removed member still in parties")
+	}
+
+	// 不足两人时解散
+	party.removeMember(902)
+	if _, ok := parties[903]; ok {
+		t.Fatal("party not disbanded")
+	}
+}
+
+func TestPlayer_onPartyOffline(t *testing.T) {
+	a, b, c := &Player{PlayerId: 901}, &Player{PlayerId: 902}, &Player{PlayerId: 903}
+	party := &Party{
+		PartyId:  901,
+		LeaderId: 901,
+		Members: []*PartyMember{
+			{PlayerId: 901, player: a},
+			{PlayerId: 902, player: b},
+			{PlayerId: 903, player: c},
+		},
+	}
+	for _, member := range party.Members {
+		parties[member.PlayerId] = party
+	}
+	defer func() {
+		for _, member := range party.Members {
+			if member.graceTimer != nil {
+				member.graceTimer.Stop()
+			}
+			delete(parties, member.PlayerId)
+		}
+	}()
+
+	// 队长断线时转让给在线的成员
+	a.onPartyOffline()
+	if party.LeaderId != 902 {
+		t.Fatalf("leader = %d, want 902", party.LeaderId)
+	}
+	b.onPartyOffline()
+	if party.LeaderId != 903 {
+		t.Fatalf("leader = %d, want 903", party.LeaderId)
+	}
+
+	// 没有在线成员时保留，第一个回来的成员接任
+	c.onPartyOffline()
+	if party.LeaderId != 903 {
+		t.Fatalf("leader = %d, want 903", party.LeaderId)
+	}
+	a.rejoinParty()
+	if party.LeaderId != 901 {
+		t.Fatalf("leader = %d, want 901", party.LeaderId)
+	}
+}
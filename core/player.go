@@ -2,8 +2,8 @@ package core
 
 import (
 	"fmt"
-	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"aoi_mmo_game/mmopb"
@@ -17,30 +17,109 @@ import (
 type Player struct {
 	PlayerId int32              // 玩家id
 	Conn     ziface.IConnection // 当前玩家连接
+	leaving  int32              // 连接正在关闭，为1时不再给该玩家发消息
+	account  string             // 所属账号
+	gmLevel  int32              // GM权限等级
 	X        float32            // 平面x坐标
 	Y        float32            // 高度
 	Z        float32            // 平面y坐标
 	V        float32            // 旋转0-360度
-}
 
-// playerIdGen playerId生成器
-var playerIdGen int32 = 1
-var playerIdLock sync.Mutex
+	lastMoveAt int64 // 上次移动的时间(unix毫秒)
+
+	Name         string            // 显示名称
+	Class        mmopb.PlayerClass // 职业
+	AppearanceId int32             // 外观id
+	Level        int32             // 等级
+	Exp          int64             // 当前等级已有的经验
+	levelLock    sync.Mutex        // 保护等级和经验的锁
+	SceneId      int32             // 所在场景id
+
+	Bag       *Inventory                // 背包
+	equips    map[mmopb.EquipSlot]int32 // 装备部位 -> 物品id
+	equipLock sync.Mutex                // 保护equips的锁
+
+	quests     map[int32]*ActiveQuest // 进行中的任务
+	doneQuests map[int32]bool         // 已完成的任务
+	questLock  sync.Mutex             // 保护任务的锁
+
+	chatLimit *ChatLimiter // 发言限制
+
+	blocked   map[int32]bool // 屏蔽的玩家
+	blockLock sync.Mutex     // 保护blocked的锁
+
+	loopAction   int32      // 正在播放的循环动作，0表示没有
+	lastActionAt int64      // 上次播放动作的时间(unix毫秒)
+	actionLock   sync.Mutex // 保护动作状态的锁
+
+	zones    map[int32]*Zone // 当前所在的触发区域
+	zoneLock sync.Mutex      // 保护zones的锁
+
+	trade         *Trade // 正在进行的交易，由tradeLock保护
+	tradeInviter  int32  // 最近一次收到的交易邀请的发起者
+	tradeInviteAt int64  // 收到交易邀请的时间(unix毫秒)
+
+	partyInviter  int32 // 最近一次收到的组队邀请的发起者，由partyLock保护
+	partyInviteAt int64 // 收到组队邀请的时间(unix毫秒)
+
+	friends     map[int32]bool              // 好友，由friendLock保护
+	friendReqs  map[int32]int64             // 收到的好友请求，请求者id -> 请求时间(unix毫秒)，由friendLock保护
+	friendInfos map[int32]*mmopb.FriendInfo // 离线好友的显示信息，上线时读档，好友下线时更新，由friendLock保护
 
-func NewPlayer(conn ziface.IConnection) *Player {
-	playerIdLock.Lock()
-	playerId := playerIdGen
-	playerIdGen++
-	playerIdLock.Unlock()
+	CombatUnit
+}
 
-	return &Player{
-		PlayerId: playerId,
-		Conn:     conn,
-		X:        float32(160 + rand.Intn(10)),
-		Y:        0,
-		Z:        float32(134 + rand.Intn(17)),
-		V:        0,
+// NewPlayer 根据存档数据创建玩家对象
+func NewPlayer(conn ziface.IConnection, data *PlayerData) *Player {
+	player := &Player{
+		PlayerId:     data.PlayerId,
+		Conn:         conn,
+		X:            data.X,
+		Y:            data.Y,
+		Z:            data.Z,
+		V:            data.V,
+		Name:         data.Name,
+		Class:        data.Class,
+		AppearanceId: data.AppearanceId,
+		Level:        ClampLevel(data.Level),
+		Exp:          data.Exp,
+		SceneId:      GetScene(data.SceneId).SceneId,
+		Bag:          NewInventory(BAG_SIZE),
+		equips:       make(map[mmopb.EquipSlot]int32),
+		quests:       make(map[int32]*ActiveQuest),
+		doneQuests:   make(map[int32]bool),
+		zones:        make(map[int32]*Zone),
+		chatLimit:    NewChatLimiter(data.ChatMutedUntil, data.ChatMuteLevel),
+		blocked:      make(map[int32]bool),
+		friends:      make(map[int32]bool),
+		friendReqs:   make(map[int32]int64),
+		friendInfos:  make(map[int32]*mmopb.FriendInfo),
+	}
+	player.Bag.Load(data.Items, data.Gold)
+
+	player.SetBaseStats(ClassStats(data.Class, player.Level))
+	player.loadEquips(data.Equips)
+	player.loadQuests(data.Quests, data.DoneQuests)
+	player.loadBlocked(data.Blocked)
+	player.loadFriends(data.Friends)
+	player.HP = data.HP
+	player.MP = data.MP
+	// 死亡状态下线的玩家，上线时在最近的复活点复活
+	if player.HP <= 0 {
+		point := GetScene(player.SceneId).NearestRespawnPoint(player.X, player.Z)
+		player.X = point.X
+		player.Z = point.Z
+		player.HP = player.MaxHP
+		player.MP = player.MaxMP
+	}
+	if player.HP > player.MaxHP {
+		player.HP = player.MaxHP
 	}
+	if player.MP < 0 || player.MP > player.MaxMP {
+		player.MP = player.MaxMP
+	}
+	player.restoreBuffs(data.Buffs, nowMillis())
+	return player
 }
 
 func (p *Player) SendMessage(msgId uint32, data proto.Message) {
@@ -56,14 +135,241 @@ func (p *Player) SendMessage(msgId uint32, data proto.Message) {
 		fmt.Println("connection in player is nil")
 		return
 	}
+	// 连接关闭回调执行期间zinx持有连接的写锁，这时再发消息会一直阻塞
+	if p.IsLeaving() {
+		return
+	}
 
 	fmt.Println("send message id=", msgId, " len=", len(msg))
-	if err := p.Conn.SendMsg(msgId, msg); err != nil {
+	if err := sendToConn(p.Conn, msgId, msg); err != nil {
 		fmt.Println("player send message err: ", err)
 		return
 	}
 }
 
+// IsLeaving 连接是否正在关闭，玩家可能还没有从世界管理器中摘除
+func (p *Player) IsLeaving() bool {
+	return atomic.LoadInt32(&p.leaving) == 1
+}
+
+// EnterWorld 玩家进入世界
+func (p *Player) EnterWorld() {
+	// 同步当前playerId给客户端，走msgId:1消息
+	p.SyncPlayerId()
+	// 同步当前玩家的初始化坐标信息给客户端，走msgId:200消息
+	p.BroadCastStartPosition()
+
+	// 添加到世界管理器
+	WorldMgrObj.AddPlayer(p)
+
+	// 绑定连接和playerId
+	p.Conn.SetProperty("playerId", p.PlayerId)
+
+	// 同步周围玩家信息
+	p.SyncSurrounding()
+
+	// 同步经验、背包和任务
+	p.SendExp(0, mmopb.ExpSource_Exp_Source_Unknown)
+	p.SendInventory()
+	p.SendQuests()
+	p.SendBlockList()
+
+	// 同步好友列表并通知好友自己上线，
+	// 连接建立时还没有选择角色，所以上线通知在进入世界时发送
+	p.SendFriendList()
+	p.NotifyFriendPresence(true)
+
+	// 重连时间内重新上线的回到原来的队伍
+	p.rejoinParty()
+
+	// 先发频道的最近消息，再补发离线期间收到的私聊，
+	// 必须在加入世界管理器之后，保证之后的私聊都能直接送达
+	p.SendChatHistory()
+	p.SendOfflineChats()
+
+	// 进入出生位置所在的区域
+	p.updateZones()
+
+	fmt.Println("======> player id = ", p.PlayerId, " arrived <======")
+}
+
+// GetEntityId 实体id
+func (p *Player) GetEntityId() int32 {
+	return p.PlayerId
+}
+
+// GetPos 实体平面坐标
+func (p *Player) GetPos() (float32, float32) {
+	return p.X, p.Z
+}
+
+// GetCombatUnit 实体战斗属性
+func (p *Player) GetCombatUnit() *CombatUnit {
+	return &p.CombatUnit
+}
+
+// OnDeath 玩家死亡，发送可选的复活点
+func (p *Player) OnDeath(killer Combatant) {
+	p.stopLoopAction()
+	fmt.Println("======> player id = ", p.PlayerId, " killed by ", killer.GetEntityId(), " <======")
+	p.SendMessage(mmopb.SCMsgIdRespawnOptions, GetScene(p.SceneId).RespawnOptionsMsg(p.X, p.Z))
+}
+
+// Respawn 玩家选择复活点复活，pointId为0时使用最近的复活点
+func (p *Player) Respawn(pointId int32) {
+	result := mmopb.ResultCode_Result_Ok
+	scene := GetScene(p.SceneId)
+
+	var point *RespawnPoint
+	if !p.IsDead() {
+		result = mmopb.ResultCode_Result_Not_Dead
+	} else if pointId == 0 {
+		point = scene.NearestRespawnPoint(p.X, p.Z)
+	} else if point = scene.GetRespawnPoint(pointId); point == nil {
+		result = mmopb.ResultCode_Result_Point_Not_Found
+	}
+
+	if result != mmopb.ResultCode_Result_Ok {
+		p.SendMessage(mmopb.SCMsgIdRespawnResult, &mmopb.RespawnResult{
+			Result:  result,
+			PointId: pointId,
+		})
+		return
+	}
+
+	p.Revive()
+	p.Teleport(point.X, point.Z)
+	WorldMgrObj.BroadCastAround(p.X, p.Z, mmopb.SCMsgIdRevive, &mmopb.Revive{
+		EntityId: p.PlayerId,
+		Pos: &mmopb.Position{
+			X: p.X,
+			Y: p.Y,
+			Z: p.Z,
+			V: p.V,
+		},
+		Stats: p.StatsMsg(),
+	})
+	fmt.Println("======> player id = ", p.PlayerId, " respawn at point ", point.PointId, " <======")
+}
+
+// Teleport 传送到同场景的指定坐标，在旧位置周围消失，并重新同步新位置的视野
+func (p *Player) Teleport(x, z float32) {
+	CancelCast(p)
+	p.stopLoopAction()
+
+	// 让自己和旧视野中的实体互相消失
+	leaveMsg := &mmopb.SyncPlayerId{
+		PlayerId: p.PlayerId,
+	}
+	for _, player := range p.GetSurroundingPlayers() {
+		if player == nil || player.PlayerId == p.PlayerId {
+			continue
+		}
+		player.SendMessage(mmopb.SCMsgIdPlayerLeave, leaveMsg)
+		p.SendMessage(mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+			PlayerId: player.PlayerId,
+		})
+	}
+	for _, grid := range WorldMgrObj.AoiMgr.GetSurroundGridsByGid(WorldMgrObj.AoiMgr.GetGidByPos(p.X, p.Z)) {
+		for _, monster := range WorldMgrObj.GetMonstersByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+				PlayerId: monster.MonsterId,
+			})
+		}
+		for _, loot := range WorldMgrObj.GetLootsByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+				PlayerId: loot.LootId,
+			})
+		}
+	}
+
+	// 更新AOI中的位置
+	WorldMgrObj.AoiMgr.RemoveFromGridByPos(int(p.PlayerId), p.X, p.Z)
+	p.X = x
+	p.Z = z
+	WorldMgrObj.AoiMgr.AddPlayerIdToGridByPos(int(p.PlayerId), p.X, p.Z)
+
+	// 同步新位置给自己，并同步新的视野
+	p.BroadCastStartPosition()
+	p.SyncSurrounding()
+	p.checkTradeRange()
+	p.checkQuestLocations()
+	p.updateZones()
+}
+
+// AttackTarget 玩家发起普通攻击，失败时告知原因
+func (p *Player) AttackTarget(targetId int32) {
+	result := Attack(p, targetId)
+	if result != mmopb.ResultCode_Result_Ok {
+		p.SendMessage(mmopb.SCMsgIdAttackResult, &mmopb.AttackResult{
+			Result:   result,
+			TargetId: targetId,
+		})
+	}
+}
+
+// CastSkill 玩家释放技能，失败时告知原因
+func (p *Player) CastSkill(skillId int32, targetId int32, x, z float32) {
+	result := mmopb.ResultCode_Result_Skill_Not_Found
+	skill := GetSkillTemplate(skillId)
+	if skill != nil && (skill.Class == mmopb.PlayerClass_Class_Unknown || skill.Class == p.Class) {
+		result = CastSkill(p, skill, targetId, x, z)
+	}
+
+	if result != mmopb.ResultCode_Result_Ok {
+		p.SendMessage(mmopb.SCMsgIdCastSkillResult, &mmopb.CastSkillResult{
+			Result:  result,
+			SkillId: skillId,
+		})
+	}
+}
+
+// GetLatency 获取玩家连接的延迟统计，供GM工具查询
+func (p *Player) GetLatency() LatencyStat {
+	if p.Conn == nil {
+		return LatencyStat{}
+	}
+	if session := SessionMgrObj.GetSession(p.Conn.GetConnID()); session != nil {
+		return session.GetLatency()
+	}
+	return LatencyStat{}
+}
+
+// ProfileMsg 玩家显示数据
+func (p *Player) ProfileMsg() *mmopb.PlayerProfile {
+	return &mmopb.PlayerProfile{
+		Name:         p.Name,
+		Class:        p.Class,
+		AppearanceId: p.AppearanceId,
+		Level:        p.Level,
+		Equips:       p.equipVisuals(),
+	}
+}
+
+// SetAppearance 修改外观，并通知周围玩家
+func (p *Player) SetAppearance(appearanceId int32) {
+	p.AppearanceId = appearanceId
+	p.BroadCastProfile()
+}
+
+// BroadCastProfile 向九宫格内的玩家(包括自己)广播显示数据变化
+func (p *Player) BroadCastProfile() {
+	p.BroadCastVisible(mmopb.SCMsgIdBroadCast, &mmopb.BroadCast{
+		PlayerId: p.PlayerId,
+		Type:     mmopb.BroadCastType_Player_Profile,
+		Profile:  p.ProfileMsg(),
+	})
+}
+
+// BroadCastVisible 广播给周围能看到自己的玩家，隐身时只发给自己
+func (p *Player) BroadCastVisible(msgId uint32, msg proto.Message) {
+	if p.IsStealthed() {
+		p.SendMessage(msgId, msg)
+		return
+	}
+	WorldMgrObj.BroadCastAround(p.X, p.Z, msgId, msg)
+}
+
 // SyncPlayerId 同步playerId给客户端
 func (p *Player) SyncPlayerId() {
 	msg := &mmopb.SyncPlayerId{
@@ -85,49 +391,19 @@ func (p *Player) BroadCastStartPosition() {
 				V: p.V,
 			},
 		},
+		Profile: p.ProfileMsg(),
 	}
 
 	// 告知自己的位置
 	p.SendMessage(mmopb.SCMsgIdBroadCast, msg)
 }
 
-// BroadCastTalk 广播玩家聊天
-func (p *Player) BroadCastTalk(content string) {
-	msg := &mmopb.BroadCast{
-		PlayerId: p.PlayerId,
-		Type:     mmopb.BroadCastType_World_Chat,
-		Data: &mmopb.BroadCast_Content{
-			Content: content,
-		},
-	}
-
-	players := WorldMgrObj.GetAllPlayers()
-
-	for _, player := range players {
-		player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
-	}
-}
-
-func (p *Player) TalkToTargetPlayer(targetPlayerId int32, content string) {
-	targetPlayer := WorldMgrObj.GetPlayerById(targetPlayerId)
-	if targetPlayer != nil {
-		talkToMsg := &mmopb.BroadCast{
-			PlayerId: p.PlayerId,
-			Type:     mmopb.BroadCastType_World_Chat,
-			Data: &mmopb.BroadCast_Content{
-				Content: content,
-			},
-		}
-		targetPlayer.SendMessage(mmopb.SCMsgIdBroadCast, talkToMsg)
-	}
-}
-
 // SyncSurrounding 给当前九宫格范围内玩家广播自己的位置
 func (p *Player) SyncSurrounding() {
 	// 找出附近的玩家id
 	pids := WorldMgrObj.AoiMgr.GetPlayerIdsByPos(p.X, p.Z)
 	// 找出附近的玩家对象
-	players := make([]*Player, len(pids))
+	players := make([]*Player, 0, len(pids))
 	for _, pid := range pids {
 		player := WorldMgrObj.GetPlayerById(int32(pid))
 		if player != nil {
@@ -146,18 +422,27 @@ func (p *Player) SyncSurrounding() {
 				V: p.V,
 			},
 		},
+		Profile: p.ProfileMsg(),
 	}
 
 	// 发送位置消息，并对自己同步周围玩家信息
-	playersData := make([]*mmopb.Player, len(players))
+	stealthed := p.IsStealthed()
+	playersData := make([]*mmopb.Player, 0, len(players))
 	for _, player := range players {
 		if player == nil {
 			continue
 		}
 
-		// 不用自己给自己同步位置
+		// 不用自己给自己同步位置，隐身的玩家互相不可见
 		if p.PlayerId != player.PlayerId {
-			player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+			if !stealthed {
+				player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+				SendBuffs(p, player)
+				SendLoopAction(p, player)
+			}
+			if player.IsStealthed() {
+				continue
+			}
 
 			mmoplayer := &mmopb.Player{
 				PlayerId: player.PlayerId,
@@ -167,19 +452,106 @@ func (p *Player) SyncSurrounding() {
 					Z: player.Z,
 					V: player.V,
 				},
+				Profile: player.ProfileMsg(),
+				Stats:   player.StatsMsg(),
 			}
 			playersData = append(playersData, mmoplayer)
 		}
 	}
 
+	// 周围的怪物和掉落物
+	monsters := make([]*Monster, 0)
+	monstersData := make([]*mmopb.Monster, 0)
+	lootsData := make([]*mmopb.GroundLoot, 0)
+	for _, grid := range WorldMgrObj.AoiMgr.GetSurroundGridsByGid(WorldMgrObj.AoiMgr.GetGidByPos(p.X, p.Z)) {
+		for _, monster := range WorldMgrObj.GetMonstersByGid(grid.GID) {
+			monsters = append(monsters, monster)
+			monstersData = append(monstersData, monster.MonsterMsg())
+		}
+		for _, loot := range WorldMgrObj.GetLootsByGid(grid.GID) {
+			lootsData = append(lootsData, loot.LootMsg())
+		}
+	}
+
 	syncMsg := &mmopb.SyncPlayers{
-		Players: playersData,
+		Players:  playersData,
+		Monsters: monstersData,
+		Loots:    lootsData,
 	}
 	p.SendMessage(mmopb.SCMsgIdSyncPlayers, syncMsg)
+
+	// 同步自己和视野内实体身上的buff
+	SendBuffs(p, p)
+	for _, player := range players {
+		if player.PlayerId != p.PlayerId && !player.IsStealthed() {
+			SendBuffs(player, p)
+			SendLoopAction(player, p)
+		}
+	}
+	for _, monster := range monsters {
+		SendBuffs(monster, p)
+	}
+}
+
+// OnStealthChanged 隐身状态变化，让自己在周围玩家的视野中消失或重新出现
+func (p *Player) OnStealthChanged(stealthed bool) {
+	leaveMsg := &mmopb.SyncPlayerId{
+		PlayerId: p.PlayerId,
+	}
+	appearMsg := &mmopb.BroadCast{
+		PlayerId: p.PlayerId,
+		Type:     mmopb.BroadCastType_Player_Pos,
+		Data: &mmopb.BroadCast_Pos{
+			Pos: &mmopb.Position{
+				X: p.X,
+				Y: p.Y,
+				Z: p.Z,
+				V: p.V,
+			},
+		},
+		Profile: p.ProfileMsg(),
+	}
+
+	for _, player := range p.GetSurroundingPlayers() {
+		if player == nil || player.PlayerId == p.PlayerId {
+			continue
+		}
+		if stealthed {
+			player.SendMessage(mmopb.SCMsgIdPlayerLeave, leaveMsg)
+		} else {
+			player.SendMessage(mmopb.SCMsgIdBroadCast, appearMsg)
+			SendBuffs(p, player)
+			SendLoopAction(p, player)
+		}
+	}
 }
 
 // UpdatePos 玩家更新位置
 func (p *Player) UpdatePos(x float32, y float32, z float32, v float32) {
+	// 死亡状态不能移动，把服务器记录的位置发回给自己
+	if p.IsDead() {
+		p.SendMessage(mmopb.SCMsgIdBroadCast, &mmopb.BroadCast{
+			PlayerId: p.PlayerId,
+			Type:     mmopb.BroadCastType_After_Move,
+			Data: &mmopb.BroadCast_Pos{
+				Pos: &mmopb.Position{
+					X: p.X,
+					Y: p.Y,
+					Z: p.Z,
+					V: p.V,
+				},
+			},
+		})
+		return
+	}
+
+	// 按移动速度限制单次移动的距离，周围玩家和自己收到的都是限制后的位置
+	x, z = p.clampMove(x, z, nowMillis())
+
+	// 移动打断施法和循环动作
+	CancelCast(p)
+	p.stopLoopAction()
+
 	// 计算新旧格子变化
 	oldGid := WorldMgrObj.AoiMgr.GetGidByPos(p.X, p.Z)
 	newGid := WorldMgrObj.AoiMgr.GetGidByPos(x, z)
@@ -195,7 +567,7 @@ func (p *Player) UpdatePos(x float32, y float32, z float32, v float32) {
 		// 把playerId从旧的grid中移除
 		WorldMgrObj.AoiMgr.RemovePlayerIdFromGrid(int(p.PlayerId), oldGid)
 		// 添加到新的格子去
-		WorldMgrObj.AoiMgr.AddPlayerIdToGrid(int(p.PlayerId), oldGid)
+		WorldMgrObj.AoiMgr.AddPlayerIdToGrid(int(p.PlayerId), newGid)
 		// 视野切换
 		_ = p.OnExchangeAoiGrid(oldGid, newGid)
 	}
@@ -214,13 +586,43 @@ func (p *Player) UpdatePos(x float32, y float32, z float32, v float32) {
 		},
 	}
 
-	// 找到附近的玩家
-	players := p.GetSurroundingPlayers()
-	for _, player := range players {
-		if player != nil && player.PlayerId > 0 {
-			player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+	if p.IsStealthed() {
+		// 隐身时只同步给自己
+		p.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+	} else {
+		// 找到附近的玩家
+		players := p.GetSurroundingPlayers()
+		for _, player := range players {
+			if player != nil && player.PlayerId > 0 {
+				player.SendMessage(mmopb.SCMsgIdBroadCast, msg)
+			}
 		}
 	}
+
+	// 走出交易距离时取消交易，更新任务的到达目标和所在的区域，区域事件可能会传送玩家，放在最后
+	p.checkTradeRange()
+	p.checkQuestLocations()
+	p.updateZones()
+}
+
+// clampMove 按移动速度和距离上次移动经过的时间计算最远能到达的位置，超出时沿移动方向截断
+func (p *Player) clampMove(x, z float32, now int64) (float32, float32) {
+	elapsed := now - p.lastMoveAt
+	if elapsed > MOVE_MAX_INTERVAL_MS {
+		elapsed = MOVE_MAX_INTERVAL_MS
+	}
+	p.lastMoveAt = now
+
+	maxDist := float32(0)
+	if speed := p.GetMoveSpeed(); speed > 0 {
+		maxDist = speed*float32(elapsed)/1000 + MOVE_TOLERANCE
+	}
+	dist := distance(p.X, p.Z, x, z)
+	if dist <= maxDist {
+		return x, z
+	}
+	ratio := maxDist / dist
+	return p.X + (x-p.X)*ratio, p.Z + (z-p.Z)*ratio
 }
 
 // GetSurroundingPlayers 找到九宫格内的所有玩家
@@ -238,6 +640,8 @@ func (p *Player) GetSurroundingPlayers() []*Player {
 
 // LostConnection 玩家下线
 func (p *Player) LostConnection() {
+	atomic.StoreInt32(&p.leaving, 1)
+
 	// 1 获取周围AOI九宫格内的玩家
 	players := p.GetSurroundingPlayers()
 
@@ -254,11 +658,51 @@ func (p *Player) LostConnection() {
 		}
 	}
 
-	// 4 世界管理器将当前玩家从AOI中摘除
+	// 4 打断施法和交易，保存玩家数据
+	CancelCast(p)
+	p.CancelTrade(mmopb.TradeCloseReason_Trade_Disconnect)
+	p.onPartyOffline()
+	p.leaveAllZones()
+	p.Save()
+
+	// 5 世界管理器将当前玩家从AOI中摘除
 	WorldMgrObj.AoiMgr.RemoveFromGridByPos(int(p.PlayerId), p.X, p.Z)
 	WorldMgrObj.RemovePlayerById(p.PlayerId)
 }
 
+// Save 保存玩家数据
+func (p *Player) Save() {
+	data := &PlayerData{
+		PlayerId: p.PlayerId,
+		X:        p.X,
+		Y:        p.Y,
+		Z:        p.Z,
+		V:        p.V,
+
+		Name:         p.Name,
+		Class:        p.Class,
+		AppearanceId: p.AppearanceId,
+		Level:        p.Level,
+		Exp:          p.Exp,
+		SceneId:      p.SceneId,
+
+		HP: p.HP,
+		MP: p.MP,
+
+		Buffs:  p.persistBuffs(nowMillis()),
+		Items:  p.Bag.Snapshot(),
+		Gold:   p.Bag.Gold(),
+		Equips: p.equipSnapshot(),
+	}
+	data.Quests, data.DoneQuests = p.questSnapshot()
+	data.ChatMutedUntil, data.ChatMuteLevel = p.chatLimit.MuteState()
+	data.Blocked = p.blockedSnapshot()
+	data.Friends = p.friendsSnapshot()
+	if err := StorageObj.SavePlayer(data); err != nil {
+		fmt.Println("save player id = ", p.PlayerId, " err: ", err)
+	}
+}
+
 // OnExchangeAoiGrid 跨格子视野切换
 func (p *Player) OnExchangeAoiGrid(oldGid int, newGid int) error {
 	// 获取旧的九宫格成员
@@ -305,6 +749,18 @@ func (p *Player) OnExchangeAoiGrid(oldGid int, newGid int) error {
 				time.Sleep(200 * time.Millisecond)
 			}
 		}
+
+		// 将格子中的怪物和掉落物在自己的客户端中消失
+		for _, monster := range WorldMgrObj.GetMonstersByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+				PlayerId: monster.MonsterId,
+			})
+		}
+		for _, loot := range WorldMgrObj.GetLootsByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+				PlayerId: loot.LootId,
+			})
+		}
 	}
 
 	// ========== 处理视野出现 ==========
@@ -326,15 +782,25 @@ func (p *Player) OnExchangeAoiGrid(oldGid int, newGid int) error {
 				V: p.V,
 			},
 		},
+		Profile: p.ProfileMsg(),
 	}
 
 	// 获取需要显示格子的全部玩家
+	stealthed := p.IsStealthed()
 	for _, grid := range enteringGrids {
 		players := WorldMgrObj.GetPlayersByGid(grid.GID)
 		for _, player := range players {
 			if player != nil {
-				// 让自己出现在别人视野中
-				player.SendMessage(mmopb.SCMsgIdBroadCast, onlineMsg)
+				// 让自己出现在别人视野中，隐身时不出现
+				if !stealthed {
+					player.SendMessage(mmopb.SCMsgIdBroadCast, onlineMsg)
+					SendBuffs(p, player)
+				}
+
+				// 隐身的玩家不出现在自己的视野中
+				if player.IsStealthed() {
+					continue
+				}
 
 				// 让其他人出现在自己的视野中
 				anotherOnlineMsg := &mmopb.BroadCast{
@@ -348,12 +814,24 @@ func (p *Player) OnExchangeAoiGrid(oldGid int, newGid int) error {
 							V: player.V,
 						},
 					},
+					Profile: player.ProfileMsg(),
 				}
 
 				p.SendMessage(mmopb.SCMsgIdBroadCast, anotherOnlineMsg)
+				SendBuffs(player, p)
+				SendLoopAction(player, p)
 				time.Sleep(200 * time.Millisecond)
 			}
 		}
+
+		// 让格子中的怪物和掉落物出现在自己的视野中
+		for _, monster := range WorldMgrObj.GetMonstersByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdMonsterAppear, monster.MonsterMsg())
+			SendBuffs(monster, p)
+		}
+		for _, loot := range WorldMgrObj.GetLootsByGid(grid.GID) {
+			p.SendMessage(mmopb.SCMsgIdLootAppear, loot.LootMsg())
+		}
 	}
 	return nil
 }
@@ -0,0 +1,30 @@
+package core
+
+import "testing"
+
+func TestPlayer_clampMove(t *testing.T) {
+	p := &Player{X: 100, Z: 100}
+	p.MoveSpeed = 6
+
+	// 1秒内最多移动速度加上误差的距离
+	p.lastMoveAt = 1000
+	if x, z := p.clampMove(103, 104, 2000); x != 103 || z != 104 {
+		t.Fatalf("move within speed clamped to (%v, %v)", x, z)
+	}
+	p.lastMoveAt = 1000
+	if x, z := p.clampMove(130, 100, 2000); x != 107 || z != 100 {
+		t.Fatalf("move = (%v, %v), want (107, 100)", x, z)
+	}
+
+	// 停了很久之后再走也只按最长间隔计算
+	p.lastMoveAt = 0
+	if x, _ := p.clampMove(130, 100, 60000); x != 107 {
+		t.Fatalf("move after idle x = %v, want 107", x)
+	}
+
+	// 速度为0时不能移动
+	p.MoveSpeed = 0
+	if x, z := p.clampMove(101, 100, 70000); x != 100 || z != 100 {
+		t.Fatalf("frozen move = (%v, %v)", x, z)
+	}
+}
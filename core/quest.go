@@ -0,0 +1,486 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"aoi_mmo_game/mmopb"
+)
+
+var (
+	ErrQuestNotFound    = errors.New("quest not found")
+	ErrQuestUnavailable = errors.New("quest unavailable")
+	ErrQuestNotReady    = errors.New("quest not ready")
+)
+
+// 任务目标类型
+const (
+	QUEST_OBJECTIVE_KILL    string = "kill"    // 击杀指定怪物
+	QUEST_OBJECTIVE_COLLECT string = "collect" // 背包中持有指定物品，交付时扣除
+	QUEST_OBJECTIVE_REACH   string = "reach"   // 到达指定地点
+	QUEST_OBJECTIVE_TALK    string = "talk"    // 和指定NPC对话
+)
+
+// QuestObjective 任务目标
+type QuestObjective struct {
+	Type     string  `json:"type"`      // 目标类型
+	TargetId int32   `json:"target_id"` // 怪物模板id、物品id或NPC id
+	Count    int32   `json:"count"`     // 需要的数量，到达和对话固定为1
+	SceneId  int32   `json:"scene_id"`  // 到达目标所在的场景
+	X        float32 `json:"x"`         // 到达目标的平面x坐标
+	Z        float32 `json:"z"`         // 到达目标的平面y坐标
+	Radius   float32 `json:"radius"`    // 到达目标的半径
+}
+
+// inside 坐标是否在到达目标的范围内
+func (o *QuestObjective) inside(sceneId int32, x, z float32) bool {
+	return o.SceneId == sceneId && distance(x, z, o.X, o.Z) <= o.Radius
+}
+
+// QuestTemplate 任务模板
+type QuestTemplate struct {
+	QuestId     int32             `json:"quest_id"`     // 任务id
+	Name        string            `json:"name"`         // 任务名称
+	Level       int32             `json:"level"`        // 接取需要的等级
+	PreQuest    int32             `json:"pre_quest"`    // 需要先完成的任务，0表示没有
+	GiverNpc    int32             `json:"giver_npc"`    // 接取任务的NPC，0表示任何地方都可以接取
+	TurnInNpc   int32             `json:"turn_in_npc"`  // 交付任务的NPC，0表示任何地方都可以交付
+	Objectives  []*QuestObjective `json:"objectives"`   // 任务目标
+	RewardExp   int64             `json:"reward_exp"`   // 奖励经验
+	RewardGold  int64             `json:"reward_gold"`  // 奖励金币
+	RewardItems []*ItemStack      `json:"reward_items"` // 奖励物品
+}
+
+// QuestConfig 任务数据文件
+type QuestConfig struct {
+	Quests []*QuestTemplate `json:"quests"`
+}
+
+// questTemplates 全部任务模板
+var questTemplates = make(map[int32]*QuestTemplate)
+
+// questLocations 格子id -> 范围与该格子相交的到达目标，移动时只检查所在格子的目标
+var questLocations = make(map[int][]*QuestObjective)
+
+// LoadQuests 读取任务数据文件，物品、怪物和场景数据需要先加载
+func LoadQuests() error {
+	config := &QuestConfig{}
+	if err := loadConfig("quests.json", config); err != nil {
+		return err
+	}
+
+	for _, quest := range config.Quests {
+		if len(quest.Objectives) == 0 {
+			return fmt.Errorf("quest id %d has no objective", quest.QuestId)
+		}
+		for _, npcId := range []int32{quest.GiverNpc, quest.TurnInNpc} {
+			if npcId > 0 && GetNpc(npcId) == nil {
+				return fmt.Errorf("quest id %d npc id %d not exist", quest.QuestId, npcId)
+			}
+		}
+		for _, obj := range quest.Objectives {
+			if err := checkObjective(obj); err != nil {
+				return fmt.Errorf("quest id %d %v", quest.QuestId, err)
+			}
+		}
+		for _, stack := range quest.RewardItems {
+			if GetItemTemplate(stack.ItemId) == nil {
+				return fmt.Errorf("quest id %d reward item id %d not exist", quest.QuestId, stack.ItemId)
+			}
+		}
+		questTemplates[quest.QuestId] = quest
+	}
+
+	for _, quest := range questTemplates {
+		if quest.PreQuest > 0 && GetQuestTemplate(quest.PreQuest) == nil {
+			return fmt.Errorf("quest id %d pre quest id %d not exist", quest.QuestId, quest.PreQuest)
+		}
+		// 把到达目标登记到范围覆盖的格子中
+		for _, obj := range quest.Objectives {
+			if obj.Type != QUEST_OBJECTIVE_REACH {
+				continue
+			}
+			for _, gid := range WorldMgrObj.AoiMgr.GetGidsByRect(obj.X-obj.Radius, obj.Z-obj.Radius, obj.X+obj.Radius, obj.Z+obj.Radius) {
+				questLocations[gid] = append(questLocations[gid], obj)
+			}
+		}
+	}
+	return nil
+}
+
+// checkObjective 检查任务目标的配置
+func checkObjective(obj *QuestObjective) error {
+	switch obj.Type {
+	case QUEST_OBJECTIVE_KILL:
+		if _, ok := monsterTemplates[obj.TargetId]; !ok {
+			return fmt.Errorf("objective monster template id %d not exist", obj.TargetId)
+		}
+	case QUEST_OBJECTIVE_COLLECT:
+		if GetItemTemplate(obj.TargetId) == nil {
+			return fmt.Errorf("objective item id %d not exist", obj.TargetId)
+		}
+	case QUEST_OBJECTIVE_REACH:
+		if _, ok := scenes[obj.SceneId]; !ok || obj.Radius <= 0 {
+			return fmt.Errorf("objective scene id %d radius %v invalid", obj.SceneId, obj.Radius)
+		}
+		obj.Count = 1
+	case QUEST_OBJECTIVE_TALK:
+		if GetNpc(obj.TargetId) == nil {
+			return fmt.Errorf("objective npc id %d not exist", obj.TargetId)
+		}
+		obj.Count = 1
+	default:
+		return fmt.Errorf("objective type %q invalid", obj.Type)
+	}
+	if obj.Count <= 0 {
+		return fmt.Errorf("objective count %d invalid", obj.Count)
+	}
+	return nil
+}
+
+// GetQuestTemplate 获取任务模板
+func GetQuestTemplate(questId int32) *QuestTemplate {
+	return questTemplates[questId]
+}
+
+// collectItems 交付时需要扣除的物品
+func (qt *QuestTemplate) collectItems() []*ItemStack {
+	items := make([]*ItemStack, 0)
+	for _, obj := range qt.Objectives {
+		if obj.Type == QUEST_OBJECTIVE_COLLECT {
+			items = append(items, &ItemStack{ItemId: obj.TargetId, Count: obj.Count})
+		}
+	}
+	return items
+}
+
+// QuestData 任务存档数据
+type QuestData struct {
+	QuestId  int32   `json:"quest_id"` // 任务id
+	Progress []int32 `json:"progress"` // 每个目标的进度
+}
+
+// ActiveQuest 进行中的任务
+type ActiveQuest struct {
+	Template *QuestTemplate // 任务模板
+	Progress []int32        // 每个目标的进度，与模板中的目标一一对应
+}
+
+// ready 全部目标是否已经完成
+func (aq *ActiveQuest) ready() bool {
+	for i, obj := range aq.Template.Objectives {
+		if aq.Progress[i] < obj.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// infoMsg 任务进度数据
+func (aq *ActiveQuest) infoMsg() *mmopb.QuestInfo {
+	state := mmopb.QuestState_Quest_State_Active
+	if aq.ready() {
+		state = mmopb.QuestState_Quest_State_Ready
+	}
+	return &mmopb.QuestInfo{
+		QuestId:  aq.Template.QuestId,
+		Progress: append([]int32(nil), aq.Progress...),
+		State:    state,
+	}
+}
+
+// loadQuests 从存档恢复任务，用于上线前
+func (p *Player) loadQuests(datas []*QuestData, doneIds []int32) {
+	p.questLock.Lock()
+	defer p.questLock.Unlock()
+
+	for _, questId := range doneIds {
+		p.doneQuests[questId] = true
+	}
+	for _, data := range datas {
+		template := GetQuestTemplate(data.QuestId)
+		if template == nil {
+			fmt.Println("load quest id = ", data.QuestId, " not exist")
+			continue
+		}
+		// 任务目标改动过时进度从头开始
+		progress := make([]int32, len(template.Objectives))
+		if len(data.Progress) == len(progress) {
+			for i, obj := range template.Objectives {
+				progress[i] = clampProgress(data.Progress[i], obj.Count)
+			}
+		}
+		p.quests[data.QuestId] = &ActiveQuest{Template: template, Progress: progress}
+	}
+}
+
+// questSnapshot 任务存档数据
+func (p *Player) questSnapshot() ([]*QuestData, []int32) {
+	p.questLock.Lock()
+	defer p.questLock.Unlock()
+
+	datas := make([]*QuestData, 0, len(p.quests))
+	for questId, quest := range p.quests {
+		datas = append(datas, &QuestData{
+			QuestId:  questId,
+			Progress: append([]int32(nil), quest.Progress...),
+		})
+	}
+	doneIds := make([]int32, 0, len(p.doneQuests))
+	for questId := range p.doneQuests {
+		doneIds = append(doneIds, questId)
+	}
+	sort.Slice(doneIds, func(i, j int) bool {
+		return doneIds[i] < doneIds[j]
+	})
+	return datas, doneIds
+}
+
+// SendQuests 同步全部任务给自己
+func (p *Player) SendQuests() {
+	_, doneIds := p.questSnapshot()
+
+	p.questLock.Lock()
+	quests := make([]*mmopb.QuestInfo, 0, len(p.quests))
+	for _, quest := range p.quests {
+		quests = append(quests, quest.infoMsg())
+	}
+	p.questLock.Unlock()
+
+	sort.Slice(quests, func(i, j int) bool {
+		return quests[i].QuestId < quests[j].QuestId
+	})
+	p.SendMessage(mmopb.SCMsgIdSyncQuests, &mmopb.SyncQuests{
+		Quests:  quests,
+		DoneIds: doneIds,
+	})
+}
+
+// sendQuestResult 告知任务操作失败的原因
+func (p *Player) sendQuestResult(err error, questId int32, npcId int32) {
+	if err == nil {
+		return
+	}
+	p.SendMessage(mmopb.SCMsgIdQuestResult, &mmopb.QuestResult{
+		Result:  resultCodeOf(err),
+		QuestId: questId,
+		NpcId:   npcId,
+	})
+}
+
+// nearNpc 是否在NPC附近
+func (p *Player) nearNpc(npcId int32) error {
+	npc := GetNpc(npcId)
+	if npc == nil {
+		return ErrTargetNotFound
+	}
+	if npc.SceneId != p.SceneId || distance(p.X, p.Z, npc.X, npc.Z) > NPC_TALK_RANGE {
+		return ErrOutOfRange
+	}
+	return nil
+}
+
+// AcceptQuest 接取任务
+func (p *Player) AcceptQuest(questId int32) {
+	p.sendQuestResult(p.acceptQuest(questId), questId, 0)
+}
+
+func (p *Player) acceptQuest(questId int32) error {
+	template := GetQuestTemplate(questId)
+	if template == nil {
+		return ErrQuestNotFound
+	}
+	if template.GiverNpc > 0 {
+		if err := p.nearNpc(template.GiverNpc); err != nil {
+			return err
+		}
+	}
+
+	p.questLock.Lock()
+	_, active := p.quests[questId]
+	if active || p.doneQuests[questId] || len(p.quests) >= MAX_ACTIVE_QUESTS ||
+		(template.PreQuest > 0 && !p.doneQuests[template.PreQuest]) || p.Level < template.Level {
+		p.questLock.Unlock()
+		return ErrQuestUnavailable
+	}
+
+	// 已经持有的物品和所在的位置直接计入进度
+	quest := &ActiveQuest{Template: template, Progress: make([]int32, len(template.Objectives))}
+	for i, obj := range template.Objectives {
+		switch obj.Type {
+		case QUEST_OBJECTIVE_COLLECT:
+			quest.Progress[i] = clampProgress(p.Bag.CountItem(obj.TargetId), obj.Count)
+		case QUEST_OBJECTIVE_REACH:
+			if obj.inside(p.SceneId, p.X, p.Z) {
+				quest.Progress[i] = 1
+			}
+		}
+	}
+	p.quests[questId] = quest
+	msg := &mmopb.QuestUpdate{Quest: quest.infoMsg()}
+	p.questLock.Unlock()
+
+	p.SendMessage(mmopb.SCMsgIdQuestUpdate, msg)
+	fmt.Println("======> player id = ", p.PlayerId, " accept quest ", questId, " <======")
+	return nil
+}
+
+// AbandonQuest 放弃任务，进度清空
+func (p *Player) AbandonQuest(questId int32) {
+	p.sendQuestResult(p.abandonQuest(questId), questId, 0)
+}
+
+func (p *Player) abandonQuest(questId int32) error {
+	p.questLock.Lock()
+	quest, ok := p.quests[questId]
+	if !ok {
+		p.questLock.Unlock()
+		return ErrQuestNotFound
+	}
+	delete(p.quests, questId)
+	info := quest.infoMsg()
+	p.questLock.Unlock()
+
+	info.State = mmopb.QuestState_Quest_State_Abandoned
+	p.SendMessage(mmopb.SCMsgIdQuestUpdate, &mmopb.QuestUpdate{Quest: info})
+	return nil
+}
+
+// TurnInQuest 交付任务，扣除收集的物品并发放奖励
+func (p *Player) TurnInQuest(questId int32) {
+	p.sendQuestResult(p.turnInQuest(questId), questId, 0)
+}
+
+func (p *Player) turnInQuest(questId int32) error {
+	template := GetQuestTemplate(questId)
+	if template == nil {
+		return ErrQuestNotFound
+	}
+	if template.TurnInNpc > 0 {
+		if err := p.nearNpc(template.TurnInNpc); err != nil {
+			return err
+		}
+	}
+
+	// 先从进行中的任务里取出，保证同一个任务只会交付一次
+	p.questLock.Lock()
+	quest, ok := p.quests[questId]
+	if !ok {
+		p.questLock.Unlock()
+		return ErrQuestNotFound
+	}
+	if !quest.ready() {
+		p.questLock.Unlock()
+		return ErrQuestNotReady
+	}
+	delete(p.quests, questId)
+	p.questLock.Unlock()
+
+	changed, err := p.Bag.Exchange(template.collectItems(), template.RewardItems)
+	if err != nil {
+		// 物品不足或放不下奖励，任务放回进行中
+		p.questLock.Lock()
+		p.quests[questId] = quest
+		p.questLock.Unlock()
+		return err
+	}
+
+	p.questLock.Lock()
+	p.doneQuests[questId] = true
+	info := quest.infoMsg()
+	p.questLock.Unlock()
+
+	info.State = mmopb.QuestState_Quest_State_Done
+	p.SendMessage(mmopb.SCMsgIdQuestUpdate, &mmopb.QuestUpdate{Quest: info})
+	p.SendInventoryChange(changed)
+	p.GiveGold(template.RewardGold)
+	p.AddExp(template.RewardExp, mmopb.ExpSource_Exp_Source_Quest)
+	fmt.Println("======> player id = ", p.PlayerId, " turn in quest ", questId, " <======")
+	return nil
+}
+
+// TalkToNpc 和附近的NPC对话
+func (p *Player) TalkToNpc(npcId int32) {
+	if err := p.nearNpc(npcId); err != nil {
+		p.sendQuestResult(err, 0, npcId)
+		return
+	}
+	p.updateQuests(QUEST_OBJECTIVE_TALK, func(obj *QuestObjective, progress int32) int32 {
+		if obj.TargetId == npcId {
+			return 1
+		}
+		return progress
+	})
+}
+
+// OnMonsterKilled 击杀怪物后更新击杀目标
+func (p *Player) OnMonsterKilled(templateId int32) {
+	p.updateQuests(QUEST_OBJECTIVE_KILL, func(obj *QuestObjective, progress int32) int32 {
+		if obj.TargetId == templateId {
+			return progress + 1
+		}
+		return progress
+	})
+}
+
+// onItemsChanged 背包物品变化后按持有数量更新收集目标
+func (p *Player) onItemsChanged() {
+	p.updateQuests(QUEST_OBJECTIVE_COLLECT, func(obj *QuestObjective, progress int32) int32 {
+		return p.Bag.CountItem(obj.TargetId)
+	})
+}
+
+// checkQuestLocations 位置变化后更新所在格子中的到达目标，到达后不会再变回未完成
+func (p *Player) checkQuestLocations() {
+	objs := questLocations[WorldMgrObj.AoiMgr.GetGidByPos(p.X, p.Z)]
+	if len(objs) == 0 {
+		return
+	}
+	p.updateQuests(QUEST_OBJECTIVE_REACH, func(obj *QuestObjective, progress int32) int32 {
+		for _, o := range objs {
+			if o == obj && obj.inside(p.SceneId, p.X, p.Z) {
+				return 1
+			}
+		}
+		return progress
+	})
+}
+
+// updateQuests 更新进行中任务里指定类型目标的进度，进度变化的任务发给自己
+func (p *Player) updateQuests(objType string, update func(obj *QuestObjective, progress int32) int32) {
+	p.questLock.Lock()
+	msgs := make([]*mmopb.QuestUpdate, 0)
+	for _, quest := range p.quests {
+		changed := false
+		for i, obj := range quest.Template.Objectives {
+			if obj.Type != objType {
+				continue
+			}
+			progress := clampProgress(update(obj, quest.Progress[i]), obj.Count)
+			if progress != quest.Progress[i] {
+				quest.Progress[i] = progress
+				changed = true
+			}
+		}
+		if changed {
+			msgs = append(msgs, &mmopb.QuestUpdate{Quest: quest.infoMsg()})
+		}
+	}
+	p.questLock.Unlock()
+
+	for _, msg := range msgs {
+		p.SendMessage(mmopb.SCMsgIdQuestUpdate, msg)
+	}
+}
+
+// clampProgress 进度限制在0到目标数量之间
+func clampProgress(progress, count int32) int32 {
+	if progress < 0 {
+		return 0
+	}
+	if progress > count {
+		return count
+	}
+	return progress
+}
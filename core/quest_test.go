@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestPlayer_updateQuests(t *testing.T) {
+	itemTemplates[9001] = &ItemTemplate{ItemId: 9001, Type: ITEM_TYPE_MATERIAL, MaxStack: 10}
+	defer delete(itemTemplates, 9001)
+
+	template := &QuestTemplate{
+		QuestId: 1,
+		Objectives: []*QuestObjective{
+			{Type: QUEST_OBJECTIVE_KILL, TargetId: 1, Count: 2},
+			{Type: QUEST_OBJECTIVE_COLLECT, TargetId: 9001, Count: 3},
+		},
+	}
+	p := &Player{
+		Bag:    NewInventory(3),
+		quests: map[int32]*ActiveQuest{1: {Template: template, Progress: make([]int32, 2)}},
+	}
+	quest := p.quests[1]
+
+	// 击杀数量不会超过目标数量，其他怪物不计数
+	for _, templateId := range []int32{1, 2, 1, 1} {
+		p.OnMonsterKilled(templateId)
+	}
+	if quest.Progress[0] != 2 {
+		t.Fatalf("kill progress = %d, want 2", quest.Progress[0])
+	}
+	if quest.ready() {
+		t.Fatal("quest ready before collecting items")
+	}
+
+	// 收集进度跟随背包中的数量变化
+	if _, err := p.Bag.AddItems(&ItemStack{ItemId: 9001, Count: 5}); err != nil {
+		t.Fatal(err)
+	}
+	p.onItemsChanged()
+	if quest.Progress[1] != 3 || !quest.ready() {
+		t.Fatalf("collect progress = %d ready = %v, want 3 true", quest.Progress[1], quest.ready())
+	}
+	if _, err := p.Bag.RemoveItems(&ItemStack{ItemId: 9001, Count: 4}); err != nil {
+		t.Fatal(err)
+	}
+	p.onItemsChanged()
+	if quest.Progress[1] != 1 || quest.ready() {
+		t.Fatalf("collect progress = %d ready = %v, want 1 false", quest.Progress[1], quest.ready())
+	}
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// RespawnPoint 复活点
+type RespawnPoint struct {
+	PointId int32   `json:"point_id"` // 复活点id
+	Name    string  `json:"name"`     // 复活点名称
+	X       float32 `json:"x"`        // 平面x坐标
+	Z       float32 `json:"z"`        // 平面y坐标
+}
+
+// Npc 场景中的NPC
+type Npc struct {
+	NpcId   int32   `json:"npc_id"` // NPC id
+	Name    string  `json:"name"`   // NPC名称
+	X       float32 `json:"x"`      // 平面x坐标
+	Z       float32 `json:"z"`      // 平面y坐标
+	SceneId int32   `json:"-"`      // 所在场景id，读取时填充
+}
+
+// Scene 场景数据
+type Scene struct {
+	SceneId       int32           `json:"scene_id"`       // 场景id
+	Name          string          `json:"name"`           // 场景名称
+	RespawnPoints []*RespawnPoint `json:"respawn_points"` // 场景内的复活点
+	Npcs          []*Npc          `json:"npcs"`           // 场景内的NPC
+	Zones         []*Zone         `json:"zones"`          // 场景内的触发区域
+}
+
+// SceneConfig 场景数据文件
+type SceneConfig struct {
+	Scenes []*Scene `json:"scenes"`
+}
+
+// scenes 全部场景
+var scenes = make(map[int32]*Scene)
+
+// npcs 全部场景中的NPC
+var npcs = make(map[int32]*Npc)
+
+// LoadScenes 读取场景数据文件
+func LoadScenes() error {
+	config := &SceneConfig{}
+	if err := loadConfig("scenes.json", config); err != nil {
+		return err
+	}
+
+	for _, scene := range config.Scenes {
+		if len(scene.RespawnPoints) == 0 {
+			return fmt.Errorf("scene id %d has no respawn point", scene.SceneId)
+		}
+		for _, npc := range scene.Npcs {
+			if _, ok := npcs[npc.NpcId]; ok {
+				return fmt.Errorf("npc id %d duplicated", npc.NpcId)
+			}
+			npc.SceneId = scene.SceneId
+			npcs[npc.NpcId] = npc
+		}
+		for _, zone := range scene.Zones {
+			if err := zone.init(scene.SceneId); err != nil {
+				return err
+			}
+			indexZone(zone)
+		}
+		scenes[scene.SceneId] = scene
+	}
+	if _, ok := scenes[DEFAULT_SCENE_ID]; !ok {
+		return fmt.Errorf("default scene id %d not exist", DEFAULT_SCENE_ID)
+	}
+	return nil
+}
+
+// GetScene 获取场景，不存在时返回默认场景
+func GetScene(sceneId int32) *Scene {
+	if scene, ok := scenes[sceneId]; ok {
+		return scene
+	}
+	return scenes[DEFAULT_SCENE_ID]
+}
+
+// GetNpc 获取NPC
+func GetNpc(npcId int32) *Npc {
+	return npcs[npcId]
+}
+
+// GetRespawnPoint 通过id获取场景内的复活点
+func (s *Scene) GetRespawnPoint(pointId int32) *RespawnPoint {
+	for _, point := range s.RespawnPoints {
+		if point.PointId == pointId {
+			return point
+		}
+	}
+	return nil
+}
+
+// NearestRespawnPoint 离坐标最近的复活点
+func (s *Scene) NearestRespawnPoint(x, z float32) *RespawnPoint {
+	var nearest *RespawnPoint
+	var minDist float32
+	for _, point := range s.RespawnPoints {
+		dist := distance(x, z, point.X, point.Z)
+		if nearest == nil || dist < minDist {
+			nearest = point
+			minDist = dist
+		}
+	}
+	return nearest
+}
+
+// RespawnOptionsMsg 在坐标处死亡时可选的复活点
+func (s *Scene) RespawnOptionsMsg(x, z float32) *mmopb.RespawnOptions {
+	points := make([]*mmopb.RespawnPoint, 0, len(s.RespawnPoints))
+	for _, point := range s.RespawnPoints {
+		points = append(points, &mmopb.RespawnPoint{
+			PointId: point.PointId,
+			Name:    point.Name,
+			Pos: &mmopb.Position{
+				X: point.X,
+				Z: point.Z,
+			},
+		})
+	}
+
+	msg := &mmopb.RespawnOptions{
+		SceneId: s.SceneId,
+		Points:  points,
+	}
+	if nearest := s.NearestRespawnPoint(x, z); nearest != nil {
+		msg.NearestPointId = nearest.PointId
+	}
+	return msg
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+
+	"github.com/aceld/zinx/ziface"
+	"github.com/golang/protobuf/proto"
+)
+
+// LatencyStat 连接延迟统计，单位毫秒
+type LatencyStat struct {
+	Last    int64 // 最近一次测得的往返延迟
+	Avg     int64 // 平滑后的平均往返延迟
+	Min     int64 // 最小往返延迟
+	Max     int64 // 最大往返延迟
+	Samples int64 // 采样次数
+}
+
+// Update 记录一次往返延迟采样
+func (ls *LatencyStat) Update(rtt int64) {
+	if rtt < 0 {
+		rtt = 0
+	}
+	ls.Last = rtt
+	if ls.Samples == 0 {
+		ls.Avg = rtt
+		ls.Min = rtt
+		ls.Max = rtt
+	} else {
+		// 与TCP的SRTT一样，新样本占1/8权重
+		ls.Avg = ls.Avg + (rtt-ls.Avg)/8
+		if rtt < ls.Min {
+			ls.Min = rtt
+		}
+		if rtt > ls.Max {
+			ls.Max = rtt
+		}
+	}
+	ls.Samples++
+}
+
+// Session 客户端连接会话，保存连接级别的状态
+type Session struct {
+	Conn        ziface.IConnection // 会话对应的连接
+	Account     *AccountData       // 登录的账号，未登录时为nil
+	Player      *Player            // 选择进入世界的角色，未进入时为nil
+	selected    int32              // 已经占用的角色id，由accountLock保护
+	entering    bool               // 角色正在进入世界，由accountLock保护
+	left        bool               // 已经开始下线流程，由accountLock保护
+	lastSeen    int64              // 最后一次收到客户端消息的时间(unix毫秒)
+	latency     LatencyStat        // 心跳测得的延迟
+	latencyLock sync.RWMutex       // latency的读写锁
+	stopped     int32              // 连接关闭回调已经执行过，为1时不再重复处理
+}
+
+// KeepAlive 收到客户端消息，刷新最后活跃时间
+func (s *Session) KeepAlive() {
+	atomic.StoreInt64(&s.lastSeen, nowMillis())
+}
+
+// MarkStopped 标记连接关闭回调已经执行。
+// 心跳超时主动Stop和读协程退出时的Stop都会触发zinx的关闭回调，只有第一次返回true
+func (s *Session) MarkStopped() bool {
+	return atomic.CompareAndSwapInt32(&s.stopped, 0, 1)
+}
+
+// IdleDuration 距离最后一次收到客户端消息经过的时间
+func (s *Session) IdleDuration() time.Duration {
+	return time.Duration(nowMillis()-atomic.LoadInt64(&s.lastSeen)) * time.Millisecond
+}
+
+// SendMessage 向客户端发送消息
+func (s *Session) SendMessage(msgId uint32, data proto.Message) {
+	msg, err := proto.Marshal(data)
+	if err != nil {
+		fmt.Println("marshal msg err: ", err)
+		return
+	}
+	if err := sendToConn(s.Conn, msgId, msg); err != nil {
+		fmt.Println("session send message err: ", err)
+	}
+}
+
+// sendToConn 通过连接的发送缓冲发送消息。
+// zinx的写协程写失败退出后，无缓冲的SendMsg会一直阻塞，占住发送方的工作协程；
+// 缓冲发送在缓冲满之前不会阻塞，连接Stop关闭缓冲通道时阻塞中或者正在检查状态的发送会panic，这里转成错误
+func sendToConn(conn ziface.IConnection, msgId uint32, data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("conn id %d stopped: %v", conn.GetConnID(), r)
+		}
+	}()
+	return conn.SendBuffMsg(msgId, data)
+}
+
+// IsStopped 连接关闭回调是否已经执行
+func (s *Session) IsStopped() bool {
+	return atomic.LoadInt32(&s.stopped) == 1
+}
+
+// SendPing 向客户端发送心跳探测，连接已经关闭时跳过
+func (s *Session) SendPing() {
+	if s.IsStopped() {
+		return
+	}
+
+	msg := &mmopb.Ping{
+		Timestamp: nowMillis(),
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		fmt.Println("marshal ping err: ", err)
+		return
+	}
+	// 走有缓冲的发送，避免半开连接阻塞心跳协程，发送期间连接关闭的panic不能带崩心跳协程
+	if err := sendToConn(s.Conn, mmopb.SCMsgIdPing, data); err != nil {
+		fmt.Println("session send ping err: ", err)
+	}
+}
+
+// OnPong 收到客户端心跳应答，记录往返延迟
+func (s *Session) OnPong(timestamp int64) {
+	s.KeepAlive()
+
+	s.latencyLock.Lock()
+	s.latency.Update(nowMillis() - timestamp)
+	s.latencyLock.Unlock()
+}
+
+// GetLatency 获取延迟统计
+func (s *Session) GetLatency() LatencyStat {
+	s.latencyLock.RLock()
+	defer s.latencyLock.RUnlock()
+	return s.latency
+}
+
+// SessionManager 会话管理器
+type SessionManager struct {
+	sessions    map[uint32]*Session // connId -> 会话
+	sessionLock sync.RWMutex        // 保护sessions的读写锁
+}
+
+// SessionMgrObj 提供一个对外的句柄
+var SessionMgrObj *SessionManager
+
+func init() {
+	SessionMgrObj = &SessionManager{
+		sessions: make(map[uint32]*Session, 50),
+	}
+}
+
+// AddSession 新连接建立，创建会话
+func (sm *SessionManager) AddSession(conn ziface.IConnection) *Session {
+	session := &Session{
+		Conn: conn,
+	}
+	session.KeepAlive()
+
+	sm.sessionLock.Lock()
+	sm.sessions[conn.GetConnID()] = session
+	sm.sessionLock.Unlock()
+	return session
+}
+
+// RemoveSession 连接断开，移除会话
+func (sm *SessionManager) RemoveSession(connId uint32) {
+	sm.sessionLock.Lock()
+	delete(sm.sessions, connId)
+	sm.sessionLock.Unlock()
+}
+
+// GetSession 通过连接id获取会话
+func (sm *SessionManager) GetSession(connId uint32) *Session {
+	sm.sessionLock.RLock()
+	defer sm.sessionLock.RUnlock()
+	return sm.sessions[connId]
+}
+
+// GetAllSessions 获取全部会话
+func (sm *SessionManager) GetAllSessions() []*Session {
+	sm.sessionLock.RLock()
+	defer sm.sessionLock.RUnlock()
+
+	sessions := make([]*Session, 0, len(sm.sessions))
+	for _, s := range sm.sessions {
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
+
+// StartHeartbeat 开启心跳检测，定时探测客户端并踢掉空闲超时的连接
+func (sm *SessionManager) StartHeartbeat() {
+	go func() {
+		ticker := time.NewTicker(HEARTBEAT_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for _, session := range sm.GetAllSessions() {
+				if session.IdleDuration() > HEARTBEAT_TIMEOUT {
+					fmt.Println("conn id = ", session.Conn.GetConnID(), " heartbeat timeout, kick")
+					// 关闭连接会触发OnConnStop，走正常的玩家下线流程
+					session.Conn.Stop()
+					continue
+				}
+				session.SendPing()
+			}
+		}
+	}()
+}
+
+// nowMillis 当前unix毫秒时间戳
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/aceld/zinx/ziface"
+)
+
+// stoppingConn 发送时连接正好被关闭，复现zinx向已关闭的缓冲通道发送
+type stoppingConn struct {
+	ziface.IConnection
+	buff  chan []byte
+	sends int
+}
+
+func (c *stoppingConn) GetConnID() uint32 {
+	return 1
+}
+
+func (c *stoppingConn) SendBuffMsg(msgId uint32, data []byte) error {
+	c.sends++
+	close(c.buff)
+	c.buff <- data
+	return nil
+}
+
+func TestSession_SendPing(t *testing.T) {
+	conn := &stoppingConn{buff: make(chan []byte, 1)}
+	session := &Session{Conn: conn}
+
+	// 发送期间连接关闭不会panic
+	session.SendPing()
+	if conn.sends != 1 {
+		t.Fatalf("sends = %d, want 1", conn.sends)
+	}
+
+	// 关闭回调执行过的连接不再发送
+	session.MarkStopped()
+	session.SendPing()
+	if conn.sends != 1 {
+		t.Fatalf("sends = %d after stopped, want 1", conn.sends)
+	}
+}
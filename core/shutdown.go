@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// shuttingDown 服务器是否正在停服，停服期间不再接受登录
+var shuttingDown int32
+
+// IsShuttingDown 服务器是否正在停服
+func IsShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) == 1
+}
+
+// Shutdown 优雅停服：停止登录，倒计时广播停服通知，等待发送队列排空后断开全部连接，由下线流程保存玩家数据
+func Shutdown(countdown int, reason string) {
+	if !atomic.CompareAndSwapInt32(&shuttingDown, 0, 1) {
+		return
+	}
+	fmt.Println("======> server shutting down in ", countdown, " seconds <======")
+
+	// 倒计时广播，开始时、每10秒以及最后5秒各通知一次，不等待发送完成
+	for remain := countdown; remain > 0; remain-- {
+		if remain == countdown || remain%10 == 0 || remain <= 5 {
+			BroadCastShutdown(int32(remain), reason, 0)
+		}
+		time.Sleep(time.Second)
+	}
+	BroadCastShutdown(0, reason, SHUTDOWN_DRAIN_TIME)
+
+	// 断开全部连接，触发玩家正常下线流程，下线流程中会保存玩家数据
+	for _, session := range SessionMgrObj.GetAllSessions() {
+		session.Conn.Stop()
+	}
+	fmt.Println("======> server shutdown complete <======")
+}
+
+// BroadCastShutdown 向全部连接广播停服通知，drain大于0时最多等待drain让各连接的发送队列排空。
+// 每个连接单独发送，卡住的连接不会拖慢停服，它的发送协程会在进程退出时结束
+func BroadCastShutdown(countdown int32, reason string, drain time.Duration) {
+	msg := &mmopb.ServerShutdown{
+		Countdown: countdown,
+		Reason:    reason,
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		fmt.Println("marshal shutdown msg err: ", err)
+		return
+	}
+	// 写协程一次只写一条消息，通知之后的心跳探测被取走时，通知和之前的消息都已经写出
+	flush, err := proto.Marshal(&mmopb.Ping{Timestamp: nowMillis()})
+	if err != nil {
+		fmt.Println("marshal ping err: ", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, session := range SessionMgrObj.GetAllSessions() {
+		wg.Add(1)
+		go func(session *Session) {
+			defer wg.Done()
+			if err := session.Conn.SendMsg(mmopb.SCMsgIdShutdown, data); err != nil {
+				fmt.Println("send shutdown msg err: ", err)
+				return
+			}
+			if drain > 0 {
+				session.Conn.SendMsg(mmopb.SCMsgIdPing, flush)
+			}
+		}(session)
+	}
+	if drain <= 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(drain):
+		fmt.Println("send queues not drained in ", drain)
+	}
+}
@@ -0,0 +1,353 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// 技能目标形状
+const (
+	SKILL_SHAPE_SINGLE string = "single" // 单体
+	SKILL_SHAPE_CIRCLE string = "circle" // 以目标点为圆心的圆形，施法距离为0时以施法者为圆心
+	SKILL_SHAPE_CONE   string = "cone"   // 以施法者为顶点朝向目标点的扇形
+	SKILL_SHAPE_LINE   string = "line"   // 从施法者出发朝向目标点的矩形
+	SKILL_SHAPE_SELF   string = "self"   // 只作用于自己
+)
+
+// SkillTemplate 技能模板
+type SkillTemplate struct {
+	SkillId    int32             `json:"skill_id"`     // 技能id
+	Name       string            `json:"name"`         // 技能名称
+	Class      mmopb.PlayerClass `json:"class"`        // 可以使用的职业，0表示所有职业
+	CooldownMs int64             `json:"cooldown_ms"`  // 冷却时间(毫秒)
+	CastTimeMs int64             `json:"cast_time_ms"` // 施法时间(毫秒)，0表示瞬发
+	MPCost     int32             `json:"mp_cost"`      // 法力消耗
+	Range      float32           `json:"range"`        // 施法距离，直线技能为长度
+	Shape      string            `json:"shape"`        // 目标形状
+	Radius     float32           `json:"radius"`       // 圆形和扇形的半径
+	Angle      float32           `json:"angle"`        // 扇形的角度
+	Width      float32           `json:"width"`        // 直线的宽度
+	DamageRate float64           `json:"damage_rate"`  // 伤害倍率
+	MaxTargets int               `json:"max_targets"`  // 最多命中的目标数量
+	BuffId     int32             `json:"buff_id"`      // 命中目标时施加的buff，0表示没有
+	SelfBuffId int32             `json:"self_buff_id"` // 施法完成时给自己施加的buff，0表示没有
+}
+
+// SkillConfig 技能数据文件
+type SkillConfig struct {
+	Skills []*SkillTemplate `json:"skills"`
+}
+
+// skillTemplates 全部技能模板
+var skillTemplates = make(map[int32]*SkillTemplate)
+
+// LoadSkills 读取技能数据文件
+func LoadSkills() error {
+	config := &SkillConfig{}
+	if err := loadConfig("skills.json", config); err != nil {
+		return err
+	}
+
+	for _, skill := range config.Skills {
+		switch skill.Shape {
+		case SKILL_SHAPE_SINGLE, SKILL_SHAPE_CIRCLE, SKILL_SHAPE_CONE, SKILL_SHAPE_LINE, SKILL_SHAPE_SELF:
+		default:
+			return fmt.Errorf("skill id %d shape %q invalid", skill.SkillId, skill.Shape)
+		}
+		for _, buffId := range []int32{skill.BuffId, skill.SelfBuffId} {
+			if buffId > 0 && GetBuffTemplate(buffId) == nil {
+				return fmt.Errorf("skill id %d buff id %d not exist", skill.SkillId, buffId)
+			}
+		}
+		skillTemplates[skill.SkillId] = skill
+	}
+	return nil
+}
+
+// GetSkillTemplate 获取技能模板
+func GetSkillTemplate(skillId int32) *SkillTemplate {
+	return skillTemplates[skillId]
+}
+
+// castState 正在施放的技能
+type castState struct {
+	skill    *SkillTemplate
+	targetId int32
+	x        float32
+	z        float32
+	timer    *time.Timer
+}
+
+// startCast 检查冷却和法力，记录施法状态
+func (cu *CombatUnit) startCast(cast *castState) mmopb.ResultCode {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	if cu.HP <= 0 {
+		return mmopb.ResultCode_Result_Dead
+	}
+	if cu.casting != nil {
+		return mmopb.ResultCode_Result_Busy
+	}
+	if nowMillis() < cu.cooldowns[cast.skill.SkillId] {
+		return mmopb.ResultCode_Result_Cooldown
+	}
+	if cu.MP < cast.skill.MPCost {
+		return mmopb.ResultCode_Result_Not_Enough_MP
+	}
+	cu.casting = cast
+	return mmopb.ResultCode_Result_Ok
+}
+
+// finishCast 结束施法，扣除法力并进入冷却
+func (cu *CombatUnit) finishCast(cast *castState) bool {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	// 施法已经被打断
+	if cu.casting != cast {
+		return false
+	}
+	cu.casting = nil
+	if cu.HP <= 0 || cu.MP < cast.skill.MPCost {
+		return false
+	}
+	cu.MP -= cast.skill.MPCost
+	if cu.cooldowns == nil {
+		cu.cooldowns = make(map[int32]int64)
+	}
+	cu.cooldowns[cast.skill.SkillId] = nowMillis() + cast.skill.CooldownMs
+	return true
+}
+
+// takeCast 取出正在施放的技能并清除施法状态
+func (cu *CombatUnit) takeCast() *castState {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+
+	cast := cu.casting
+	cu.casting = nil
+	return cast
+}
+
+// IsCasting 是否正在施法
+func (cu *CombatUnit) IsCasting() bool {
+	cu.combatLock.Lock()
+	defer cu.combatLock.Unlock()
+	return cu.casting != nil
+}
+
+// CastSkill 释放技能，有施法时间的技能在施法结束后生效，期间可以被打断
+func CastSkill(caster Combatant, skill *SkillTemplate, targetId int32, x, z float32) mmopb.ResultCode {
+	cx, cz := caster.GetPos()
+
+	// 单体技能以目标所在位置作为目标点
+	if skill.Shape == SKILL_SHAPE_SINGLE {
+		target := WorldMgrObj.GetCombatantById(targetId)
+		if target == nil || target.GetEntityId() == caster.GetEntityId() || target.GetCombatUnit().IsStealthed() {
+			return mmopb.ResultCode_Result_Target_Not_Found
+		}
+		if target.GetCombatUnit().IsDead() {
+			return mmopb.ResultCode_Result_Target_Dead
+		}
+		if !CanAttack(caster, target) {
+			return mmopb.ResultCode_Result_Zone_No_Combat
+		}
+		x, z = target.GetPos()
+	}
+	// 施法距离为0的圆形技能围绕施法者释放，忽略客户端发来的目标点
+	if skill.Shape == SKILL_SHAPE_SELF || (skill.Shape == SKILL_SHAPE_CIRCLE && skill.Range <= 0) {
+		x, z = cx, cz
+	}
+	// 直线和扇形技能从施法者出发，只有方向没有距离限制
+	if skill.Shape == SKILL_SHAPE_SINGLE || skill.Shape == SKILL_SHAPE_CIRCLE {
+		if distance(cx, cz, x, z) > skill.Range {
+			return mmopb.ResultCode_Result_Out_Of_Range
+		}
+	}
+
+	cast := &castState{
+		skill:    skill,
+		targetId: targetId,
+		x:        x,
+		z:        z,
+	}
+	unit := caster.GetCombatUnit()
+	if result := unit.startCast(cast); result != mmopb.ResultCode_Result_Ok {
+		return result
+	}
+	// 施法解除隐身
+	BreakStealth(caster)
+
+	if skill.CastTimeMs <= 0 {
+		finishCast(caster, cast)
+		return mmopb.ResultCode_Result_Ok
+	}
+
+	broadCastSkillAction(caster, cast, mmopb.SkillState_Skill_Cast_Start)
+	cast.timer = time.AfterFunc(time.Duration(skill.CastTimeMs)*time.Millisecond, func() {
+		finishCast(caster, cast)
+	})
+	return mmopb.ResultCode_Result_Ok
+}
+
+// CancelCast 打断正在施放的技能，并通知周围玩家
+func CancelCast(caster Combatant) {
+	cast := caster.GetCombatUnit().takeCast()
+	if cast == nil {
+		return
+	}
+	if cast.timer != nil {
+		cast.timer.Stop()
+	}
+	broadCastSkillAction(caster, cast, mmopb.SkillState_Skill_Cast_Cancel)
+}
+
+// finishCast 施法完成，查找目标并结算伤害
+func finishCast(caster Combatant, cast *castState) {
+	unit := caster.GetCombatUnit()
+	if !unit.finishCast(cast) {
+		return
+	}
+	broadCastSkillAction(caster, cast, mmopb.SkillState_Skill_Cast_Finish)
+
+	if cast.skill.SelfBuffId > 0 {
+		AddBuff(caster, caster.GetEntityId(), cast.skill.SelfBuffId)
+	}
+
+	for _, target := range findSkillTargets(caster, cast) {
+		damage, critical := CalcDamage(unit, target.GetCombatUnit())
+		damage = int32(math.Round(float64(damage) * cast.skill.DamageRate))
+		if damage < 1 {
+			damage = 1
+		}
+		ApplyDamage(caster, target, cast.skill.SkillId, damage, critical)
+		if cast.skill.BuffId > 0 {
+			AddBuff(target, caster.GetEntityId(), cast.skill.BuffId)
+		}
+	}
+}
+
+// findSkillTargets 通过AOI格子索引查找技能范围内的目标
+func findSkillTargets(caster Combatant, cast *castState) []Combatant {
+	skill := cast.skill
+	cx, cz := caster.GetPos()
+
+	if skill.Shape == SKILL_SHAPE_SELF {
+		return nil
+	}
+	if skill.Shape == SKILL_SHAPE_SINGLE {
+		target := WorldMgrObj.GetCombatantById(cast.targetId)
+		// 施法期间目标可能已经走进安全区
+		if target == nil || target.GetCombatUnit().IsDead() || !CanAttack(caster, target) {
+			return nil
+		}
+		// 施法期间目标可能已经移动，结算时放宽一点距离
+		tx, tz := target.GetPos()
+		if distance(cx, cz, tx, tz) > skill.Range+SKILL_RANGE_TOLERANCE {
+			return nil
+		}
+		return []Combatant{target}
+	}
+
+	// 只查找形状包围盒覆盖到的格子，而不是遍历全部实体
+	minX, minZ, maxX, maxZ := skillBounds(skill, cx, cz, cast.x, cast.z)
+	ids := WorldMgrObj.AoiMgr.GetPlayerIdsByRect(minX, minZ, maxX, maxZ)
+
+	targets := make([]Combatant, 0)
+	for _, id := range ids {
+		if skill.MaxTargets > 0 && len(targets) >= skill.MaxTargets {
+			break
+		}
+		if int32(id) == caster.GetEntityId() {
+			continue
+		}
+		target := WorldMgrObj.GetCombatantById(int32(id))
+		if target == nil || target.GetCombatUnit().IsDead() || target.GetCombatUnit().IsStealthed() || !CanAttack(caster, target) {
+			continue
+		}
+		tx, tz := target.GetPos()
+		if InSkillShape(skill, cx, cz, cast.x, cast.z, tx, tz) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// skillBounds 技能形状的包围盒
+func skillBounds(skill *SkillTemplate, cx, cz, x, z float32) (minX, minZ, maxX, maxZ float32) {
+	switch skill.Shape {
+	case SKILL_SHAPE_CIRCLE:
+		return x - skill.Radius, z - skill.Radius, x + skill.Radius, z + skill.Radius
+	case SKILL_SHAPE_CONE:
+		return cx - skill.Radius, cz - skill.Radius, cx + skill.Radius, cz + skill.Radius
+	default:
+		r := skill.Range + skill.Width/2
+		return cx - r, cz - r, cx + r, cz + r
+	}
+}
+
+// InSkillShape 判断目标坐标(tx, tz)是否在技能形状内，(cx, cz)为施法者坐标，(x, z)为目标点
+func InSkillShape(skill *SkillTemplate, cx, cz, x, z, tx, tz float32) bool {
+	switch skill.Shape {
+	case SKILL_SHAPE_CIRCLE:
+		return distance(x, z, tx, tz) <= skill.Radius
+	case SKILL_SHAPE_CONE:
+		d := distance(cx, cz, tx, tz)
+		if d > skill.Radius {
+			return false
+		}
+		if d == 0 {
+			return true
+		}
+		// 目标方向与朝向的夹角不超过扇形角度的一半
+		dirX, dirZ := direction(cx, cz, x, z)
+		cos := (dirX*(tx-cx) + dirZ*(tz-cz)) / d
+		return float64(cos) >= math.Cos(float64(skill.Angle)/2*math.Pi/180)
+	case SKILL_SHAPE_LINE:
+		dirX, dirZ := direction(cx, cz, x, z)
+		// 投影到朝向上的长度和到直线的垂直距离
+		along := dirX*(tx-cx) + dirZ*(tz-cz)
+		across := float32(math.Abs(float64(dirX*(tz-cz) - dirZ*(tx-cx))))
+		return along >= 0 && along <= skill.Range && across <= skill.Width/2
+	}
+	return false
+}
+
+// broadCastSkillAction 向施法者周围的玩家广播施法动作
+func broadCastSkillAction(caster Combatant, cast *castState, state mmopb.SkillState) {
+	x, z := caster.GetPos()
+	WorldMgrObj.BroadCastAround(x, z, mmopb.SCMsgIdBroadCast, &mmopb.BroadCast{
+		PlayerId: caster.GetEntityId(),
+		Type:     mmopb.BroadCastType_Player_Action,
+		Data: &mmopb.BroadCast_Skill{
+			Skill: &mmopb.SkillAction{
+				SkillId:  cast.skill.SkillId,
+				State:    state,
+				TargetId: cast.targetId,
+				X:        cast.x,
+				Z:        cast.z,
+				CastTime: int32(cast.skill.CastTimeMs),
+			},
+		},
+	})
+}
+
+// direction 从(x1, z1)指向(x2, z2)的单位向量，两点重合时默认朝向x轴正方向
+func direction(x1, z1, x2, z2 float32) (float32, float32) {
+	d := distance(x1, z1, x2, z2)
+	if d == 0 {
+		return 1, 0
+	}
+	return (x2 - x1) / d, (z2 - z1) / d
+}
+
+// distance 平面上两点的距离
+func distance(x1, z1, x2, z2 float32) float32 {
+	dx := float64(x1 - x2)
+	dz := float64(z1 - z2)
+	return float32(math.Sqrt(dx*dx + dz*dz))
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"aoi_mmo_game/mmopb"
+)
+
+func TestInSkillShape(t *testing.T) {
+	circle := &SkillTemplate{Shape: SKILL_SHAPE_CIRCLE, Radius: 5}
+	cone := &SkillTemplate{Shape: SKILL_SHAPE_CONE, Radius: 10, Angle: 90}
+	line := &SkillTemplate{Shape: SKILL_SHAPE_LINE, Range: 10, Width: 2}
+
+	cases := []struct {
+		skill  *SkillTemplate
+		tx, tz float32
+		want   bool
+	}{
+		// 圆心在(20, 0)
+		{circle, 23, 4, true},
+		{circle, 24, 4, false},
+		// 施法者在原点，朝向x轴正方向
+		{cone, 5, 4, true},
+		{cone, 5, 6, false},
+		{cone, -1, 0, false},
+		{cone, 11, 0, false},
+		{line, 9, 0.9, true},
+		{line, 9, 1.1, false},
+		{line, -0.5, 0, false},
+		{line, 10.5, 0, false},
+	}
+	for i, c := range cases {
+		if got := InSkillShape(c.skill, 0, 0, 20, 0, c.tx, c.tz); got != c.want {
+			t.Errorf("case %d: InSkillShape(%s, %v, %v) = %v, want %v", i, c.skill.Shape, c.tx, c.tz, got, c.want)
+		}
+	}
+}
+
+func TestCastSkill_CircleRange(t *testing.T) {
+	caster := &Monster{MonsterId: -1, X: 100, Z: 100}
+	caster.HP, caster.MaxHP = 100, 100
+
+	// 施法距离为0的圆形技能以施法者为圆心，客户端发来的目标点被忽略
+	whirl := &SkillTemplate{SkillId: -1, Shape: SKILL_SHAPE_CIRCLE, Radius: 6, CastTimeMs: 1000}
+	if result := CastSkill(caster, whirl, 0, 0, 0); result != mmopb.ResultCode_Result_Ok {
+		t.Fatalf("range 0 circle: result = %v, want Ok", result)
+	}
+	cast := caster.casting
+	CancelCast(caster)
+	if cast == nil || cast.x != 100 || cast.z != 100 {
+		t.Fatalf("range 0 circle: cast = %+v, want centred on caster", cast)
+	}
+
+	// 有施法距离的圆形技能仍然检查目标点
+	blast := &SkillTemplate{SkillId: -2, Shape: SKILL_SHAPE_CIRCLE, Range: 20, Radius: 4, CastTimeMs: 1000}
+	if result := CastSkill(caster, blast, 0, 0, 0); result != mmopb.ResultCode_Result_Out_Of_Range {
+		t.Errorf("far circle: result = %v, want Out_Of_Range", result)
+	}
+	if result := CastSkill(caster, blast, 0, 110, 100); result != mmopb.ResultCode_Result_Ok {
+		t.Errorf("near circle: result = %v, want Ok", result)
+	}
+	CancelCast(caster)
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// ErrDataNotFound 存档数据不存在
+var ErrDataNotFound = errors.New("data not found")
+
+// PlayerData 玩家存档数据
+type PlayerData struct {
+	PlayerId int32   `json:"player_id"` // 玩家id
+	X        float32 `json:"x"`         // 平面x坐标
+	Y        float32 `json:"y"`         // 高度
+	Z        float32 `json:"z"`         // 平面y坐标
+	V        float32 `json:"v"`         // 旋转0-360度
+
+	Name         string            `json:"name"`          // 显示名称
+	Class        mmopb.PlayerClass `json:"class"`         // 职业
+	AppearanceId int32             `json:"appearance_id"` // 外观id
+	Level        int32             `json:"level"`         // 等级
+	Exp          int64             `json:"exp"`           // 当前等级已有的经验
+
+	SceneId int32 `json:"scene_id"` // 所在场景id
+
+	HP int32 `json:"hp"` // 当前血量
+	MP int32 `json:"mp"` // 当前法力
+
+	Buffs []*BuffData `json:"buffs,omitempty"` // 下线时保存的buff
+	Items []*ItemData `json:"items,omitempty"` // 背包中的物品
+	Gold  int64       `json:"gold"`            // 金币
+
+	Equips []*EquipData `json:"equips,omitempty"` // 身上的装备
+
+	Quests     []*QuestData `json:"quests,omitempty"`      // 进行中的任务
+	DoneQuests []int32      `json:"done_quests,omitempty"` // 已完成的任务id
+
+	ChatMutedUntil int64 `json:"chat_muted_until,omitempty"` // 禁言结束时间(unix毫秒)
+	ChatMuteLevel  int   `json:"chat_mute_level,omitempty"`  // 已经被禁言的次数
+
+	Blocked []int32 `json:"blocked,omitempty"` // 屏蔽的玩家id
+	Friends []int32 `json:"friends,omitempty"` // 好友的玩家id
+}
+
+// BuffData buff存档数据
+type BuffData struct {
+	BuffId   int32 `json:"buff_id"`   // buff id
+	CasterId int32 `json:"caster_id"` // 施加者id
+	Stacks   int32 `json:"stacks"`    // 叠加层数
+	RemainMs int64 `json:"remain_ms"` // 剩余时间(毫秒)，下线期间不计时
+}
+
+// OfflineChatData 离线期间收到的私聊
+type OfflineChatData struct {
+	SenderId   int32  `json:"sender_id"`   // 发送者id
+	SenderName string `json:"sender_name"` // 发送者名称
+	Content    string `json:"content"`     // 过滤后的内容
+	TimeMs     int64  `json:"time_ms"`     // 发送时间(unix毫秒)
+}
+
+// AccountData 账号存档数据
+type AccountData struct {
+	Account   string  `json:"account"`    // 账号
+	Salt      string  `json:"salt"`       // 密码的盐，十六进制
+	Password  string  `json:"password"`   // 加盐迭代后的密码哈希，十六进制
+	PlayerIds []int32 `json:"player_ids"` // 账号下的角色id
+}
+
+// ServerData 全服存档数据
+type ServerData struct {
+	NextPlayerId int32            `json:"next_player_id"` // 下一个可分配的playerId
+	Names        map[string]int32 `json:"names"`          // 已占用的角色名 -> playerId
+}
+
+// Storage 玩家数据存储接口
+type Storage interface {
+	// SavePlayer 保存玩家数据
+	SavePlayer(data *PlayerData) error
+	// LoadPlayer 读取玩家数据，不存在时返回ErrDataNotFound
+	LoadPlayer(playerId int32) (*PlayerData, error)
+	// SaveAccount 保存账号数据
+	SaveAccount(data *AccountData) error
+	// LoadAccount 读取账号数据，不存在时返回ErrDataNotFound
+	LoadAccount(account string) (*AccountData, error)
+	// SaveServerData 保存全服数据
+	SaveServerData(data *ServerData) error
+	// LoadServerData 读取全服数据，不存在时返回ErrDataNotFound
+	LoadServerData() (*ServerData, error)
+	// SaveOfflineChats 保存玩家的离线私聊
+	SaveOfflineChats(playerId int32, chats []*OfflineChatData) error
+	// LoadOfflineChats 读取玩家的离线私聊，不存在时返回ErrDataNotFound
+	LoadOfflineChats(playerId int32) ([]*OfflineChatData, error)
+}
+
+// StorageObj 提供一个对外的句柄
+var StorageObj Storage
+
+func init() {
+	StorageObj = NewFileStorage(SAVE_DIR)
+}
+
+// FileStorage 基于本地json文件的存储实现
+type FileStorage struct {
+	dir  string     // 存档根目录
+	lock sync.Mutex // 保护文件读写的锁
+}
+
+// NewFileStorage 创建一个文件存储
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{
+		dir: dir,
+	}
+}
+
+// SavePlayer 保存玩家数据
+func (fs *FileStorage) SavePlayer(data *PlayerData) error {
+	return fs.save(fs.playerPath(data.PlayerId), data)
+}
+
+// LoadPlayer 读取玩家数据
+func (fs *FileStorage) LoadPlayer(playerId int32) (*PlayerData, error) {
+	data := &PlayerData{}
+	if err := fs.load(fs.playerPath(playerId), data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// SaveAccount 保存账号数据
+func (fs *FileStorage) SaveAccount(data *AccountData) error {
+	return fs.save(fs.accountPath(data.Account), data)
+}
+
+// LoadAccount 读取账号数据
+func (fs *FileStorage) LoadAccount(account string) (*AccountData, error) {
+	data := &AccountData{}
+	if err := fs.load(fs.accountPath(account), data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// SaveServerData 保存全服数据
+func (fs *FileStorage) SaveServerData(data *ServerData) error {
+	return fs.save(fs.serverPath(), data)
+}
+
+// LoadServerData 读取全服数据
+func (fs *FileStorage) LoadServerData() (*ServerData, error) {
+	data := &ServerData{}
+	if err := fs.load(fs.serverPath(), data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// SaveOfflineChats 保存玩家的离线私聊
+func (fs *FileStorage) SaveOfflineChats(playerId int32, chats []*OfflineChatData) error {
+	return fs.save(fs.offlineChatPath(playerId), chats)
+}
+
+// LoadOfflineChats 读取玩家的离线私聊
+func (fs *FileStorage) LoadOfflineChats(playerId int32) ([]*OfflineChatData, error) {
+	chats := make([]*OfflineChatData, 0)
+	if err := fs.load(fs.offlineChatPath(playerId), &chats); err != nil {
+		return nil, err
+	}
+	return chats, nil
+}
+
+// playerPath 玩家存档文件路径
+func (fs *FileStorage) playerPath(playerId int32) string {
+	return filepath.Join(fs.dir, "players", fmt.Sprintf("%d.json", playerId))
+}
+
+// accountPath 账号存档文件路径，账号名在登录时已经校验过字符
+func (fs *FileStorage) accountPath(account string) string {
+	return filepath.Join(fs.dir, "accounts", account+".json")
+}
+
+// offlineChatPath 离线私聊文件路径，和玩家存档分开，玩家不在线时也能写入
+func (fs *FileStorage) offlineChatPath(playerId int32) string {
+	return filepath.Join(fs.dir, "offline_chats", fmt.Sprintf("%d.json", playerId))
+}
+
+// serverPath 全服存档文件路径
+func (fs *FileStorage) serverPath() string {
+	return filepath.Join(fs.dir, "server.json")
+}
+
+// save 将数据序列化后写入文件，先写临时文件再改名，避免写一半时停服损坏存档
+func (fs *FileStorage) save(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// load 读取文件并反序列化
+func (fs *FileStorage) load(path string, v interface{}) error {
+	fs.lock.Lock()
+	data, err := ioutil.ReadFile(path)
+	fs.lock.Unlock()
+
+	if os.IsNotExist(err) {
+		return ErrDataNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,390 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	ErrItemCannotTrade = errors.New("item cannot be traded")
+	ErrTargetBusy      = errors.New("target is busy")
+	ErrNotInTrade      = errors.New("not in trade")
+	ErrTradeNotLocked  = errors.New("trade not locked")
+)
+
+// tradeLock 保护全部交易状态、玩家身上的交易和交易邀请
+var tradeLock sync.Mutex
+
+// tradeIdGen tradeId生成器，需持有tradeLock
+var tradeIdGen int32
+
+// tradeMsg 等待发送的交易消息
+type tradeMsg struct {
+	player *Player
+	msgId  uint32
+	msg    proto.Message
+}
+
+// tradeDelta 交易完成后等待同步的背包变化
+type tradeDelta struct {
+	player  *Player
+	changed []*mmopb.ItemSlot
+}
+
+// tradeOutbox 持有tradeLock时收集的消息和存档，释放tradeLock后再执行，
+// 避免一个卡住的连接或者写档拖住全部交易。用法：
+//
+//	var out tradeOutbox
+//	defer out.flush()
+//	tradeLock.Lock()
+//	defer tradeLock.Unlock()
+type tradeOutbox struct {
+	msgs   []tradeMsg
+	deltas []tradeDelta
+	saves  []*Player
+}
+
+// send 记录一条等待发送的消息
+func (o *tradeOutbox) send(player *Player, msgId uint32, msg proto.Message) {
+	o.msgs = append(o.msgs, tradeMsg{player: player, msgId: msgId, msg: msg})
+}
+
+// flush 同步背包变化、存档并发送消息，调用时不能持有tradeLock
+func (o *tradeOutbox) flush() {
+	for _, delta := range o.deltas {
+		delta.player.sendInventoryDelta(delta.changed)
+	}
+	// 交易完成立即存档，避免停服或宕机时物品丢失或复制
+	for _, player := range o.saves {
+		player.Save()
+	}
+	for _, m := range o.msgs {
+		m.player.SendMessage(m.msgId, m.msg)
+	}
+}
+
+// TradeSide 交易的一方
+type TradeSide struct {
+	Player    *Player      // 玩家
+	Items     []*ItemStack // 交出的物品
+	Gold      int64        // 交出的金币
+	Locked    bool         // 是否已锁定
+	Confirmed bool         // 是否已确认
+}
+
+// Trade 两个玩家之间的交易
+type Trade struct {
+	TradeId int32         // 交易id
+	Sides   [2]*TradeSide // 交易双方，第一个为发起邀请的玩家
+}
+
+// sides 返回玩家自己和对方
+func (t *Trade) sides(p *Player) (mine *TradeSide, other *TradeSide) {
+	if t.Sides[0].Player == p {
+		return t.Sides[0], t.Sides[1]
+	}
+	return t.Sides[1], t.Sides[0]
+}
+
+// inRange 双方是否在交易距离内，坐标以AOI中记录的为准
+func (t *Trade) inRange() bool {
+	a, b := t.Sides[0].Player, t.Sides[1].Player
+	return distance(a.X, a.Z, b.X, b.Z) <= TRADE_RANGE
+}
+
+// sendUpdate 把交易双方的内容和状态发给双方，调用方需持有tradeLock
+func (t *Trade) sendUpdate(out *tradeOutbox) {
+	msg := &mmopb.TradeUpdate{
+		TradeId: t.TradeId,
+		Sides:   make([]*mmopb.TradeSideInfo, 0, len(t.Sides)),
+	}
+	for _, side := range t.Sides {
+		items := make([]*mmopb.ItemCount, 0, len(side.Items))
+		for _, item := range side.Items {
+			items = append(items, &mmopb.ItemCount{ItemId: item.ItemId, Count: item.Count})
+		}
+		msg.Sides = append(msg.Sides, &mmopb.TradeSideInfo{
+			PlayerId:  side.Player.PlayerId,
+			Items:     items,
+			Gold:      side.Gold,
+			Locked:    side.Locked,
+			Confirmed: side.Confirmed,
+		})
+	}
+	for _, side := range t.Sides {
+		out.send(side.Player, mmopb.SCMsgIdTradeUpdate, msg)
+	}
+}
+
+// close 结束交易并通知双方，调用方需持有tradeLock
+func (t *Trade) close(out *tradeOutbox, reason mmopb.TradeCloseReason, playerId int32) {
+	msg := &mmopb.TradeClosed{
+		TradeId:  t.TradeId,
+		Reason:   reason,
+		PlayerId: playerId,
+	}
+	for _, side := range t.Sides {
+		side.Player.trade = nil
+		out.send(side.Player, mmopb.SCMsgIdTradeClosed, msg)
+	}
+	fmt.Println("======> trade id = ", t.TradeId, " closed, reason = ", reason, " <======")
+}
+
+// execute 双方都确认后交换物品和金币，调用方需持有tradeLock
+func (t *Trade) execute(out *tradeOutbox) error {
+	a, b := t.Sides[0], t.Sides[1]
+	aChanged, bChanged, err := swapInventories(a.Player.Bag, a.Items, a.Gold, b.Player.Bag, b.Items, b.Gold)
+	if err != nil {
+		return err
+	}
+
+	out.deltas = append(out.deltas,
+		tradeDelta{player: a.Player, changed: aChanged},
+		tradeDelta{player: b.Player, changed: bChanged})
+	out.saves = append(out.saves, a.Player, b.Player)
+	return nil
+}
+
+// sendTradeResult 告知交易操作失败的原因
+func (p *Player) sendTradeResult(err error) {
+	if err == nil {
+		return
+	}
+	p.SendMessage(mmopb.SCMsgIdTradeResult, &mmopb.TradeResult{
+		Result: resultCodeOf(err),
+	})
+}
+
+// RequestTrade 邀请附近的玩家交易
+func (p *Player) RequestTrade(targetId int32) {
+	p.sendTradeResult(p.requestTrade(targetId))
+}
+
+func (p *Player) requestTrade(targetId int32) error {
+	target := WorldMgrObj.GetPlayerById(targetId)
+	if target == nil || target == p {
+		return ErrTargetNotFound
+	}
+	if distance(p.X, p.Z, target.X, target.Z) > TRADE_RANGE {
+		return ErrOutOfRange
+	}
+	// 被对方屏蔽时自动拒绝
+	if target.IsBlocking(p.PlayerId) {
+		return ErrBlocked
+	}
+
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	if p.trade != nil || target.trade != nil {
+		return ErrTargetBusy
+	}
+	target.tradeInviter = p.PlayerId
+	target.tradeInviteAt = nowMillis()
+	out.send(target, mmopb.SCMsgIdTradeInvite, &mmopb.TradeInvite{
+		InviterId:   p.PlayerId,
+		InviterName: p.Name,
+	})
+	return nil
+}
+
+// RespondTrade 回应交易邀请，接受后开始交易
+func (p *Player) RespondTrade(inviterId int32, accept bool) {
+	p.sendTradeResult(p.respondTrade(inviterId, accept))
+}
+
+func (p *Player) respondTrade(inviterId int32, accept bool) error {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	if p.tradeInviter != inviterId || nowMillis()-p.tradeInviteAt > TRADE_INVITE_TIMEOUT_MS {
+		return ErrTargetNotFound
+	}
+	p.tradeInviter = 0
+
+	inviter := WorldMgrObj.GetPlayerById(inviterId)
+	if inviter == nil {
+		return ErrTargetNotFound
+	}
+	if !accept {
+		out.send(inviter, mmopb.SCMsgIdTradeClosed, &mmopb.TradeClosed{
+			Reason:   mmopb.TradeCloseReason_Trade_Cancelled,
+			PlayerId: p.PlayerId,
+		})
+		return nil
+	}
+	if p.trade != nil || inviter.trade != nil {
+		return ErrTargetBusy
+	}
+	if distance(p.X, p.Z, inviter.X, inviter.Z) > TRADE_RANGE {
+		return ErrOutOfRange
+	}
+
+	tradeIdGen++
+	t := &Trade{
+		TradeId: tradeIdGen,
+		Sides:   [2]*TradeSide{{Player: inviter}, {Player: p}},
+	}
+	inviter.trade = t
+	p.trade = t
+
+	out.send(inviter, mmopb.SCMsgIdTradeOpen, &mmopb.TradeOpen{TradeId: t.TradeId, PartnerId: p.PlayerId})
+	out.send(p, mmopb.SCMsgIdTradeOpen, &mmopb.TradeOpen{TradeId: t.TradeId, PartnerId: inviter.PlayerId})
+	t.sendUpdate(&out)
+	return nil
+}
+
+// SetTradeOffer 设置自己交出的物品和金币，锁定后修改会取消交易
+func (p *Player) SetTradeOffer(items []*ItemStack, gold int64) {
+	p.sendTradeResult(p.setTradeOffer(items, gold))
+}
+
+func (p *Player) setTradeOffer(items []*ItemStack, gold int64) error {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	t := p.trade
+	if t == nil {
+		return ErrNotInTrade
+	}
+	mine, other := t.sides(p)
+	if mine.Locked {
+		t.close(&out, mmopb.TradeCloseReason_Trade_Offer_Changed, p.PlayerId)
+		return nil
+	}
+
+	// 校验物品可以交易且数量足够，同一种物品合并计算
+	if gold < 0 || gold > p.Bag.Gold() {
+		return ErrGoldNotEnough
+	}
+	counts := make(map[int32]int32)
+	offer := make([]*ItemStack, 0, len(items))
+	for _, item := range items {
+		template := GetItemTemplate(item.ItemId)
+		if template == nil {
+			return ErrItemNotFound
+		}
+		if template.Type == ITEM_TYPE_QUEST {
+			return ErrItemCannotTrade
+		}
+		if item.Count <= 0 {
+			return ErrItemCountInvalid
+		}
+		if _, ok := counts[item.ItemId]; !ok {
+			offer = append(offer, &ItemStack{ItemId: item.ItemId})
+		}
+		counts[item.ItemId] += item.Count
+	}
+	for _, item := range offer {
+		item.Count = counts[item.ItemId]
+		if p.Bag.CountItem(item.ItemId) < item.Count {
+			return ErrItemNotEnough
+		}
+	}
+
+	mine.Items = offer
+	mine.Gold = gold
+	// 对方锁定的是修改前的内容，需要重新锁定
+	other.Locked = false
+	other.Confirmed = false
+	t.sendUpdate(&out)
+	return nil
+}
+
+// LockTrade 锁定自己的交易内容
+func (p *Player) LockTrade() {
+	p.sendTradeResult(p.lockTrade())
+}
+
+func (p *Player) lockTrade() error {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	t := p.trade
+	if t == nil {
+		return ErrNotInTrade
+	}
+	if !t.inRange() {
+		t.close(&out, mmopb.TradeCloseReason_Trade_Out_Of_Range, p.PlayerId)
+		return nil
+	}
+	mine, _ := t.sides(p)
+	mine.Locked = true
+	t.sendUpdate(&out)
+	return nil
+}
+
+// ConfirmTrade 确认交易，双方都确认后交换物品和金币
+func (p *Player) ConfirmTrade() {
+	p.sendTradeResult(p.confirmTrade())
+}
+
+func (p *Player) confirmTrade() error {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	t := p.trade
+	if t == nil {
+		return ErrNotInTrade
+	}
+	mine, other := t.sides(p)
+	if !mine.Locked || !other.Locked {
+		return ErrTradeNotLocked
+	}
+	if !t.inRange() {
+		t.close(&out, mmopb.TradeCloseReason_Trade_Out_Of_Range, p.PlayerId)
+		return nil
+	}
+	mine.Confirmed = true
+	if !other.Confirmed {
+		t.sendUpdate(&out)
+		return nil
+	}
+
+	if err := t.execute(&out); err != nil {
+		fmt.Println("trade id = ", t.TradeId, " execute err: ", err)
+		t.close(&out, mmopb.TradeCloseReason_Trade_Failed, p.PlayerId)
+		return err
+	}
+	t.close(&out, mmopb.TradeCloseReason_Trade_Done, 0)
+	return nil
+}
+
+// CancelTrade 取消正在进行的交易
+func (p *Player) CancelTrade(reason mmopb.TradeCloseReason) {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	if p.trade != nil {
+		p.trade.close(&out, reason, p.PlayerId)
+	}
+}
+
+// checkTradeRange 位置变化后检查交易距离，超出时取消交易
+func (p *Player) checkTradeRange() {
+	var out tradeOutbox
+	defer out.flush()
+	tradeLock.Lock()
+	defer tradeLock.Unlock()
+
+	if p.trade != nil && !p.trade.inRange() {
+		p.trade.close(&out, mmopb.TradeCloseReason_Trade_Out_Of_Range, p.PlayerId)
+	}
+}
@@ -2,13 +2,22 @@ package core
 
 import (
 	"sync"
+	"time"
+
+	"aoi_mmo_game/mmopb"
+
+	"github.com/golang/protobuf/proto"
 )
 
 // WorldManager 游戏世界管理器
 type WorldManager struct {
-	AoiMgr     *AOIManager       // 世界地图aoi管理器
-	Players    map[int32]*Player // 在线玩家集合
-	playerLock sync.RWMutex      // 保护Players的读写锁
+	AoiMgr      *AOIManager           // 世界地图aoi管理器
+	Players     map[int32]*Player     // 在线玩家集合
+	playerLock  sync.RWMutex          // 保护Players的读写锁
+	Monsters    map[int32]*Monster    // 存活的怪物集合
+	monsterLock sync.RWMutex          // 保护Monsters的读写锁
+	Loots       map[int32]*GroundLoot // 地面上可以拾取的掉落物
+	lootLock    sync.RWMutex          // 保护Loots的读写锁
 }
 
 // WorldMgrObj 提供一个对外的句柄
@@ -16,8 +25,10 @@ var WorldMgrObj *WorldManager
 
 func init() {
 	WorldMgrObj = &WorldManager{
-		AoiMgr:  NewAOIManager(AOI_MIN_X, AOI_MAX_X, AOI_CNTS_X, AOI_MIN_Y, AOI_MAX_Y, AOI_CNTS_Y),
-		Players: make(map[int32]*Player, 50),
+		AoiMgr:   NewAOIManager(AOI_MIN_X, AOI_MAX_X, AOI_CNTS_X, AOI_MIN_Y, AOI_MAX_Y, AOI_CNTS_Y),
+		Players:  make(map[int32]*Player, 50),
+		Monsters: make(map[int32]*Monster, 50),
+		Loots:    make(map[int32]*GroundLoot, 50),
 	}
 }
 
@@ -65,7 +76,7 @@ func (wm *WorldManager) GetAllPlayers() []*Player {
 // GetPlayersByGid 获取指定gid中的所有player信息
 func (wm *WorldManager) GetPlayersByGid(gid int) (players []*Player) {
 	if grid, ok := wm.AoiMgr.grids[gid]; ok {
-		players = make([]*Player, len(grid.GetPlayerIds()))
+		players = make([]*Player, 0, len(grid.GetPlayerIds()))
 		wm.playerLock.RLock()
 		for _, playerId := range grid.GetPlayerIds() {
 			if player, ok := wm.Players[int32(playerId)]; ok {
@@ -76,3 +87,202 @@ func (wm *WorldManager) GetPlayersByGid(gid int) (players []*Player) {
 	}
 	return
 }
+
+// SaveAllPlayers 保存全部在线玩家数据
+func (wm *WorldManager) SaveAllPlayers() {
+	for _, player := range wm.GetAllPlayers() {
+		player.Save()
+	}
+}
+
+// AddMonster 怪物出生，添加到世界中并通知周围玩家
+func (wm *WorldManager) AddMonster(monster *Monster) {
+	wm.monsterLock.Lock()
+	wm.Monsters[monster.MonsterId] = monster
+	wm.monsterLock.Unlock()
+
+	wm.AoiMgr.AddPlayerIdToGridByPos(int(monster.MonsterId), monster.X, monster.Z)
+	wm.BroadCastAround(monster.X, monster.Z, mmopb.SCMsgIdMonsterAppear, monster.MonsterMsg())
+}
+
+// RemoveMonster 怪物死亡或消失，从世界中移除并通知周围玩家
+func (wm *WorldManager) RemoveMonster(monster *Monster) {
+	wm.monsterLock.Lock()
+	delete(wm.Monsters, monster.MonsterId)
+	wm.monsterLock.Unlock()
+
+	wm.AoiMgr.RemoveFromGridByPos(int(monster.MonsterId), monster.X, monster.Z)
+	// 怪物离开视野同样走PlayerLeave消息，怪物id与playerId不重叠
+	wm.BroadCastAround(monster.X, monster.Z, mmopb.SCMsgIdPlayerLeave, &mmopb.SyncPlayerId{
+		PlayerId: monster.MonsterId,
+	})
+}
+
+// GetMonsterById 通过id获取怪物
+func (wm *WorldManager) GetMonsterById(monsterId int32) *Monster {
+	wm.monsterLock.RLock()
+	defer wm.monsterLock.RUnlock()
+	return wm.Monsters[monsterId]
+}
+
+// GetAllMonsters 获取全部存活的怪物
+func (wm *WorldManager) GetAllMonsters() []*Monster {
+	wm.monsterLock.RLock()
+	defer wm.monsterLock.RUnlock()
+
+	monsters := make([]*Monster, 0, len(wm.Monsters))
+	for _, monster := range wm.Monsters {
+		monsters = append(monsters, monster)
+	}
+	return monsters
+}
+
+// GetMonstersByGid 获取指定gid中的所有怪物
+func (wm *WorldManager) GetMonstersByGid(gid int) (monsters []*Monster) {
+	if grid, ok := wm.AoiMgr.grids[gid]; ok {
+		ids := grid.GetPlayerIds()
+		monsters = make([]*Monster, 0, len(ids))
+		wm.monsterLock.RLock()
+		for _, id := range ids {
+			if monster, ok := wm.Monsters[int32(id)]; ok {
+				monsters = append(monsters, monster)
+			}
+		}
+		wm.monsterLock.RUnlock()
+	}
+	return
+}
+
+// AddLoot 掉落物出现在地面上，添加到世界中并通知周围玩家
+func (wm *WorldManager) AddLoot(loot *GroundLoot) {
+	wm.lootLock.Lock()
+	wm.Loots[loot.LootId] = loot
+	wm.lootLock.Unlock()
+
+	wm.AoiMgr.AddPlayerIdToGridByPos(int(loot.LootId), loot.X, loot.Z)
+	wm.BroadCastAround(loot.X, loot.Z, mmopb.SCMsgIdLootAppear, loot.LootMsg())
+}
+
+// ClaimLoot 认领掉落物，认领后其他玩家不能再拾取，同一个掉落物只有第一次认领会成功
+func (wm *WorldManager) ClaimLoot(lootId int32) *GroundLoot {
+	wm.lootLock.Lock()
+	defer wm.lootLock.Unlock()
+
+	loot, ok := wm.Loots[lootId]
+	if !ok {
+		return nil
+	}
+	delete(wm.Loots, lootId)
+	return loot
+}
+
+// UnclaimLoot 取消认领，掉落物重新可以被拾取。认领期间已经超时时返回false，由调用方移除
+func (wm *WorldManager) UnclaimLoot(loot *GroundLoot) bool {
+	wm.lootLock.Lock()
+	defer wm.lootLock.Unlock()
+
+	if loot.expired {
+		return false
+	}
+	wm.Loots[loot.LootId] = loot
+	return true
+}
+
+// ExpireLoot 掉落物超时，返回true时由调用方移除。正被拾取认领时只做标记，认领失败放回时再移除
+func (wm *WorldManager) ExpireLoot(loot *GroundLoot) bool {
+	wm.lootLock.Lock()
+	defer wm.lootLock.Unlock()
+
+	if _, ok := wm.Loots[loot.LootId]; !ok {
+		loot.expired = true
+		return false
+	}
+	delete(wm.Loots, loot.LootId)
+	return true
+}
+
+// RemoveLoot 把已认领的掉落物从地面上移除，并通知周围玩家，pickerId为0表示超时消失
+func (wm *WorldManager) RemoveLoot(loot *GroundLoot, pickerId int32) {
+	wm.AoiMgr.RemoveFromGridByPos(int(loot.LootId), loot.X, loot.Z)
+	wm.BroadCastAround(loot.X, loot.Z, mmopb.SCMsgIdLootRemove, &mmopb.LootRemove{
+		LootId:   loot.LootId,
+		PickerId: pickerId,
+	})
+}
+
+// GetLootById 通过id获取可以拾取的掉落物
+func (wm *WorldManager) GetLootById(lootId int32) *GroundLoot {
+	wm.lootLock.RLock()
+	defer wm.lootLock.RUnlock()
+	return wm.Loots[lootId]
+}
+
+// GetLootsByGid 获取指定gid中可以拾取的掉落物
+func (wm *WorldManager) GetLootsByGid(gid int) (loots []*GroundLoot) {
+	if grid, ok := wm.AoiMgr.grids[gid]; ok {
+		ids := grid.GetPlayerIds()
+		loots = make([]*GroundLoot, 0, len(ids))
+		wm.lootLock.RLock()
+		for _, id := range ids {
+			if loot, ok := wm.Loots[int32(id)]; ok {
+				loots = append(loots, loot)
+			}
+		}
+		wm.lootLock.RUnlock()
+	}
+	return
+}
+
+// GetCombatantById 通过实体id获取玩家或怪物
+func (wm *WorldManager) GetCombatantById(entityId int32) Combatant {
+	if entityId >= MONSTER_ID_BASE {
+		if monster := wm.GetMonsterById(entityId); monster != nil {
+			return monster
+		}
+		return nil
+	}
+	if player := wm.GetPlayerById(entityId); player != nil {
+		return player
+	}
+	return nil
+}
+
+// GetPlayersAround 获取坐标周围九宫格内的全部玩家
+func (wm *WorldManager) GetPlayersAround(x, z float32) []*Player {
+	ids := wm.AoiMgr.GetPlayerIdsByPos(x, z)
+
+	players := make([]*Player, 0, len(ids))
+	wm.playerLock.RLock()
+	for _, id := range ids {
+		if player, ok := wm.Players[int32(id)]; ok {
+			players = append(players, player)
+		}
+	}
+	wm.playerLock.RUnlock()
+	return players
+}
+
+// BroadCastAround 向坐标周围九宫格内的全部玩家广播消息
+func (wm *WorldManager) BroadCastAround(x, z float32, msgId uint32, msg proto.Message) {
+	for _, player := range wm.GetPlayersAround(x, z) {
+		player.SendMessage(msgId, msg)
+	}
+}
+
+// StartTick 开启场景心跳，定时处理玩家和怪物身上buff的到期和周期效果
+func (wm *WorldManager) StartTick() {
+	go func() {
+		ticker := time.NewTicker(SCENE_TICK_INTERVAL)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			now := nowMillis()
+			for _, player := range wm.GetAllPlayers() {
+				TickBuffs(player, now)
+			}
+			for _, monster := range wm.GetAllMonsters() {
+				TickBuffs(monster, now)
+			}
+		}
+	}()
+}
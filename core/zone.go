@@ -0,0 +1,294 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"aoi_mmo_game/mmopb"
+)
+
+// 区域类型，事件的订阅者按类型决定区域的作用
+const (
+	ZONE_TYPE_SAFE     string = "safe"     // 安全区
+	ZONE_TYPE_PVP      string = "pvp"      // PvP区域
+	ZONE_TYPE_QUEST    string = "quest"    // 任务区域
+	ZONE_TYPE_TELEPORT string = "teleport" // 传送阵，进入后传送到目标坐标
+)
+
+// 区域形状
+const (
+	ZONE_SHAPE_CIRCLE  string = "circle"  // 圆形
+	ZONE_SHAPE_POLYGON string = "polygon" // 多边形
+)
+
+// ZonePoint 多边形的顶点
+type ZonePoint struct {
+	X float32 `json:"x"` // 平面x坐标
+	Z float32 `json:"z"` // 平面y坐标
+}
+
+// Zone 场景中的触发区域
+type Zone struct {
+	ZoneId  int32        `json:"zone_id"`  // 区域id
+	Name    string       `json:"name"`     // 区域名称
+	Type    string       `json:"type"`     // 区域类型
+	Shape   string       `json:"shape"`    // 区域形状
+	X       float32      `json:"x"`        // 圆心平面x坐标
+	Z       float32      `json:"z"`        // 圆心平面y坐标
+	Radius  float32      `json:"radius"`   // 圆的半径
+	Points  []*ZonePoint `json:"points"`   // 多边形的顶点，按顺序连接
+	TargetX float32      `json:"target_x"` // 传送阵的目标平面x坐标
+	TargetZ float32      `json:"target_z"` // 传送阵的目标平面y坐标
+	SceneId int32        `json:"-"`        // 所在场景id，读取时填充
+
+	minX, minZ, maxX, maxZ float32 // 外接矩形，用于登记到格子
+}
+
+// ZoneHandler 区域事件的处理函数
+type ZoneHandler func(p *Player, zone *Zone)
+
+var (
+	zoneEnterHandlers []ZoneHandler // 进入区域的订阅者
+	zoneLeaveHandlers []ZoneHandler // 离开区域的订阅者
+	zoneHandlerLock   sync.RWMutex  // 保护订阅者的读写锁
+)
+
+// zonesByGid 格子id -> 范围与该格子相交的区域，查找时只检查所在格子的区域
+var zonesByGid = make(map[int][]*Zone)
+
+func init() {
+	// 把进出区域告知玩家自己
+	OnZoneEnter(func(p *Player, zone *Zone) {
+		p.SendMessage(mmopb.SCMsgIdZoneEvent, zone.EventMsg(true))
+	})
+	OnZoneLeave(func(p *Player, zone *Zone) {
+		p.SendMessage(mmopb.SCMsgIdZoneEvent, zone.EventMsg(false))
+	})
+	// 传送阵
+	OnZoneEnter(func(p *Player, zone *Zone) {
+		if zone.Type == ZONE_TYPE_TELEPORT && !p.IsDead() {
+			p.Teleport(zone.TargetX, zone.TargetZ)
+		}
+	})
+}
+
+// OnZoneEnter 订阅玩家进入区域的事件，需要在服务启动前订阅
+func OnZoneEnter(handler ZoneHandler) {
+	zoneHandlerLock.Lock()
+	zoneEnterHandlers = append(zoneEnterHandlers, handler)
+	zoneHandlerLock.Unlock()
+}
+
+// OnZoneLeave 订阅玩家离开区域的事件，需要在服务启动前订阅
+func OnZoneLeave(handler ZoneHandler) {
+	zoneHandlerLock.Lock()
+	zoneLeaveHandlers = append(zoneLeaveHandlers, handler)
+	zoneHandlerLock.Unlock()
+}
+
+// init 检查区域配置并计算外接矩形
+func (zone *Zone) init(sceneId int32) error {
+	zone.SceneId = sceneId
+	switch zone.Type {
+	case ZONE_TYPE_SAFE, ZONE_TYPE_PVP, ZONE_TYPE_QUEST, ZONE_TYPE_TELEPORT:
+	default:
+		return fmt.Errorf("zone id %d type %q invalid", zone.ZoneId, zone.Type)
+	}
+
+	switch zone.Shape {
+	case ZONE_SHAPE_CIRCLE:
+		if zone.Radius <= 0 {
+			return fmt.Errorf("zone id %d radius %v invalid", zone.ZoneId, zone.Radius)
+		}
+		zone.minX, zone.minZ = zone.X-zone.Radius, zone.Z-zone.Radius
+		zone.maxX, zone.maxZ = zone.X+zone.Radius, zone.Z+zone.Radius
+	case ZONE_SHAPE_POLYGON:
+		if len(zone.Points) < 3 {
+			return fmt.Errorf("zone id %d polygon needs at least 3 points", zone.ZoneId)
+		}
+		zone.minX, zone.minZ = zone.Points[0].X, zone.Points[0].Z
+		zone.maxX, zone.maxZ = zone.minX, zone.minZ
+		for _, point := range zone.Points[1:] {
+			zone.minX, zone.maxX = minFloat32(zone.minX, point.X), maxFloat32(zone.maxX, point.X)
+			zone.minZ, zone.maxZ = minFloat32(zone.minZ, point.Z), maxFloat32(zone.maxZ, point.Z)
+		}
+	default:
+		return fmt.Errorf("zone id %d shape %q invalid", zone.ZoneId, zone.Shape)
+	}
+
+	// 传送到区域内会反复触发
+	if zone.Type == ZONE_TYPE_TELEPORT && zone.Contains(zone.TargetX, zone.TargetZ) {
+		return fmt.Errorf("zone id %d teleport target inside the zone", zone.ZoneId)
+	}
+	return nil
+}
+
+// Contains 坐标是否在区域内
+func (zone *Zone) Contains(x, z float32) bool {
+	if x < zone.minX || x > zone.maxX || z < zone.minZ || z > zone.maxZ {
+		return false
+	}
+	if zone.Shape == ZONE_SHAPE_CIRCLE {
+		return distance(x, z, zone.X, zone.Z) <= zone.Radius
+	}
+
+	// 射线法：向x正方向的射线与多边形的边相交奇数次时在内部
+	inside := false
+	points := zone.Points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.Z > z) != (b.Z > z) && x < (b.X-a.X)*(z-a.Z)/(b.Z-a.Z)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// EventMsg 进出区域的事件
+func (zone *Zone) EventMsg(enter bool) *mmopb.ZoneEvent {
+	return &mmopb.ZoneEvent{
+		ZoneId:   zone.ZoneId,
+		Name:     zone.Name,
+		ZoneType: zone.Type,
+		Enter:    enter,
+	}
+}
+
+// indexZone 把区域登记到范围覆盖的格子中
+func indexZone(zone *Zone) {
+	for _, gid := range WorldMgrObj.AoiMgr.GetGidsByRect(zone.minX, zone.minZ, zone.maxX, zone.maxZ) {
+		zonesByGid[gid] = append(zonesByGid[gid], zone)
+	}
+}
+
+// ZonesAt 坐标所在的全部区域
+func ZonesAt(sceneId int32, x, z float32) []*Zone {
+	zones := make([]*Zone, 0)
+	for _, zone := range zonesByGid[WorldMgrObj.AoiMgr.GetGidByPos(x, z)] {
+		if zone.SceneId == sceneId && zone.Contains(x, z) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// InZoneType 玩家是否在指定类型的区域内
+func (p *Player) InZoneType(zoneType string) bool {
+	p.zoneLock.Lock()
+	defer p.zoneLock.Unlock()
+
+	for _, zone := range p.zones {
+		if zone.Type == zoneType {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAttack 区域是否允许攻击者伤害目标：安全区内的玩家不会受到伤害，
+// 玩家之间只有双方都在PvP区域内才能互相攻击，攻击怪物不受区域限制
+func CanAttack(attacker, target Combatant) bool {
+	targetPlayer, ok := target.(*Player)
+	if !ok {
+		return true
+	}
+	if targetPlayer.InZoneType(ZONE_TYPE_SAFE) {
+		return false
+	}
+	attackerPlayer, ok := attacker.(*Player)
+	if !ok {
+		return true
+	}
+	return attackerPlayer.InZoneType(ZONE_TYPE_PVP) && targetPlayer.InZoneType(ZONE_TYPE_PVP)
+}
+
+// updateZones 位置变化后比较进出的区域，先触发离开再触发进入
+func (p *Player) updateZones() {
+	current := ZonesAt(p.SceneId, p.X, p.Z)
+
+	p.zoneLock.Lock()
+	entered := make([]*Zone, 0)
+	left := make([]*Zone, 0)
+	next := make(map[int32]*Zone, len(current))
+	for _, zone := range current {
+		next[zone.ZoneId] = zone
+		if _, ok := p.zones[zone.ZoneId]; !ok {
+			entered = append(entered, zone)
+		}
+	}
+	for zoneId, zone := range p.zones {
+		if _, ok := next[zoneId]; !ok {
+			left = append(left, zone)
+		}
+	}
+	p.zones = next
+	p.zoneLock.Unlock()
+
+	p.fireZoneEvents(left, false)
+	p.fireZoneEvents(entered, true)
+}
+
+// leaveAllZones 下线时离开全部区域
+func (p *Player) leaveAllZones() {
+	p.zoneLock.Lock()
+	left := make([]*Zone, 0, len(p.zones))
+	for _, zone := range p.zones {
+		left = append(left, zone)
+	}
+	p.zones = make(map[int32]*Zone)
+	p.zoneLock.Unlock()
+
+	p.fireZoneEvents(left, false)
+}
+
+// fireZoneEvents 按区域id顺序通知订阅者，不持有任何锁，订阅者可以移动玩家
+func (p *Player) fireZoneEvents(zones []*Zone, enter bool) {
+	if len(zones) == 0 {
+		return
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ZoneId < zones[j].ZoneId
+	})
+
+	zoneHandlerLock.RLock()
+	handlers := zoneLeaveHandlers
+	if enter {
+		handlers = zoneEnterHandlers
+	}
+	zoneHandlerLock.RUnlock()
+
+	for _, zone := range zones {
+		fmt.Println("======> player id = ", p.PlayerId, " zone ", zone.ZoneId, " enter = ", enter, " <======")
+		for _, handler := range handlers {
+			// 订阅者把玩家传送走之后，旧位置的区域不再触发进入
+			if enter && !p.inZone(zone.ZoneId) {
+				break
+			}
+			handler(p, zone)
+		}
+	}
+}
+
+// inZone 玩家当前是否在区域内
+func (p *Player) inZone(zoneId int32) bool {
+	p.zoneLock.Lock()
+	defer p.zoneLock.Unlock()
+
+	_, ok := p.zones[zoneId]
+	return ok
+}
+
+func minFloat32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestZone_Contains(t *testing.T) {
+	circle := &Zone{ZoneId: 1, Type: ZONE_TYPE_SAFE, Shape: ZONE_SHAPE_CIRCLE, X: 100, Z: 100, Radius: 10}
+	// 凹多边形，(105, 105)在缺口中
+	polygon := &Zone{ZoneId: 2, Type: ZONE_TYPE_PVP, Shape: ZONE_SHAPE_POLYGON, Points: []*ZonePoint{
+		{X: 100, Z: 100}, {X: 110, Z: 100}, {X: 110, Z: 110}, {X: 105, Z: 102}, {X: 100, Z: 110},
+	}}
+	for _, zone := range []*Zone{circle, polygon} {
+		if err := zone.init(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		zone *Zone
+		x, z float32
+		want bool
+	}{
+		{circle, 100, 100, true},
+		{circle, 107, 107, true},
+		{circle, 108, 108, false},
+		{polygon, 101, 101, true},
+		{polygon, 109, 108, true},
+		{polygon, 105, 105, false},
+		{polygon, 111, 105, false},
+	}
+	for i, c := range cases {
+		if got := c.zone.Contains(c.x, c.z); got != c.want {
+			t.Errorf("case %d: zone %d contains (%v, %v) = %v, want %v", i, c.zone.ZoneId, c.x, c.z, got, c.want)
+		}
+	}
+
+	// 目标在区域内的传送阵会反复触发
+	pad := &Zone{ZoneId: 3, Type: ZONE_TYPE_TELEPORT, Shape: ZONE_SHAPE_CIRCLE, X: 100, Z: 100, Radius: 3, TargetX: 101, TargetZ: 100}
+	if err := pad.init(1); err == nil {
+		t.Fatal("teleport target inside the zone should be rejected")
+	}
+}
+
+func TestCanAttack(t *testing.T) {
+	safe := &Zone{ZoneId: 1, Type: ZONE_TYPE_SAFE}
+	pvp := &Zone{ZoneId: 2, Type: ZONE_TYPE_PVP}
+	inZone := func(zones ...*Zone) *Player {
+		p := &Player{zones: make(map[int32]*Zone)}
+		for _, zone := range zones {
+			p.zones[zone.ZoneId] = zone
+		}
+		return p
+	}
+	field, inSafe, inPvp := inZone(), inZone(safe), inZone(pvp)
+	monster := &Monster{}
+
+	cases := []struct {
+		name             string
+		attacker, target Combatant
+		want             bool
+	}{
+		{"player vs monster", field, monster, true},
+		{"safe player vs monster", inSafe, monster, true},
+		{"monster vs player", monster, field, true},
+		{"monster vs safe player", monster, inSafe, false},
+		{"pvp outside pvp zone", field, field, false},
+		{"pvp into pvp zone", field, inPvp, false},
+		{"pvp inside pvp zone", inPvp, inZone(pvp), true},
+		{"pvp vs safe player", inPvp, inZone(pvp, safe), false},
+	}
+	for _, c := range cases {
+		if got := CanAttack(c.attacker, c.target); got != c.want {
+			t.Errorf("%s: CanAttack = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -6,17 +6,104 @@ import (
 
 // 客户端消息
 const (
-	CSMsgIdTalk uint32 = 1
-	CSMsgIdMove uint32 = 2
+	CSMsgIdTalk            uint32 = 1
+	CSMsgIdMove            uint32 = 2
+	CSMsgIdPong            uint32 = 3
+	CSMsgIdLogin           uint32 = 4
+	CSMsgIdCharacterList   uint32 = 5
+	CSMsgIdCreateCharacter uint32 = 6
+	CSMsgIdSelectCharacter uint32 = 7
+	CSMsgIdAttack          uint32 = 8
+	CSMsgIdCastSkill       uint32 = 9
+	CSMsgIdRespawn         uint32 = 10
+	CSMsgIdMoveItem        uint32 = 11
+	CSMsgIdSplitItem       uint32 = 12
+	CSMsgIdUseItem         uint32 = 13
+	CSMsgIdEquipItem       uint32 = 14
+	CSMsgIdUnequipItem     uint32 = 15
+	CSMsgIdPickupLoot      uint32 = 16
+	CSMsgIdTradeRequest    uint32 = 17
+	CSMsgIdTradeRespond    uint32 = 18
+	CSMsgIdTradeOffer      uint32 = 19
+	CSMsgIdTradeLock       uint32 = 20
+	CSMsgIdTradeConfirm    uint32 = 21
+	CSMsgIdTradeCancel     uint32 = 22
+	CSMsgIdAcceptQuest     uint32 = 23
+	CSMsgIdAbandonQuest    uint32 = 24
+	CSMsgIdTurnInQuest     uint32 = 25
+	CSMsgIdTalkNpc         uint32 = 26
+	CSMsgIdBlockPlayer     uint32 = 27
+	CSMsgIdWhisperHistory  uint32 = 28
+	CSMsgIdPlayAction      uint32 = 29
+	CSMsgIdPartyInvite     uint32 = 30
+	CSMsgIdPartyRespond    uint32 = 31
+	CSMsgIdPartyLeave      uint32 = 32
+	CSMsgIdPartyKick       uint32 = 33
+	CSMsgIdPartyTransfer   uint32 = 34
+	CSMsgIdFriendRequest   uint32 = 35
+	CSMsgIdFriendRespond   uint32 = 36
+	CSMsgIdRemoveFriend    uint32 = 37
+	CSMsgIdRegister        uint32 = 38
 )
 
 // 服务器消息
 const (
-	SCMsgIdSyncPlayerId uint32 = 1
-	SCMsgIdBroadCast    uint32 = 2
-	SCMsgIdSyncPlayers  uint32 = 3
-	SCMsgIdMove         uint32 = 4
-	SCMsgIdPlayerLeave  uint32 = 5
+	SCMsgIdSyncPlayerId          uint32 = 1
+	SCMsgIdBroadCast             uint32 = 2
+	SCMsgIdSyncPlayers           uint32 = 3
+	SCMsgIdMove                  uint32 = 4
+	SCMsgIdPlayerLeave           uint32 = 5
+	SCMsgIdPing                  uint32 = 6
+	SCMsgIdShutdown              uint32 = 7
+	SCMsgIdLoginResult           uint32 = 8
+	SCMsgIdCharacterList         uint32 = 9
+	SCMsgIdCreateCharacterResult uint32 = 10
+	SCMsgIdSelectCharacterResult uint32 = 11
+	SCMsgIdAttackResult          uint32 = 12
+	SCMsgIdDamage                uint32 = 13
+	SCMsgIdDeath                 uint32 = 14
+	SCMsgIdMonsterAppear         uint32 = 15
+	SCMsgIdCastSkillResult       uint32 = 16
+	SCMsgIdSyncBuffs             uint32 = 17
+	SCMsgIdBuffChange            uint32 = 18
+	SCMsgIdRespawnOptions        uint32 = 19
+	SCMsgIdRespawnResult         uint32 = 20
+	SCMsgIdRevive                uint32 = 21
+	SCMsgIdExpChange             uint32 = 22
+	SCMsgIdLevelUp               uint32 = 23
+	SCMsgIdSyncInventory         uint32 = 24
+	SCMsgIdInventoryChange       uint32 = 25
+	SCMsgIdInventoryResult       uint32 = 26
+	SCMsgIdEntityStats           uint32 = 27
+	SCMsgIdEquipResult           uint32 = 28
+	SCMsgIdLootAppear            uint32 = 29
+	SCMsgIdLootRemove            uint32 = 30
+	SCMsgIdPickupResult          uint32 = 31
+	SCMsgIdTradeInvite           uint32 = 32
+	SCMsgIdTradeOpen             uint32 = 33
+	SCMsgIdTradeUpdate           uint32 = 34
+	SCMsgIdTradeClosed           uint32 = 35
+	SCMsgIdTradeResult           uint32 = 36
+	SCMsgIdSyncQuests            uint32 = 37
+	SCMsgIdQuestUpdate           uint32 = 38
+	SCMsgIdQuestResult           uint32 = 39
+	SCMsgIdZoneEvent             uint32 = 40
+	SCMsgIdChat                  uint32 = 41
+	SCMsgIdChatResult            uint32 = 42
+	SCMsgIdWhisperResult         uint32 = 43
+	SCMsgIdSyncBlockList         uint32 = 44
+	SCMsgIdBlockResult           uint32 = 45
+	SCMsgIdChatHistory           uint32 = 46
+	SCMsgIdGmResult              uint32 = 47
+	SCMsgIdActionResult          uint32 = 48
+	SCMsgIdPartyInvitation       uint32 = 49
+	SCMsgIdPartyInfo             uint32 = 50
+	SCMsgIdPartyStates           uint32 = 51
+	SCMsgIdPartyResult           uint32 = 52
+	SCMsgIdFriendInvitation      uint32 = 53
+	SCMsgIdSyncFriendList        uint32 = 54
+	SCMsgIdFriendPresence        uint32 = 55
+	SCMsgIdFriendResult          uint32 = 56
 )
 
 // SCId2Message server to client id message map
@@ -28,16 +115,91 @@ var CSId2Message map[uint32]proto.Message
 func init() {
 	// 客户端消息
 	CSId2Message = map[uint32]proto.Message{
-		CSMsgIdTalk: &BroadCast{},
-		CSMsgIdMove: &BroadCast{},
+		CSMsgIdTalk:            &BroadCast{},
+		CSMsgIdMove:            &BroadCast{},
+		CSMsgIdPong:            &Pong{},
+		CSMsgIdLogin:           &Login{},
+		CSMsgIdCharacterList:   &CharacterList{},
+		CSMsgIdCreateCharacter: &CreateCharacter{},
+		CSMsgIdSelectCharacter: &SelectCharacter{},
+		CSMsgIdAttack:          &Attack{},
+		CSMsgIdCastSkill:       &CastSkill{},
+		CSMsgIdRespawn:         &Respawn{},
+		CSMsgIdMoveItem:        &MoveItem{},
+		CSMsgIdSplitItem:       &SplitItem{},
+		CSMsgIdUseItem:         &UseItem{},
+		CSMsgIdEquipItem:       &EquipItem{},
+		CSMsgIdUnequipItem:     &UnequipItem{},
+		CSMsgIdPickupLoot:      &PickupLoot{},
+		CSMsgIdTradeRequest:    &TradeRequest{},
+		CSMsgIdTradeRespond:    &TradeRespond{},
+		CSMsgIdTradeOffer:      &TradeOffer{},
+		CSMsgIdTradeLock:       &TradeLock{},
+		CSMsgIdTradeConfirm:    &TradeConfirm{},
+		CSMsgIdTradeCancel:     &TradeCancel{},
+		CSMsgIdAcceptQuest:     &AcceptQuest{},
+		CSMsgIdAbandonQuest:    &AbandonQuest{},
+		CSMsgIdTurnInQuest:     &TurnInQuest{},
+		CSMsgIdTalkNpc:         &TalkNpc{},
 	}
 
 	// 服务器消息
 	SCId2Message = map[uint32]proto.Message{
-		SCMsgIdSyncPlayerId: &SyncPlayerId{},
-		SCMsgIdBroadCast:    &BroadCast{},
-		SCMsgIdSyncPlayers:  &SyncPlayers{},
-		SCMsgIdMove:         &Position{},
-		SCMsgIdPlayerLeave:  &SyncPlayerId{},
+		SCMsgIdSyncPlayerId:          &SyncPlayerId{},
+		SCMsgIdBroadCast:             &BroadCast{},
+		SCMsgIdSyncPlayers:           &SyncPlayers{},
+		SCMsgIdMove:                  &Position{},
+		SCMsgIdPlayerLeave:           &SyncPlayerId{},
+		SCMsgIdPing:                  &Ping{},
+		SCMsgIdShutdown:              &ServerShutdown{},
+		SCMsgIdLoginResult:           &LoginResult{},
+		SCMsgIdCharacterList:         &CharacterList{},
+		SCMsgIdCreateCharacterResult: &CreateCharacterResult{},
+		SCMsgIdSelectCharacterResult: &SelectCharacterResult{},
+		SCMsgIdAttackResult:          &AttackResult{},
+		SCMsgIdDamage:                &Damage{},
+		SCMsgIdDeath:                 &Death{},
+		SCMsgIdMonsterAppear:         &Monster{},
+		SCMsgIdCastSkillResult:       &CastSkillResult{},
+		SCMsgIdSyncBuffs:             &SyncBuffs{},
+		SCMsgIdBuffChange:            &BuffChange{},
+		SCMsgIdRespawnOptions:        &RespawnOptions{},
+		SCMsgIdRespawnResult:         &RespawnResult{},
+		SCMsgIdRevive:                &Revive{},
+		SCMsgIdExpChange:             &ExpChange{},
+		SCMsgIdLevelUp:               &LevelUp{},
+		SCMsgIdSyncInventory:         &SyncInventory{},
+		SCMsgIdInventoryChange:       &InventoryChange{},
+		SCMsgIdInventoryResult:       &InventoryResult{},
+		SCMsgIdEntityStats:           &EntityStats{},
+		SCMsgIdEquipResult:           &EquipResult{},
+		SCMsgIdLootAppear:            &GroundLoot{},
+		SCMsgIdLootRemove:            &LootRemove{},
+		SCMsgIdPickupResult:          &PickupResult{},
+		SCMsgIdTradeInvite:           &TradeInvite{},
+		SCMsgIdTradeOpen:             &TradeOpen{},
+		SCMsgIdTradeUpdate:           &TradeUpdate{},
+		SCMsgIdTradeClosed:           &TradeClosed{},
+		SCMsgIdTradeResult:           &TradeResult{},
+		SCMsgIdSyncQuests:            &SyncQuests{},
+		SCMsgIdQuestUpdate:           &QuestUpdate{},
+		SCMsgIdQuestResult:           &QuestResult{},
+		SCMsgIdZoneEvent:             &ZoneEvent{},
+		SCMsgIdChat:                  &ChatMessage{},
+		SCMsgIdChatResult:            &ChatResult{},
+		SCMsgIdWhisperResult:         &WhisperResult{},
+		SCMsgIdSyncBlockList:         &SyncBlockList{},
+		SCMsgIdBlockResult:           &BlockResult{},
+		SCMsgIdChatHistory:           &ChatHistory{},
+		SCMsgIdGmResult:              &GmCommandResult{},
+		SCMsgIdActionResult:          &ActionResult{},
+		SCMsgIdPartyInvitation:       &PartyInvitation{},
+		SCMsgIdPartyInfo:             &PartyInfo{},
+		SCMsgIdPartyStates:           &PartyStates{},
+		SCMsgIdPartyResult:           &PartyResult{},
+		SCMsgIdFriendInvitation:      &FriendInvitation{},
+		SCMsgIdSyncFriendList:        &SyncFriendList{},
+		SCMsgIdFriendPresence:        &FriendPresence{},
+		SCMsgIdFriendResult:          &FriendResult{},
 	}
 }
@@ -23,11 +23,12 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type BroadCastType int32
 
 const (
-	BroadCastType_Unspecified   BroadCastType = 0
-	BroadCastType_World_Chat    BroadCastType = 1
-	BroadCastType_Player_Pos    BroadCastType = 2
-	BroadCastType_Player_Action BroadCastType = 3
-	BroadCastType_After_Move    BroadCastType = 4
+	BroadCastType_Unspecified    BroadCastType = 0
+	BroadCastType_World_Chat     BroadCastType = 1
+	BroadCastType_Player_Pos     BroadCastType = 2
+	BroadCastType_Player_Action  BroadCastType = 3
+	BroadCastType_After_Move     BroadCastType = 4
+	BroadCastType_Player_Profile BroadCastType = 5
 )
 
 var BroadCastType_name = map[int32]string{
@@ -36,14 +37,16 @@ var BroadCastType_name = map[int32]string{
 	2: "Player_Pos",
 	3: "Player_Action",
 	4: "After_Move",
+	5: "Player_Profile",
 }
 
 var BroadCastType_value = map[string]int32{
-	"Unspecified":   0,
-	"World_Chat":    1,
-	"Player_Pos":    2,
-	"Player_Action": 3,
-	"After_Move":    4,
+	"Unspecified":    0,
+	"World_Chat":     1,
+	"Player_Pos":     2,
+	"Player_Action":  3,
+	"After_Move":     4,
+	"Player_Profile": 5,
 }
 
 func (x BroadCastType) String() string {
@@ -54,6 +57,486 @@ func (BroadCastType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_33c57e4bae7b9afd, []int{0}
 }
 
+// 职业
+type PlayerClass int32
+
+const (
+	PlayerClass_Class_Unknown PlayerClass = 0
+	PlayerClass_Class_Warrior PlayerClass = 1
+	PlayerClass_Class_Mage    PlayerClass = 2
+	PlayerClass_Class_Archer  PlayerClass = 3
+	PlayerClass_Class_Priest  PlayerClass = 4
+)
+
+var PlayerClass_name = map[int32]string{
+	0: "Class_Unknown",
+	1: "Class_Warrior",
+	2: "Class_Mage",
+	3: "Class_Archer",
+	4: "Class_Priest",
+}
+
+var PlayerClass_value = map[string]int32{
+	"Class_Unknown": 0,
+	"Class_Warrior": 1,
+	"Class_Mage":    2,
+	"Class_Archer":  3,
+	"Class_Priest":  4,
+}
+
+func (x PlayerClass) String() string {
+	return proto.EnumName(PlayerClass_name, int32(x))
+}
+
+func (PlayerClass) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{1}
+}
+
+// 装备部位
+type EquipSlot int32
+
+const (
+	EquipSlot_Equip_Slot_None      EquipSlot = 0
+	EquipSlot_Equip_Slot_Weapon    EquipSlot = 1
+	EquipSlot_Equip_Slot_Head      EquipSlot = 2
+	EquipSlot_Equip_Slot_Chest     EquipSlot = 3
+	EquipSlot_Equip_Slot_Legs      EquipSlot = 4
+	EquipSlot_Equip_Slot_Feet      EquipSlot = 5
+	EquipSlot_Equip_Slot_Accessory EquipSlot = 6
+)
+
+var EquipSlot_name = map[int32]string{
+	0: "Equip_Slot_None",
+	1: "Equip_Slot_Weapon",
+	2: "Equip_Slot_Head",
+	3: "Equip_Slot_Chest",
+	4: "Equip_Slot_Legs",
+	5: "Equip_Slot_Feet",
+	6: "Equip_Slot_Accessory",
+}
+
+var EquipSlot_value = map[string]int32{
+	"Equip_Slot_None":      0,
+	"Equip_Slot_Weapon":    1,
+	"Equip_Slot_Head":      2,
+	"Equip_Slot_Chest":     3,
+	"Equip_Slot_Legs":      4,
+	"Equip_Slot_Feet":      5,
+	"Equip_Slot_Accessory": 6,
+}
+
+func (x EquipSlot) String() string {
+	return proto.EnumName(EquipSlot_name, int32(x))
+}
+
+func (EquipSlot) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{2}
+}
+
+// 技能施法阶段
+type SkillState int32
+
+const (
+	SkillState_Skill_Cast_Start  SkillState = 0
+	SkillState_Skill_Cast_Finish SkillState = 1
+	SkillState_Skill_Cast_Cancel SkillState = 2
+)
+
+var SkillState_name = map[int32]string{
+	0: "Skill_Cast_Start",
+	1: "Skill_Cast_Finish",
+	2: "Skill_Cast_Cancel",
+}
+
+var SkillState_value = map[string]int32{
+	"Skill_Cast_Start":  0,
+	"Skill_Cast_Finish": 1,
+	"Skill_Cast_Cancel": 2,
+}
+
+func (x SkillState) String() string {
+	return proto.EnumName(SkillState_name, int32(x))
+}
+
+func (SkillState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{3}
+}
+
+// 聊天频道
+type ChatChannel int32
+
+const (
+	ChatChannel_Chat_Channel_World   ChatChannel = 0
+	ChatChannel_Chat_Channel_Nearby  ChatChannel = 1
+	ChatChannel_Chat_Channel_Scene   ChatChannel = 2
+	ChatChannel_Chat_Channel_Party   ChatChannel = 3
+	ChatChannel_Chat_Channel_Guild   ChatChannel = 4
+	ChatChannel_Chat_Channel_Whisper ChatChannel = 5
+	ChatChannel_Chat_Channel_System  ChatChannel = 6
+)
+
+var ChatChannel_name = map[int32]string{
+	0: "Chat_Channel_World",
+	1: "Chat_Channel_Nearby",
+	2: "Chat_Channel_Scene",
+	3: "Chat_Channel_Party",
+	4: "Chat_Channel_Guild",
+	5: "Chat_Channel_Whisper",
+	6: "Chat_Channel_System",
+}
+
+var ChatChannel_value = map[string]int32{
+	"Chat_Channel_World":   0,
+	"Chat_Channel_Nearby":  1,
+	"Chat_Channel_Scene":   2,
+	"Chat_Channel_Party":   3,
+	"Chat_Channel_Guild":   4,
+	"Chat_Channel_Whisper": 5,
+	"Chat_Channel_System":  6,
+}
+
+func (x ChatChannel) String() string {
+	return proto.EnumName(ChatChannel_name, int32(x))
+}
+
+func (ChatChannel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{4}
+}
+
+// 私聊的投递状态
+type WhisperStatus int32
+
+const (
+	WhisperStatus_Whisper_Delivered WhisperStatus = 0
+	WhisperStatus_Whisper_Offline   WhisperStatus = 1
+	WhisperStatus_Whisper_Blocked   WhisperStatus = 2
+	WhisperStatus_Whisper_Muted     WhisperStatus = 3
+)
+
+var WhisperStatus_name = map[int32]string{
+	0: "Whisper_Delivered",
+	1: "Whisper_Offline",
+	2: "Whisper_Blocked",
+	3: "Whisper_Muted",
+}
+
+var WhisperStatus_value = map[string]int32{
+	"Whisper_Delivered": 0,
+	"Whisper_Offline":   1,
+	"Whisper_Blocked":   2,
+	"Whisper_Muted":     3,
+}
+
+func (x WhisperStatus) String() string {
+	return proto.EnumName(WhisperStatus_name, int32(x))
+}
+
+func (WhisperStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{5}
+}
+
+// 通用结果码
+type ResultCode int32
+
+const (
+	ResultCode_Result_Ok                  ResultCode = 0
+	ResultCode_Result_Failed              ResultCode = 1
+	ResultCode_Result_Not_Login           ResultCode = 2
+	ResultCode_Result_Server_Closing      ResultCode = 3
+	ResultCode_Result_Account_Invalid     ResultCode = 4
+	ResultCode_Result_Name_Invalid        ResultCode = 5
+	ResultCode_Result_Name_Duplicate      ResultCode = 6
+	ResultCode_Result_Character_Limit     ResultCode = 7
+	ResultCode_Result_Character_Not_Found ResultCode = 8
+	ResultCode_Result_Character_Online    ResultCode = 9
+	ResultCode_Result_Target_Not_Found    ResultCode = 10
+	ResultCode_Result_Out_Of_Range        ResultCode = 11
+	ResultCode_Result_Dead                ResultCode = 12
+	ResultCode_Result_Target_Dead         ResultCode = 13
+	ResultCode_Result_Cooldown            ResultCode = 14
+	ResultCode_Result_Skill_Not_Found     ResultCode = 15
+	ResultCode_Result_Not_Enough_MP       ResultCode = 16
+	ResultCode_Result_Busy                ResultCode = 17
+	ResultCode_Result_Not_Dead            ResultCode = 18
+	ResultCode_Result_Point_Not_Found     ResultCode = 19
+	ResultCode_Result_Bag_Full            ResultCode = 20
+	ResultCode_Result_Item_Not_Found      ResultCode = 21
+	ResultCode_Result_Item_Not_Enough     ResultCode = 22
+	ResultCode_Result_Slot_Invalid        ResultCode = 23
+	ResultCode_Result_Item_Cannot_Use     ResultCode = 24
+	ResultCode_Result_Cannot_Equip        ResultCode = 25
+	ResultCode_Result_Not_Owner           ResultCode = 26
+	ResultCode_Result_Gold_Not_Enough     ResultCode = 27
+	ResultCode_Result_Item_Cannot_Trade   ResultCode = 28
+	ResultCode_Result_Target_Busy         ResultCode = 29
+	ResultCode_Result_Not_In_Trade        ResultCode = 30
+	ResultCode_Result_Trade_Not_Locked    ResultCode = 31
+	ResultCode_Result_Quest_Not_Found     ResultCode = 32
+	ResultCode_Result_Quest_Unavailable   ResultCode = 33
+	ResultCode_Result_Quest_Not_Ready     ResultCode = 34
+	ResultCode_Result_Channel_Unavailable ResultCode = 35
+	ResultCode_Result_Chat_Too_Fast       ResultCode = 36
+	ResultCode_Result_Chat_Duplicate      ResultCode = 37
+	ResultCode_Result_Chat_Muted          ResultCode = 38
+	ResultCode_Result_Chat_Banned_Word    ResultCode = 39
+	ResultCode_Result_Chat_Too_Long       ResultCode = 40
+	ResultCode_Result_Blocked             ResultCode = 41
+	ResultCode_Result_Block_List_Full     ResultCode = 42
+	ResultCode_Result_Gm_Unknown_Command  ResultCode = 43
+	ResultCode_Result_Gm_Bad_Args         ResultCode = 44
+	ResultCode_Result_Gm_No_Permission    ResultCode = 45
+	ResultCode_Result_Action_Not_Found    ResultCode = 46
+	ResultCode_Result_Action_Too_Fast     ResultCode = 47
+	ResultCode_Result_Party_Full          ResultCode = 48
+	ResultCode_Result_Not_In_Party        ResultCode = 49
+	ResultCode_Result_Not_Party_Leader    ResultCode = 50
+	ResultCode_Result_Already_In_Party    ResultCode = 51
+	ResultCode_Result_Invite_Declined     ResultCode = 52
+	ResultCode_Result_Friend_List_Full    ResultCode = 53
+	ResultCode_Result_Already_Friend      ResultCode = 54
+	ResultCode_Result_Not_Friend          ResultCode = 55
+	ResultCode_Result_Login_Failed        ResultCode = 56
+	ResultCode_Result_Account_Exists      ResultCode = 57
+	ResultCode_Result_Password_Invalid    ResultCode = 58
+	ResultCode_Result_Zone_No_Combat      ResultCode = 59
+)
+
+var ResultCode_name = map[int32]string{
+	0:  "Result_Ok",
+	1:  "Result_Failed",
+	2:  "Result_Not_Login",
+	3:  "Result_Server_Closing",
+	4:  "Result_Account_Invalid",
+	5:  "Result_Name_Invalid",
+	6:  "Result_Name_Duplicate",
+	7:  "Result_Character_Limit",
+	8:  "Result_Character_Not_Found",
+	9:  "Result_Character_Online",
+	10: "Result_Target_Not_Found",
+	11: "Result_Out_Of_Range",
+	12: "Result_Dead",
+	13: "Result_Target_Dead",
+	14: "Result_Cooldown",
+	15: "Result_Skill_Not_Found",
+	16: "Result_Not_Enough_MP",
+	17: "Result_Busy",
+	18: "Result_Not_Dead",
+	19: "Result_Point_Not_Found",
+	20: "Result_Bag_Full",
+	21: "Result_Item_Not_Found",
+	22: "Result_Item_Not_Enough",
+	23: "Result_Slot_Invalid",
+	24: "Result_Item_Cannot_Use",
+	25: "Result_Cannot_Equip",
+	26: "Result_Not_Owner",
+	27: "Result_Gold_Not_Enough",
+	28: "Result_Item_Cannot_Trade",
+	29: "Result_Target_Busy",
+	30: "Result_Not_In_Trade",
+	31: "Result_Trade_Not_Locked",
+	32: "Result_Quest_Not_Found",
+	33: "Result_Quest_Unavailable",
+	34: "Result_Quest_Not_Ready",
+	35: "Result_Channel_Unavailable",
+	36: "Result_Chat_Too_Fast",
+	37: "Result_Chat_Duplicate",
+	38: "Result_Chat_Muted",
+	39: "Result_Chat_Banned_Word",
+	40: "Result_Chat_Too_Long",
+	41: "Result_Blocked",
+	42: "Result_Block_List_Full",
+	43: "Result_Gm_Unknown_Command",
+	44: "Result_Gm_Bad_Args",
+	45: "Result_Gm_No_Permission",
+	46: "Result_Action_Not_Found",
+	47: "Result_Action_Too_Fast",
+	48: "Result_Party_Full",
+	49: "Result_Not_In_Party",
+	50: "Result_Not_Party_Leader",
+	51: "Result_Already_In_Party",
+	52: "Result_Invite_Declined",
+	53: "Result_Friend_List_Full",
+	54: "Result_Already_Friend",
+	55: "Result_Not_Friend",
+	56: "Result_Login_Failed",
+	57: "Result_Account_Exists",
+	58: "Result_Password_Invalid",
+	59: "Result_Zone_No_Combat",
+}
+
+var ResultCode_value = map[string]int32{
+	"Result_Ok":                  0,
+	"Result_Failed":              1,
+	"Result_Not_Login":           2,
+	"Result_Server_Closing":      3,
+	"Result_Account_Invalid":     4,
+	"Result_Name_Invalid":        5,
+	"Result_Name_Duplicate":      6,
+	"Result_Character_Limit":     7,
+	"Result_Character_Not_Found": 8,
+	"Result_Character_Online":    9,
+	"Result_Target_Not_Found":    10,
+	"Result_Out_Of_Range":        11,
+	"Result_Dead":                12,
+	"Result_Target_Dead":         13,
+	"Result_Cooldown":            14,
+	"Result_Skill_Not_Found":     15,
+	"Result_Not_Enough_MP":       16,
+	"Result_Busy":                17,
+	"Result_Not_Dead":            18,
+	"Result_Point_Not_Found":     19,
+	"Result_Bag_Full":            20,
+	"Result_Item_Not_Found":      21,
+	"Result_Item_Not_Enough":     22,
+	"Result_Slot_Invalid":        23,
+	"Result_Item_Cannot_Use":     24,
+	"Result_Cannot_Equip":        25,
+	"Result_Not_Owner":           26,
+	"Result_Gold_Not_Enough":     27,
+	"Result_Item_Cannot_Trade":   28,
+	"Result_Target_Busy":         29,
+	"Result_Not_In_Trade":        30,
+	"Result_Trade_Not_Locked":    31,
+	"Result_Quest_Not_Found":     32,
+	"Result_Quest_Unavailable":   33,
+	"Result_Quest_Not_Ready":     34,
+	"Result_Channel_Unavailable": 35,
+	"Result_Chat_Too_Fast":       36,
+	"Result_Chat_Duplicate":      37,
+	"Result_Chat_Muted":          38,
+	"Result_Chat_Banned_Word":    39,
+	"Result_Chat_Too_Long":       40,
+	"Result_Blocked":             41,
+	"Result_Block_List_Full":     42,
+	"Result_Gm_Unknown_Command":  43,
+	"Result_Gm_Bad_Args":         44,
+	"Result_Gm_No_Permission":    45,
+	"Result_Action_Not_Found":    46,
+	"Result_Action_Too_Fast":     47,
+	"Result_Party_Full":          48,
+	"Result_Not_In_Party":        49,
+	"Result_Not_Party_Leader":    50,
+	"Result_Already_In_Party":    51,
+	"Result_Invite_Declined":     52,
+	"Result_Friend_List_Full":    53,
+	"Result_Already_Friend":      54,
+	"Result_Not_Friend":          55,
+	"Result_Login_Failed":        56,
+	"Result_Account_Exists":      57,
+	"Result_Password_Invalid":    58,
+	"Result_Zone_No_Combat":      59,
+}
+
+func (x ResultCode) String() string {
+	return proto.EnumName(ResultCode_name, int32(x))
+}
+
+func (ResultCode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{6}
+}
+
+// 经验来源
+type ExpSource int32
+
+const (
+	ExpSource_Exp_Source_Unknown ExpSource = 0
+	ExpSource_Exp_Source_Kill    ExpSource = 1
+	ExpSource_Exp_Source_Quest   ExpSource = 2
+	ExpSource_Exp_Source_Party   ExpSource = 3
+)
+
+var ExpSource_name = map[int32]string{
+	0: "Exp_Source_Unknown",
+	1: "Exp_Source_Kill",
+	2: "Exp_Source_Quest",
+	3: "Exp_Source_Party",
+}
+
+var ExpSource_value = map[string]int32{
+	"Exp_Source_Unknown": 0,
+	"Exp_Source_Kill":    1,
+	"Exp_Source_Quest":   2,
+	"Exp_Source_Party":   3,
+}
+
+func (x ExpSource) String() string {
+	return proto.EnumName(ExpSource_name, int32(x))
+}
+
+func (ExpSource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{7}
+}
+
+// 交易结束原因
+type TradeCloseReason int32
+
+const (
+	TradeCloseReason_Trade_Done          TradeCloseReason = 0
+	TradeCloseReason_Trade_Cancelled     TradeCloseReason = 1
+	TradeCloseReason_Trade_Disconnect    TradeCloseReason = 2
+	TradeCloseReason_Trade_Out_Of_Range  TradeCloseReason = 3
+	TradeCloseReason_Trade_Offer_Changed TradeCloseReason = 4
+	TradeCloseReason_Trade_Failed        TradeCloseReason = 5
+)
+
+var TradeCloseReason_name = map[int32]string{
+	0: "Trade_Done",
+	1: "Trade_Cancelled",
+	2: "Trade_Disconnect",
+	3: "Trade_Out_Of_Range",
+	4: "Trade_Offer_Changed",
+	5: "Trade_Failed",
+}
+
+var TradeCloseReason_value = map[string]int32{
+	"Trade_Done":          0,
+	"Trade_Cancelled":     1,
+	"Trade_Disconnect":    2,
+	"Trade_Out_Of_Range":  3,
+	"Trade_Offer_Changed": 4,
+	"Trade_Failed":        5,
+}
+
+func (x TradeCloseReason) String() string {
+	return proto.EnumName(TradeCloseReason_name, int32(x))
+}
+
+func (TradeCloseReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{8}
+}
+
+// 任务状态
+type QuestState int32
+
+const (
+	QuestState_Quest_State_Active    QuestState = 0
+	QuestState_Quest_State_Ready     QuestState = 1
+	QuestState_Quest_State_Done      QuestState = 2
+	QuestState_Quest_State_Abandoned QuestState = 3
+)
+
+var QuestState_name = map[int32]string{
+	0: "Quest_State_Active",
+	1: "Quest_State_Ready",
+	2: "Quest_State_Done",
+	3: "Quest_State_Abandoned",
+}
+
+var QuestState_value = map[string]int32{
+	"Quest_State_Active":    0,
+	"Quest_State_Ready":     1,
+	"Quest_State_Done":      2,
+	"Quest_State_Abandoned": 3,
+}
+
+func (x QuestState) String() string {
+	return proto.EnumName(QuestState_name, int32(x))
+}
+
+func (QuestState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{9}
+}
+
 // 同步客户端玩家id
 type SyncPlayerId struct {
 	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
@@ -158,6 +641,214 @@ func (m *Position) GetV() float32 {
 	return 0
 }
 
+// 玩家显示数据
+type PlayerProfile struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Class                PlayerClass    `protobuf:"varint,2,opt,name=class,proto3,enum=mmopb.PlayerClass" json:"class,omitempty"`
+	AppearanceId         int32          `protobuf:"varint,3,opt,name=appearance_id,json=appearanceId,proto3" json:"appearance_id,omitempty"`
+	Level                int32          `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Equips               []*EquipVisual `protobuf:"bytes,5,rep,name=equips,proto3" json:"equips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PlayerProfile) Reset()         { *m = PlayerProfile{} }
+func (m *PlayerProfile) String() string { return proto.CompactTextString(m) }
+func (*PlayerProfile) ProtoMessage()    {}
+func (*PlayerProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{2}
+}
+
+func (m *PlayerProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerProfile.Unmarshal(m, b)
+}
+func (m *PlayerProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerProfile.Marshal(b, m, deterministic)
+}
+func (m *PlayerProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerProfile.Merge(m, src)
+}
+func (m *PlayerProfile) XXX_Size() int {
+	return xxx_messageInfo_PlayerProfile.Size(m)
+}
+func (m *PlayerProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerProfile proto.InternalMessageInfo
+
+func (m *PlayerProfile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlayerProfile) GetClass() PlayerClass {
+	if m != nil {
+		return m.Class
+	}
+	return PlayerClass_Class_Unknown
+}
+
+func (m *PlayerProfile) GetAppearanceId() int32 {
+	if m != nil {
+		return m.AppearanceId
+	}
+	return 0
+}
+
+func (m *PlayerProfile) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *PlayerProfile) GetEquips() []*EquipVisual {
+	if m != nil {
+		return m.Equips
+	}
+	return nil
+}
+
+// 装备外观
+type EquipVisual struct {
+	Slot                 EquipSlot `protobuf:"varint,1,opt,name=slot,proto3,enum=mmopb.EquipSlot" json:"slot,omitempty"`
+	ItemId               int32     `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	ModelId              int32     `protobuf:"varint,3,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *EquipVisual) Reset()         { *m = EquipVisual{} }
+func (m *EquipVisual) String() string { return proto.CompactTextString(m) }
+func (*EquipVisual) ProtoMessage()    {}
+func (*EquipVisual) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{3}
+}
+
+func (m *EquipVisual) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EquipVisual.Unmarshal(m, b)
+}
+func (m *EquipVisual) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EquipVisual.Marshal(b, m, deterministic)
+}
+func (m *EquipVisual) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EquipVisual.Merge(m, src)
+}
+func (m *EquipVisual) XXX_Size() int {
+	return xxx_messageInfo_EquipVisual.Size(m)
+}
+func (m *EquipVisual) XXX_DiscardUnknown() {
+	xxx_messageInfo_EquipVisual.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EquipVisual proto.InternalMessageInfo
+
+func (m *EquipVisual) GetSlot() EquipSlot {
+	if m != nil {
+		return m.Slot
+	}
+	return EquipSlot_Equip_Slot_None
+}
+
+func (m *EquipVisual) GetItemId() int32 {
+	if m != nil {
+		return m.ItemId
+	}
+	return 0
+}
+
+func (m *EquipVisual) GetModelId() int32 {
+	if m != nil {
+		return m.ModelId
+	}
+	return 0
+}
+
+// 技能动作
+type SkillAction struct {
+	SkillId              int32      `protobuf:"varint,1,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	State                SkillState `protobuf:"varint,2,opt,name=state,proto3,enum=mmopb.SkillState" json:"state,omitempty"`
+	TargetId             int32      `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	X                    float32    `protobuf:"fixed32,4,opt,name=x,proto3" json:"x,omitempty"`
+	Z                    float32    `protobuf:"fixed32,5,opt,name=z,proto3" json:"z,omitempty"`
+	CastTime             int32      `protobuf:"varint,6,opt,name=cast_time,json=castTime,proto3" json:"cast_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SkillAction) Reset()         { *m = SkillAction{} }
+func (m *SkillAction) String() string { return proto.CompactTextString(m) }
+func (*SkillAction) ProtoMessage()    {}
+func (*SkillAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{4}
+}
+
+func (m *SkillAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SkillAction.Unmarshal(m, b)
+}
+func (m *SkillAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SkillAction.Marshal(b, m, deterministic)
+}
+func (m *SkillAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SkillAction.Merge(m, src)
+}
+func (m *SkillAction) XXX_Size() int {
+	return xxx_messageInfo_SkillAction.Size(m)
+}
+func (m *SkillAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_SkillAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SkillAction proto.InternalMessageInfo
+
+func (m *SkillAction) GetSkillId() int32 {
+	if m != nil {
+		return m.SkillId
+	}
+	return 0
+}
+
+func (m *SkillAction) GetState() SkillState {
+	if m != nil {
+		return m.State
+	}
+	return SkillState_Skill_Cast_Start
+}
+
+func (m *SkillAction) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *SkillAction) GetX() float32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *SkillAction) GetZ() float32 {
+	if m != nil {
+		return m.Z
+	}
+	return 0
+}
+
+func (m *SkillAction) GetCastTime() int32 {
+	if m != nil {
+		return m.CastTime
+	}
+	return 0
+}
+
 // 玩家广播数据
 type BroadCast struct {
 	PlayerId int32         `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
@@ -166,7 +857,9 @@ type BroadCast struct {
 	//	*BroadCast_Content
 	//	*BroadCast_Pos
 	//	*BroadCast_Action
+	//	*BroadCast_Skill
 	Data                 isBroadCast_Data `protobuf_oneof:"Data"`
+	Profile              *PlayerProfile   `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -176,7 +869,7 @@ func (m *BroadCast) Reset()         { *m = BroadCast{} }
 func (m *BroadCast) String() string { return proto.CompactTextString(m) }
 func (*BroadCast) ProtoMessage()    {}
 func (*BroadCast) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{2}
+	return fileDescriptor_33c57e4bae7b9afd, []int{5}
 }
 
 func (m *BroadCast) XXX_Unmarshal(b []byte) error {
@@ -227,12 +920,18 @@ type BroadCast_Action struct {
 	Action int32 `protobuf:"varint,5,opt,name=action,proto3,oneof"`
 }
 
+type BroadCast_Skill struct {
+	Skill *SkillAction `protobuf:"bytes,7,opt,name=skill,proto3,oneof"`
+}
+
 func (*BroadCast_Content) isBroadCast_Data() {}
 
 func (*BroadCast_Pos) isBroadCast_Data() {}
 
 func (*BroadCast_Action) isBroadCast_Data() {}
 
+func (*BroadCast_Skill) isBroadCast_Data() {}
+
 func (m *BroadCast) GetData() isBroadCast_Data {
 	if m != nil {
 		return m.Data
@@ -261,187 +960,5429 @@ func (m *BroadCast) GetAction() int32 {
 	return 0
 }
 
+func (m *BroadCast) GetSkill() *SkillAction {
+	if x, ok := m.GetData().(*BroadCast_Skill); ok {
+		return x.Skill
+	}
+	return nil
+}
+
+func (m *BroadCast) GetProfile() *PlayerProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*BroadCast) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*BroadCast_Content)(nil),
 		(*BroadCast_Pos)(nil),
 		(*BroadCast_Action)(nil),
+		(*BroadCast_Skill)(nil),
 	}
 }
 
-// 玩家聊天数据
-type Talk struct {
-	TargetPlayerId       int32    `protobuf:"varint,1,opt,name=target_player_id,json=targetPlayerId,proto3" json:"target_player_id,omitempty"`
-	Content              string   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+// 播放表情动作，成功时以Player_Action广播给周围玩家
+type PlayAction struct {
+	Action               int32    `protobuf:"varint,1,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Talk) Reset()         { *m = Talk{} }
-func (m *Talk) String() string { return proto.CompactTextString(m) }
-func (*Talk) ProtoMessage()    {}
-func (*Talk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{3}
+func (m *PlayAction) Reset()         { *m = PlayAction{} }
+func (m *PlayAction) String() string { return proto.CompactTextString(m) }
+func (*PlayAction) ProtoMessage()    {}
+func (*PlayAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{6}
 }
 
-func (m *Talk) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Talk.Unmarshal(m, b)
+func (m *PlayAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayAction.Unmarshal(m, b)
 }
-func (m *Talk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Talk.Marshal(b, m, deterministic)
+func (m *PlayAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayAction.Marshal(b, m, deterministic)
 }
-func (m *Talk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Talk.Merge(m, src)
+func (m *PlayAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayAction.Merge(m, src)
 }
-func (m *Talk) XXX_Size() int {
-	return xxx_messageInfo_Talk.Size(m)
+func (m *PlayAction) XXX_Size() int {
+	return xxx_messageInfo_PlayAction.Size(m)
 }
-func (m *Talk) XXX_DiscardUnknown() {
-	xxx_messageInfo_Talk.DiscardUnknown(m)
+func (m *PlayAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayAction.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Talk proto.InternalMessageInfo
+var xxx_messageInfo_PlayAction proto.InternalMessageInfo
 
-func (m *Talk) GetTargetPlayerId() int32 {
+func (m *PlayAction) GetAction() int32 {
 	if m != nil {
-		return m.TargetPlayerId
+		return m.Action
 	}
 	return 0
 }
 
-func (m *Talk) GetContent() string {
+// 播放动作失败的原因
+type ActionResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Action               int32      `protobuf:"varint,2,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ActionResult) Reset()         { *m = ActionResult{} }
+func (m *ActionResult) String() string { return proto.CompactTextString(m) }
+func (*ActionResult) ProtoMessage()    {}
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{7}
+}
+
+func (m *ActionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionResult.Unmarshal(m, b)
+}
+func (m *ActionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionResult.Marshal(b, m, deterministic)
+}
+func (m *ActionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionResult.Merge(m, src)
+}
+func (m *ActionResult) XXX_Size() int {
+	return xxx_messageInfo_ActionResult.Size(m)
+}
+func (m *ActionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionResult proto.InternalMessageInfo
+
+func (m *ActionResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *ActionResult) GetAction() int32 {
+	if m != nil {
+		return m.Action
+	}
+	return 0
+}
+
+// 玩家聊天数据
+type Talk struct {
+	TargetPlayerId       int32       `protobuf:"varint,1,opt,name=target_player_id,json=targetPlayerId,proto3" json:"target_player_id,omitempty"`
+	Content              string      `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Channel              ChatChannel `protobuf:"varint,3,opt,name=channel,proto3,enum=mmopb.ChatChannel" json:"channel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Talk) Reset()         { *m = Talk{} }
+func (m *Talk) String() string { return proto.CompactTextString(m) }
+func (*Talk) ProtoMessage()    {}
+func (*Talk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{8}
+}
+
+func (m *Talk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Talk.Unmarshal(m, b)
+}
+func (m *Talk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Talk.Marshal(b, m, deterministic)
+}
+func (m *Talk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Talk.Merge(m, src)
+}
+func (m *Talk) XXX_Size() int {
+	return xxx_messageInfo_Talk.Size(m)
+}
+func (m *Talk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Talk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Talk proto.InternalMessageInfo
+
+func (m *Talk) GetTargetPlayerId() int32 {
+	if m != nil {
+		return m.TargetPlayerId
+	}
+	return 0
+}
+
+func (m *Talk) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *Talk) GetChannel() ChatChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return ChatChannel_Chat_Channel_World
+}
+
+// 聊天消息，发给频道内的玩家，私聊时也会发回给发送者
+type ChatMessage struct {
+	Channel              ChatChannel `protobuf:"varint,1,opt,name=channel,proto3,enum=mmopb.ChatChannel" json:"channel,omitempty"`
+	SenderId             int32       `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	SenderName           string      `protobuf:"bytes,3,opt,name=sender_name,json=senderName,proto3" json:"sender_name,omitempty"`
+	TargetId             int32       `protobuf:"varint,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Content              string      `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	TimeMs               int64       `protobuf:"varint,6,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"`
+	Offline              bool        `protobuf:"varint,7,opt,name=offline,proto3" json:"offline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChatMessage) Reset()         { *m = ChatMessage{} }
+func (m *ChatMessage) String() string { return proto.CompactTextString(m) }
+func (*ChatMessage) ProtoMessage()    {}
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{9}
+}
+
+func (m *ChatMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatMessage.Unmarshal(m, b)
+}
+func (m *ChatMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatMessage.Marshal(b, m, deterministic)
+}
+func (m *ChatMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatMessage.Merge(m, src)
+}
+func (m *ChatMessage) XXX_Size() int {
+	return xxx_messageInfo_ChatMessage.Size(m)
+}
+func (m *ChatMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatMessage proto.InternalMessageInfo
+
+func (m *ChatMessage) GetChannel() ChatChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return ChatChannel_Chat_Channel_World
+}
+
+func (m *ChatMessage) GetSenderId() int32 {
+	if m != nil {
+		return m.SenderId
+	}
+	return 0
+}
+
+func (m *ChatMessage) GetSenderName() string {
+	if m != nil {
+		return m.SenderName
+	}
+	return ""
+}
+
+func (m *ChatMessage) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *ChatMessage) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *ChatMessage) GetTimeMs() int64 {
+	if m != nil {
+		return m.TimeMs
+	}
+	return 0
+}
+
+func (m *ChatMessage) GetOffline() bool {
+	if m != nil {
+		return m.Offline
+	}
+	return false
+}
+
+// 聊天结果，只在失败时返回
+type ChatResult struct {
+	Result               ResultCode  `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Channel              ChatChannel `protobuf:"varint,2,opt,name=channel,proto3,enum=mmopb.ChatChannel" json:"channel,omitempty"`
+	TargetId             int32       `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	MuteRemainMs         int64       `protobuf:"varint,4,opt,name=mute_remain_ms,json=muteRemainMs,proto3" json:"mute_remain_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChatResult) Reset()         { *m = ChatResult{} }
+func (m *ChatResult) String() string { return proto.CompactTextString(m) }
+func (*ChatResult) ProtoMessage()    {}
+func (*ChatResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{10}
+}
+
+func (m *ChatResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatResult.Unmarshal(m, b)
+}
+func (m *ChatResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatResult.Marshal(b, m, deterministic)
+}
+func (m *ChatResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatResult.Merge(m, src)
+}
+func (m *ChatResult) XXX_Size() int {
+	return xxx_messageInfo_ChatResult.Size(m)
+}
+func (m *ChatResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatResult proto.InternalMessageInfo
+
+func (m *ChatResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *ChatResult) GetChannel() ChatChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return ChatChannel_Chat_Channel_World
+}
+
+func (m *ChatResult) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *ChatResult) GetMuteRemainMs() int64 {
+	if m != nil {
+		return m.MuteRemainMs
+	}
+	return 0
+}
+
+// 私聊结果，每次私聊都会返回
+type WhisperResult struct {
+	TargetId             int32         `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Status               WhisperStatus `protobuf:"varint,2,opt,name=status,proto3,enum=mmopb.WhisperStatus" json:"status,omitempty"`
+	Stored               bool          `protobuf:"varint,3,opt,name=stored,proto3" json:"stored,omitempty"`
+	MuteRemainMs         int64         `protobuf:"varint,4,opt,name=mute_remain_ms,json=muteRemainMs,proto3" json:"mute_remain_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WhisperResult) Reset()         { *m = WhisperResult{} }
+func (m *WhisperResult) String() string { return proto.CompactTextString(m) }
+func (*WhisperResult) ProtoMessage()    {}
+func (*WhisperResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{11}
+}
+
+func (m *WhisperResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WhisperResult.Unmarshal(m, b)
+}
+func (m *WhisperResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WhisperResult.Marshal(b, m, deterministic)
+}
+func (m *WhisperResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WhisperResult.Merge(m, src)
+}
+func (m *WhisperResult) XXX_Size() int {
+	return xxx_messageInfo_WhisperResult.Size(m)
+}
+func (m *WhisperResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_WhisperResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WhisperResult proto.InternalMessageInfo
+
+func (m *WhisperResult) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *WhisperResult) GetStatus() WhisperStatus {
+	if m != nil {
+		return m.Status
+	}
+	return WhisperStatus_Whisper_Delivered
+}
+
+func (m *WhisperResult) GetStored() bool {
+	if m != nil {
+		return m.Stored
+	}
+	return false
+}
+
+func (m *WhisperResult) GetMuteRemainMs() int64 {
+	if m != nil {
+		return m.MuteRemainMs
+	}
+	return 0
+}
+
+// 聊天记录，进入世界时发送各频道最近的消息，或者回应私聊记录的请求
+type ChatHistory struct {
+	Channel              ChatChannel    `protobuf:"varint,1,opt,name=channel,proto3,enum=mmopb.ChatChannel" json:"channel,omitempty"`
+	TargetId             int32          `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Messages             []*ChatMessage `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChatHistory) Reset()         { *m = ChatHistory{} }
+func (m *ChatHistory) String() string { return proto.CompactTextString(m) }
+func (*ChatHistory) ProtoMessage()    {}
+func (*ChatHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{12}
+}
+
+func (m *ChatHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChatHistory.Unmarshal(m, b)
+}
+func (m *ChatHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChatHistory.Marshal(b, m, deterministic)
+}
+func (m *ChatHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChatHistory.Merge(m, src)
+}
+func (m *ChatHistory) XXX_Size() int {
+	return xxx_messageInfo_ChatHistory.Size(m)
+}
+func (m *ChatHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChatHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChatHistory proto.InternalMessageInfo
+
+func (m *ChatHistory) GetChannel() ChatChannel {
+	if m != nil {
+		return m.Channel
+	}
+	return ChatChannel_Chat_Channel_World
+}
+
+func (m *ChatHistory) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *ChatHistory) GetMessages() []*ChatMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// 请求和指定玩家之间的私聊记录
+type GetWhisperHistory struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWhisperHistory) Reset()         { *m = GetWhisperHistory{} }
+func (m *GetWhisperHistory) String() string { return proto.CompactTextString(m) }
+func (*GetWhisperHistory) ProtoMessage()    {}
+func (*GetWhisperHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{13}
+}
+
+func (m *GetWhisperHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWhisperHistory.Unmarshal(m, b)
+}
+func (m *GetWhisperHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWhisperHistory.Marshal(b, m, deterministic)
+}
+func (m *GetWhisperHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWhisperHistory.Merge(m, src)
+}
+func (m *GetWhisperHistory) XXX_Size() int {
+	return xxx_messageInfo_GetWhisperHistory.Size(m)
+}
+func (m *GetWhisperHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWhisperHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWhisperHistory proto.InternalMessageInfo
+
+func (m *GetWhisperHistory) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// GM命令执行结果，GM在聊天中发送以/开头的消息时返回
+type GmCommandResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Command              string     `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Output               string     `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GmCommandResult) Reset()         { *m = GmCommandResult{} }
+func (m *GmCommandResult) String() string { return proto.CompactTextString(m) }
+func (*GmCommandResult) ProtoMessage()    {}
+func (*GmCommandResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{14}
+}
+
+func (m *GmCommandResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GmCommandResult.Unmarshal(m, b)
+}
+func (m *GmCommandResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GmCommandResult.Marshal(b, m, deterministic)
+}
+func (m *GmCommandResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GmCommandResult.Merge(m, src)
+}
+func (m *GmCommandResult) XXX_Size() int {
+	return xxx_messageInfo_GmCommandResult.Size(m)
+}
+func (m *GmCommandResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GmCommandResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GmCommandResult proto.InternalMessageInfo
+
+func (m *GmCommandResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *GmCommandResult) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *GmCommandResult) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+// 屏蔽或取消屏蔽玩家，被屏蔽玩家的聊天、交易和组队邀请都不会送达
+type BlockPlayer struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Block                bool     `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockPlayer) Reset()         { *m = BlockPlayer{} }
+func (m *BlockPlayer) String() string { return proto.CompactTextString(m) }
+func (*BlockPlayer) ProtoMessage()    {}
+func (*BlockPlayer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{15}
+}
+
+func (m *BlockPlayer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockPlayer.Unmarshal(m, b)
+}
+func (m *BlockPlayer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockPlayer.Marshal(b, m, deterministic)
+}
+func (m *BlockPlayer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockPlayer.Merge(m, src)
+}
+func (m *BlockPlayer) XXX_Size() int {
+	return xxx_messageInfo_BlockPlayer.Size(m)
+}
+func (m *BlockPlayer) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockPlayer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockPlayer proto.InternalMessageInfo
+
+func (m *BlockPlayer) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *BlockPlayer) GetBlock() bool {
+	if m != nil {
+		return m.Block
+	}
+	return false
+}
+
+// 屏蔽列表，上线时同步
+type SyncBlockList struct {
+	PlayerIds            []int32  `protobuf:"varint,1,rep,packed,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncBlockList) Reset()         { *m = SyncBlockList{} }
+func (m *SyncBlockList) String() string { return proto.CompactTextString(m) }
+func (*SyncBlockList) ProtoMessage()    {}
+func (*SyncBlockList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{16}
+}
+
+func (m *SyncBlockList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncBlockList.Unmarshal(m, b)
+}
+func (m *SyncBlockList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncBlockList.Marshal(b, m, deterministic)
+}
+func (m *SyncBlockList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncBlockList.Merge(m, src)
+}
+func (m *SyncBlockList) XXX_Size() int {
+	return xxx_messageInfo_SyncBlockList.Size(m)
+}
+func (m *SyncBlockList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncBlockList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncBlockList proto.InternalMessageInfo
+
+func (m *SyncBlockList) GetPlayerIds() []int32 {
+	if m != nil {
+		return m.PlayerIds
+	}
+	return nil
+}
+
+// 屏蔽操作结果
+type BlockResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	PlayerId             int32      `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Blocked              bool       `protobuf:"varint,3,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BlockResult) Reset()         { *m = BlockResult{} }
+func (m *BlockResult) String() string { return proto.CompactTextString(m) }
+func (*BlockResult) ProtoMessage()    {}
+func (*BlockResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{17}
+}
+
+func (m *BlockResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockResult.Unmarshal(m, b)
+}
+func (m *BlockResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockResult.Marshal(b, m, deterministic)
+}
+func (m *BlockResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResult.Merge(m, src)
+}
+func (m *BlockResult) XXX_Size() int {
+	return xxx_messageInfo_BlockResult.Size(m)
+}
+func (m *BlockResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResult proto.InternalMessageInfo
+
+func (m *BlockResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *BlockResult) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *BlockResult) GetBlocked() bool {
+	if m != nil {
+		return m.Blocked
+	}
+	return false
+}
+
+// 战斗属性
+type CombatStats struct {
+	Hp                   int32    `protobuf:"varint,1,opt,name=hp,proto3" json:"hp,omitempty"`
+	MaxHp                int32    `protobuf:"varint,2,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
+	Mp                   int32    `protobuf:"varint,3,opt,name=mp,proto3" json:"mp,omitempty"`
+	MaxMp                int32    `protobuf:"varint,4,opt,name=max_mp,json=maxMp,proto3" json:"max_mp,omitempty"`
+	MoveSpeed            float32  `protobuf:"fixed32,5,opt,name=move_speed,json=moveSpeed,proto3" json:"move_speed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CombatStats) Reset()         { *m = CombatStats{} }
+func (m *CombatStats) String() string { return proto.CompactTextString(m) }
+func (*CombatStats) ProtoMessage()    {}
+func (*CombatStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{18}
+}
+
+func (m *CombatStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CombatStats.Unmarshal(m, b)
+}
+func (m *CombatStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CombatStats.Marshal(b, m, deterministic)
+}
+func (m *CombatStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CombatStats.Merge(m, src)
+}
+func (m *CombatStats) XXX_Size() int {
+	return xxx_messageInfo_CombatStats.Size(m)
+}
+func (m *CombatStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CombatStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CombatStats proto.InternalMessageInfo
+
+func (m *CombatStats) GetHp() int32 {
+	if m != nil {
+		return m.Hp
+	}
+	return 0
+}
+
+func (m *CombatStats) GetMaxHp() int32 {
+	if m != nil {
+		return m.MaxHp
+	}
+	return 0
+}
+
+func (m *CombatStats) GetMp() int32 {
+	if m != nil {
+		return m.Mp
+	}
+	return 0
+}
+
+func (m *CombatStats) GetMaxMp() int32 {
+	if m != nil {
+		return m.MaxMp
+	}
+	return 0
+}
+
+func (m *CombatStats) GetMoveSpeed() float32 {
+	if m != nil {
+		return m.MoveSpeed
+	}
+	return 0
+}
+
+// 玩家信息
+type Player struct {
+	PlayerId             int32          `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Pos                  *Position      `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
+	Profile              *PlayerProfile `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	Stats                *CombatStats   `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Player) Reset()         { *m = Player{} }
+func (m *Player) String() string { return proto.CompactTextString(m) }
+func (*Player) ProtoMessage()    {}
+func (*Player) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{19}
+}
+
+func (m *Player) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Player.Unmarshal(m, b)
+}
+func (m *Player) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Player.Marshal(b, m, deterministic)
+}
+func (m *Player) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Player.Merge(m, src)
+}
+func (m *Player) XXX_Size() int {
+	return xxx_messageInfo_Player.Size(m)
+}
+func (m *Player) XXX_DiscardUnknown() {
+	xxx_messageInfo_Player.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Player proto.InternalMessageInfo
+
+func (m *Player) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *Player) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+func (m *Player) GetProfile() *PlayerProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *Player) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// 怪物信息
+type Monster struct {
+	MonsterId            int32        `protobuf:"varint,1,opt,name=monster_id,json=monsterId,proto3" json:"monster_id,omitempty"`
+	TemplateId           int32        `protobuf:"varint,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Name                 string       `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Pos                  *Position    `protobuf:"bytes,4,opt,name=pos,proto3" json:"pos,omitempty"`
+	Stats                *CombatStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Monster) Reset()         { *m = Monster{} }
+func (m *Monster) String() string { return proto.CompactTextString(m) }
+func (*Monster) ProtoMessage()    {}
+func (*Monster) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{20}
+}
+
+func (m *Monster) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Monster.Unmarshal(m, b)
+}
+func (m *Monster) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Monster.Marshal(b, m, deterministic)
+}
+func (m *Monster) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Monster.Merge(m, src)
+}
+func (m *Monster) XXX_Size() int {
+	return xxx_messageInfo_Monster.Size(m)
+}
+func (m *Monster) XXX_DiscardUnknown() {
+	xxx_messageInfo_Monster.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Monster proto.InternalMessageInfo
+
+func (m *Monster) GetMonsterId() int32 {
+	if m != nil {
+		return m.MonsterId
+	}
+	return 0
+}
+
+func (m *Monster) GetTemplateId() int32 {
+	if m != nil {
+		return m.TemplateId
+	}
+	return 0
+}
+
+func (m *Monster) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Monster) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+func (m *Monster) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// 同步视野内的玩家和怪物显示数据
+type SyncPlayers struct {
+	Players              []*Player     `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	Monsters             []*Monster    `protobuf:"bytes,2,rep,name=monsters,proto3" json:"monsters,omitempty"`
+	Loots                []*GroundLoot `protobuf:"bytes,3,rep,name=loots,proto3" json:"loots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SyncPlayers) Reset()         { *m = SyncPlayers{} }
+func (m *SyncPlayers) String() string { return proto.CompactTextString(m) }
+func (*SyncPlayers) ProtoMessage()    {}
+func (*SyncPlayers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{21}
+}
+
+func (m *SyncPlayers) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncPlayers.Unmarshal(m, b)
+}
+func (m *SyncPlayers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncPlayers.Marshal(b, m, deterministic)
+}
+func (m *SyncPlayers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncPlayers.Merge(m, src)
+}
+func (m *SyncPlayers) XXX_Size() int {
+	return xxx_messageInfo_SyncPlayers.Size(m)
+}
+func (m *SyncPlayers) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncPlayers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncPlayers proto.InternalMessageInfo
+
+func (m *SyncPlayers) GetPlayers() []*Player {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *SyncPlayers) GetMonsters() []*Monster {
+	if m != nil {
+		return m.Monsters
+	}
+	return nil
+}
+
+func (m *SyncPlayers) GetLoots() []*GroundLoot {
+	if m != nil {
+		return m.Loots
+	}
+	return nil
+}
+
+// 服务器心跳探测
+type Ping struct {
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return proto.CompactTextString(m) }
+func (*Ping) ProtoMessage()    {}
+func (*Ping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{22}
+}
+
+func (m *Ping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Ping.Unmarshal(m, b)
+}
+func (m *Ping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Ping.Marshal(b, m, deterministic)
+}
+func (m *Ping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Ping.Merge(m, src)
+}
+func (m *Ping) XXX_Size() int {
+	return xxx_messageInfo_Ping.Size(m)
+}
+func (m *Ping) XXX_DiscardUnknown() {
+	xxx_messageInfo_Ping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Ping proto.InternalMessageInfo
+
+func (m *Ping) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// 客户端心跳应答
+type Pong struct {
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pong) Reset()         { *m = Pong{} }
+func (m *Pong) String() string { return proto.CompactTextString(m) }
+func (*Pong) ProtoMessage()    {}
+func (*Pong) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{23}
+}
+
+func (m *Pong) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pong.Unmarshal(m, b)
+}
+func (m *Pong) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pong.Marshal(b, m, deterministic)
+}
+func (m *Pong) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pong.Merge(m, src)
+}
+func (m *Pong) XXX_Size() int {
+	return xxx_messageInfo_Pong.Size(m)
+}
+func (m *Pong) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pong.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pong proto.InternalMessageInfo
+
+func (m *Pong) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// 停服通知
+type ServerShutdown struct {
+	Countdown            int32    `protobuf:"varint,1,opt,name=countdown,proto3" json:"countdown,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServerShutdown) Reset()         { *m = ServerShutdown{} }
+func (m *ServerShutdown) String() string { return proto.CompactTextString(m) }
+func (*ServerShutdown) ProtoMessage()    {}
+func (*ServerShutdown) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{24}
+}
+
+func (m *ServerShutdown) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerShutdown.Unmarshal(m, b)
+}
+func (m *ServerShutdown) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerShutdown.Marshal(b, m, deterministic)
+}
+func (m *ServerShutdown) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerShutdown.Merge(m, src)
+}
+func (m *ServerShutdown) XXX_Size() int {
+	return xxx_messageInfo_ServerShutdown.Size(m)
+}
+func (m *ServerShutdown) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerShutdown.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerShutdown proto.InternalMessageInfo
+
+func (m *ServerShutdown) GetCountdown() int32 {
+	if m != nil {
+		return m.Countdown
+	}
+	return 0
+}
+
+func (m *ServerShutdown) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// 账号登录
+type Login struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Login) Reset()         { *m = Login{} }
+func (m *Login) String() string { return proto.CompactTextString(m) }
+func (*Login) ProtoMessage()    {}
+func (*Login) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{25}
+}
+
+func (m *Login) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Login.Unmarshal(m, b)
+}
+func (m *Login) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Login.Marshal(b, m, deterministic)
+}
+func (m *Login) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Login.Merge(m, src)
+}
+func (m *Login) XXX_Size() int {
+	return xxx_messageInfo_Login.Size(m)
+}
+func (m *Login) XXX_DiscardUnknown() {
+	xxx_messageInfo_Login.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Login proto.InternalMessageInfo
+
+func (m *Login) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *Login) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+// 注册账号，成功后直接登录，返回LoginResult
+type Register struct {
+	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Register) Reset()         { *m = Register{} }
+func (m *Register) String() string { return proto.CompactTextString(m) }
+func (*Register) ProtoMessage()    {}
+func (*Register) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{26}
+}
+
+func (m *Register) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Register.Unmarshal(m, b)
+}
+func (m *Register) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Register.Marshal(b, m, deterministic)
+}
+func (m *Register) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Register.Merge(m, src)
+}
+func (m *Register) XXX_Size() int {
+	return xxx_messageInfo_Register.Size(m)
+}
+func (m *Register) XXX_DiscardUnknown() {
+	xxx_messageInfo_Register.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Register proto.InternalMessageInfo
+
+func (m *Register) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *Register) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+// 角色简要信息
+type CharacterBrief struct {
+	PlayerId             int32          `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Profile              *PlayerProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CharacterBrief) Reset()         { *m = CharacterBrief{} }
+func (m *CharacterBrief) String() string { return proto.CompactTextString(m) }
+func (*CharacterBrief) ProtoMessage()    {}
+func (*CharacterBrief) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{27}
+}
+
+func (m *CharacterBrief) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CharacterBrief.Unmarshal(m, b)
+}
+func (m *CharacterBrief) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CharacterBrief.Marshal(b, m, deterministic)
+}
+func (m *CharacterBrief) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CharacterBrief.Merge(m, src)
+}
+func (m *CharacterBrief) XXX_Size() int {
+	return xxx_messageInfo_CharacterBrief.Size(m)
+}
+func (m *CharacterBrief) XXX_DiscardUnknown() {
+	xxx_messageInfo_CharacterBrief.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CharacterBrief proto.InternalMessageInfo
+
+func (m *CharacterBrief) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *CharacterBrief) GetProfile() *PlayerProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+// 登录结果
+type LoginResult struct {
+	Result               ResultCode        `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Characters           []*CharacterBrief `protobuf:"bytes,2,rep,name=characters,proto3" json:"characters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LoginResult) Reset()         { *m = LoginResult{} }
+func (m *LoginResult) String() string { return proto.CompactTextString(m) }
+func (*LoginResult) ProtoMessage()    {}
+func (*LoginResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{28}
+}
+
+func (m *LoginResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoginResult.Unmarshal(m, b)
+}
+func (m *LoginResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoginResult.Marshal(b, m, deterministic)
+}
+func (m *LoginResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoginResult.Merge(m, src)
+}
+func (m *LoginResult) XXX_Size() int {
+	return xxx_messageInfo_LoginResult.Size(m)
+}
+func (m *LoginResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoginResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoginResult proto.InternalMessageInfo
+
+func (m *LoginResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *LoginResult) GetCharacters() []*CharacterBrief {
+	if m != nil {
+		return m.Characters
+	}
+	return nil
+}
+
+// 角色列表，客户端请求时为空
+type CharacterList struct {
+	Characters           []*CharacterBrief `protobuf:"bytes,1,rep,name=characters,proto3" json:"characters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CharacterList) Reset()         { *m = CharacterList{} }
+func (m *CharacterList) String() string { return proto.CompactTextString(m) }
+func (*CharacterList) ProtoMessage()    {}
+func (*CharacterList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{29}
+}
+
+func (m *CharacterList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CharacterList.Unmarshal(m, b)
+}
+func (m *CharacterList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CharacterList.Marshal(b, m, deterministic)
+}
+func (m *CharacterList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CharacterList.Merge(m, src)
+}
+func (m *CharacterList) XXX_Size() int {
+	return xxx_messageInfo_CharacterList.Size(m)
+}
+func (m *CharacterList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CharacterList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CharacterList proto.InternalMessageInfo
+
+func (m *CharacterList) GetCharacters() []*CharacterBrief {
+	if m != nil {
+		return m.Characters
+	}
+	return nil
+}
+
+// 创建角色
+type CreateCharacter struct {
+	Name                 string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Class                PlayerClass `protobuf:"varint,2,opt,name=class,proto3,enum=mmopb.PlayerClass" json:"class,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateCharacter) Reset()         { *m = CreateCharacter{} }
+func (m *CreateCharacter) String() string { return proto.CompactTextString(m) }
+func (*CreateCharacter) ProtoMessage()    {}
+func (*CreateCharacter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{30}
+}
+
+func (m *CreateCharacter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCharacter.Unmarshal(m, b)
+}
+func (m *CreateCharacter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCharacter.Marshal(b, m, deterministic)
+}
+func (m *CreateCharacter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCharacter.Merge(m, src)
+}
+func (m *CreateCharacter) XXX_Size() int {
+	return xxx_messageInfo_CreateCharacter.Size(m)
+}
+func (m *CreateCharacter) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCharacter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCharacter proto.InternalMessageInfo
+
+func (m *CreateCharacter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateCharacter) GetClass() PlayerClass {
+	if m != nil {
+		return m.Class
+	}
+	return PlayerClass_Class_Unknown
+}
+
+// 创建角色结果
+type CreateCharacterResult struct {
+	Result               ResultCode      `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Character            *CharacterBrief `protobuf:"bytes,2,opt,name=character,proto3" json:"character,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CreateCharacterResult) Reset()         { *m = CreateCharacterResult{} }
+func (m *CreateCharacterResult) String() string { return proto.CompactTextString(m) }
+func (*CreateCharacterResult) ProtoMessage()    {}
+func (*CreateCharacterResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{31}
+}
+
+func (m *CreateCharacterResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateCharacterResult.Unmarshal(m, b)
+}
+func (m *CreateCharacterResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateCharacterResult.Marshal(b, m, deterministic)
+}
+func (m *CreateCharacterResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateCharacterResult.Merge(m, src)
+}
+func (m *CreateCharacterResult) XXX_Size() int {
+	return xxx_messageInfo_CreateCharacterResult.Size(m)
+}
+func (m *CreateCharacterResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateCharacterResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateCharacterResult proto.InternalMessageInfo
+
+func (m *CreateCharacterResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *CreateCharacterResult) GetCharacter() *CharacterBrief {
+	if m != nil {
+		return m.Character
+	}
+	return nil
+}
+
+// 选择角色进入世界
+type SelectCharacter struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelectCharacter) Reset()         { *m = SelectCharacter{} }
+func (m *SelectCharacter) String() string { return proto.CompactTextString(m) }
+func (*SelectCharacter) ProtoMessage()    {}
+func (*SelectCharacter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{32}
+}
+
+func (m *SelectCharacter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectCharacter.Unmarshal(m, b)
+}
+func (m *SelectCharacter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectCharacter.Marshal(b, m, deterministic)
+}
+func (m *SelectCharacter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectCharacter.Merge(m, src)
+}
+func (m *SelectCharacter) XXX_Size() int {
+	return xxx_messageInfo_SelectCharacter.Size(m)
+}
+func (m *SelectCharacter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectCharacter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectCharacter proto.InternalMessageInfo
+
+func (m *SelectCharacter) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// 选择角色结果
+type SelectCharacterResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	PlayerId             int32      `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SelectCharacterResult) Reset()         { *m = SelectCharacterResult{} }
+func (m *SelectCharacterResult) String() string { return proto.CompactTextString(m) }
+func (*SelectCharacterResult) ProtoMessage()    {}
+func (*SelectCharacterResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{33}
+}
+
+func (m *SelectCharacterResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelectCharacterResult.Unmarshal(m, b)
+}
+func (m *SelectCharacterResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelectCharacterResult.Marshal(b, m, deterministic)
+}
+func (m *SelectCharacterResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelectCharacterResult.Merge(m, src)
+}
+func (m *SelectCharacterResult) XXX_Size() int {
+	return xxx_messageInfo_SelectCharacterResult.Size(m)
+}
+func (m *SelectCharacterResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelectCharacterResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelectCharacterResult proto.InternalMessageInfo
+
+func (m *SelectCharacterResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *SelectCharacterResult) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// 攻击请求
+type Attack struct {
+	TargetId             int32    `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attack) Reset()         { *m = Attack{} }
+func (m *Attack) String() string { return proto.CompactTextString(m) }
+func (*Attack) ProtoMessage()    {}
+func (*Attack) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{34}
+}
+
+func (m *Attack) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attack.Unmarshal(m, b)
+}
+func (m *Attack) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attack.Marshal(b, m, deterministic)
+}
+func (m *Attack) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attack.Merge(m, src)
+}
+func (m *Attack) XXX_Size() int {
+	return xxx_messageInfo_Attack.Size(m)
+}
+func (m *Attack) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attack.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attack proto.InternalMessageInfo
+
+func (m *Attack) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 攻击结果，只在攻击失败时返回给攻击者
+type AttackResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	TargetId             int32      `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AttackResult) Reset()         { *m = AttackResult{} }
+func (m *AttackResult) String() string { return proto.CompactTextString(m) }
+func (*AttackResult) ProtoMessage()    {}
+func (*AttackResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{35}
+}
+
+func (m *AttackResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttackResult.Unmarshal(m, b)
+}
+func (m *AttackResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttackResult.Marshal(b, m, deterministic)
+}
+func (m *AttackResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttackResult.Merge(m, src)
+}
+func (m *AttackResult) XXX_Size() int {
+	return xxx_messageInfo_AttackResult.Size(m)
+}
+func (m *AttackResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttackResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttackResult proto.InternalMessageInfo
+
+func (m *AttackResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *AttackResult) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 伤害事件
+type Damage struct {
+	AttackerId           int32    `protobuf:"varint,1,opt,name=attacker_id,json=attackerId,proto3" json:"attacker_id,omitempty"`
+	TargetId             int32    `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Damage               int32    `protobuf:"varint,3,opt,name=damage,proto3" json:"damage,omitempty"`
+	Critical             bool     `protobuf:"varint,4,opt,name=critical,proto3" json:"critical,omitempty"`
+	Hp                   int32    `protobuf:"varint,5,opt,name=hp,proto3" json:"hp,omitempty"`
+	SkillId              int32    `protobuf:"varint,6,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Damage) Reset()         { *m = Damage{} }
+func (m *Damage) String() string { return proto.CompactTextString(m) }
+func (*Damage) ProtoMessage()    {}
+func (*Damage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{36}
+}
+
+func (m *Damage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Damage.Unmarshal(m, b)
+}
+func (m *Damage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Damage.Marshal(b, m, deterministic)
+}
+func (m *Damage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Damage.Merge(m, src)
+}
+func (m *Damage) XXX_Size() int {
+	return xxx_messageInfo_Damage.Size(m)
+}
+func (m *Damage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Damage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Damage proto.InternalMessageInfo
+
+func (m *Damage) GetAttackerId() int32 {
+	if m != nil {
+		return m.AttackerId
+	}
+	return 0
+}
+
+func (m *Damage) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *Damage) GetDamage() int32 {
+	if m != nil {
+		return m.Damage
+	}
+	return 0
+}
+
+func (m *Damage) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+func (m *Damage) GetHp() int32 {
+	if m != nil {
+		return m.Hp
+	}
+	return 0
+}
+
+func (m *Damage) GetSkillId() int32 {
+	if m != nil {
+		return m.SkillId
+	}
+	return 0
+}
+
+// 死亡事件
+type Death struct {
+	EntityId             int32    `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	KillerId             int32    `protobuf:"varint,2,opt,name=killer_id,json=killerId,proto3" json:"killer_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Death) Reset()         { *m = Death{} }
+func (m *Death) String() string { return proto.CompactTextString(m) }
+func (*Death) ProtoMessage()    {}
+func (*Death) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{37}
+}
+
+func (m *Death) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Death.Unmarshal(m, b)
+}
+func (m *Death) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Death.Marshal(b, m, deterministic)
+}
+func (m *Death) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Death.Merge(m, src)
+}
+func (m *Death) XXX_Size() int {
+	return xxx_messageInfo_Death.Size(m)
+}
+func (m *Death) XXX_DiscardUnknown() {
+	xxx_messageInfo_Death.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Death proto.InternalMessageInfo
+
+func (m *Death) GetEntityId() int32 {
+	if m != nil {
+		return m.EntityId
+	}
+	return 0
+}
+
+func (m *Death) GetKillerId() int32 {
+	if m != nil {
+		return m.KillerId
+	}
+	return 0
+}
+
+// 释放技能
+type CastSkill struct {
+	SkillId              int32    `protobuf:"varint,1,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	TargetId             int32    `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	X                    float32  `protobuf:"fixed32,3,opt,name=x,proto3" json:"x,omitempty"`
+	Z                    float32  `protobuf:"fixed32,4,opt,name=z,proto3" json:"z,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CastSkill) Reset()         { *m = CastSkill{} }
+func (m *CastSkill) String() string { return proto.CompactTextString(m) }
+func (*CastSkill) ProtoMessage()    {}
+func (*CastSkill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{38}
+}
+
+func (m *CastSkill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CastSkill.Unmarshal(m, b)
+}
+func (m *CastSkill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CastSkill.Marshal(b, m, deterministic)
+}
+func (m *CastSkill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CastSkill.Merge(m, src)
+}
+func (m *CastSkill) XXX_Size() int {
+	return xxx_messageInfo_CastSkill.Size(m)
+}
+func (m *CastSkill) XXX_DiscardUnknown() {
+	xxx_messageInfo_CastSkill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CastSkill proto.InternalMessageInfo
+
+func (m *CastSkill) GetSkillId() int32 {
+	if m != nil {
+		return m.SkillId
+	}
+	return 0
+}
+
+func (m *CastSkill) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+func (m *CastSkill) GetX() float32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *CastSkill) GetZ() float32 {
+	if m != nil {
+		return m.Z
+	}
+	return 0
+}
+
+// 释放技能结果，只在失败时返回给施法者
+type CastSkillResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	SkillId              int32      `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CastSkillResult) Reset()         { *m = CastSkillResult{} }
+func (m *CastSkillResult) String() string { return proto.CompactTextString(m) }
+func (*CastSkillResult) ProtoMessage()    {}
+func (*CastSkillResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{39}
+}
+
+func (m *CastSkillResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CastSkillResult.Unmarshal(m, b)
+}
+func (m *CastSkillResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CastSkillResult.Marshal(b, m, deterministic)
+}
+func (m *CastSkillResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CastSkillResult.Merge(m, src)
+}
+func (m *CastSkillResult) XXX_Size() int {
+	return xxx_messageInfo_CastSkillResult.Size(m)
+}
+func (m *CastSkillResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CastSkillResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CastSkillResult proto.InternalMessageInfo
+
+func (m *CastSkillResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *CastSkillResult) GetSkillId() int32 {
+	if m != nil {
+		return m.SkillId
+	}
+	return 0
+}
+
+// buff信息
+type BuffInfo struct {
+	BuffId               int32    `protobuf:"varint,1,opt,name=buff_id,json=buffId,proto3" json:"buff_id,omitempty"`
+	Stacks               int32    `protobuf:"varint,2,opt,name=stacks,proto3" json:"stacks,omitempty"`
+	RemainMs             int32    `protobuf:"varint,3,opt,name=remain_ms,json=remainMs,proto3" json:"remain_ms,omitempty"`
+	CasterId             int32    `protobuf:"varint,4,opt,name=caster_id,json=casterId,proto3" json:"caster_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuffInfo) Reset()         { *m = BuffInfo{} }
+func (m *BuffInfo) String() string { return proto.CompactTextString(m) }
+func (*BuffInfo) ProtoMessage()    {}
+func (*BuffInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{40}
+}
+
+func (m *BuffInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuffInfo.Unmarshal(m, b)
+}
+func (m *BuffInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuffInfo.Marshal(b, m, deterministic)
+}
+func (m *BuffInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuffInfo.Merge(m, src)
+}
+func (m *BuffInfo) XXX_Size() int {
+	return xxx_messageInfo_BuffInfo.Size(m)
+}
+func (m *BuffInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuffInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuffInfo proto.InternalMessageInfo
+
+func (m *BuffInfo) GetBuffId() int32 {
+	if m != nil {
+		return m.BuffId
+	}
+	return 0
+}
+
+func (m *BuffInfo) GetStacks() int32 {
+	if m != nil {
+		return m.Stacks
+	}
+	return 0
+}
+
+func (m *BuffInfo) GetRemainMs() int32 {
+	if m != nil {
+		return m.RemainMs
+	}
+	return 0
+}
+
+func (m *BuffInfo) GetCasterId() int32 {
+	if m != nil {
+		return m.CasterId
+	}
+	return 0
+}
+
+// 同步实体身上的全部buff，进入视野时发送
+type SyncBuffs struct {
+	EntityId             int32       `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Buffs                []*BuffInfo `protobuf:"bytes,2,rep,name=buffs,proto3" json:"buffs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SyncBuffs) Reset()         { *m = SyncBuffs{} }
+func (m *SyncBuffs) String() string { return proto.CompactTextString(m) }
+func (*SyncBuffs) ProtoMessage()    {}
+func (*SyncBuffs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{41}
+}
+
+func (m *SyncBuffs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncBuffs.Unmarshal(m, b)
+}
+func (m *SyncBuffs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncBuffs.Marshal(b, m, deterministic)
+}
+func (m *SyncBuffs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncBuffs.Merge(m, src)
+}
+func (m *SyncBuffs) XXX_Size() int {
+	return xxx_messageInfo_SyncBuffs.Size(m)
+}
+func (m *SyncBuffs) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncBuffs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncBuffs proto.InternalMessageInfo
+
+func (m *SyncBuffs) GetEntityId() int32 {
+	if m != nil {
+		return m.EntityId
+	}
+	return 0
+}
+
+func (m *SyncBuffs) GetBuffs() []*BuffInfo {
+	if m != nil {
+		return m.Buffs
+	}
+	return nil
+}
+
+// buff变化，添加、叠加、刷新或移除时广播
+type BuffChange struct {
+	EntityId             int32     `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Buff                 *BuffInfo `protobuf:"bytes,2,opt,name=buff,proto3" json:"buff,omitempty"`
+	Removed              bool      `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BuffChange) Reset()         { *m = BuffChange{} }
+func (m *BuffChange) String() string { return proto.CompactTextString(m) }
+func (*BuffChange) ProtoMessage()    {}
+func (*BuffChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{42}
+}
+
+func (m *BuffChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuffChange.Unmarshal(m, b)
+}
+func (m *BuffChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuffChange.Marshal(b, m, deterministic)
+}
+func (m *BuffChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuffChange.Merge(m, src)
+}
+func (m *BuffChange) XXX_Size() int {
+	return xxx_messageInfo_BuffChange.Size(m)
+}
+func (m *BuffChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuffChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuffChange proto.InternalMessageInfo
+
+func (m *BuffChange) GetEntityId() int32 {
+	if m != nil {
+		return m.EntityId
+	}
+	return 0
+}
+
+func (m *BuffChange) GetBuff() *BuffInfo {
+	if m != nil {
+		return m.Buff
+	}
+	return nil
+}
+
+func (m *BuffChange) GetRemoved() bool {
+	if m != nil {
+		return m.Removed
+	}
+	return false
+}
+
+// 复活点
+type RespawnPoint struct {
+	PointId              int32     `protobuf:"varint,1,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	Name                 string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Pos                  *Position `protobuf:"bytes,3,opt,name=pos,proto3" json:"pos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RespawnPoint) Reset()         { *m = RespawnPoint{} }
+func (m *RespawnPoint) String() string { return proto.CompactTextString(m) }
+func (*RespawnPoint) ProtoMessage()    {}
+func (*RespawnPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{43}
+}
+
+func (m *RespawnPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespawnPoint.Unmarshal(m, b)
+}
+func (m *RespawnPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespawnPoint.Marshal(b, m, deterministic)
+}
+func (m *RespawnPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespawnPoint.Merge(m, src)
+}
+func (m *RespawnPoint) XXX_Size() int {
+	return xxx_messageInfo_RespawnPoint.Size(m)
+}
+func (m *RespawnPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespawnPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespawnPoint proto.InternalMessageInfo
+
+func (m *RespawnPoint) GetPointId() int32 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+func (m *RespawnPoint) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RespawnPoint) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+// 玩家死亡后发送可选的复活点，nearest_point_id为离死亡位置最近的复活点
+type RespawnOptions struct {
+	SceneId              int32           `protobuf:"varint,1,opt,name=scene_id,json=sceneId,proto3" json:"scene_id,omitempty"`
+	Points               []*RespawnPoint `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	NearestPointId       int32           `protobuf:"varint,3,opt,name=nearest_point_id,json=nearestPointId,proto3" json:"nearest_point_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RespawnOptions) Reset()         { *m = RespawnOptions{} }
+func (m *RespawnOptions) String() string { return proto.CompactTextString(m) }
+func (*RespawnOptions) ProtoMessage()    {}
+func (*RespawnOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{44}
+}
+
+func (m *RespawnOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespawnOptions.Unmarshal(m, b)
+}
+func (m *RespawnOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespawnOptions.Marshal(b, m, deterministic)
+}
+func (m *RespawnOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespawnOptions.Merge(m, src)
+}
+func (m *RespawnOptions) XXX_Size() int {
+	return xxx_messageInfo_RespawnOptions.Size(m)
+}
+func (m *RespawnOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespawnOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespawnOptions proto.InternalMessageInfo
+
+func (m *RespawnOptions) GetSceneId() int32 {
+	if m != nil {
+		return m.SceneId
+	}
+	return 0
+}
+
+func (m *RespawnOptions) GetPoints() []*RespawnPoint {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+func (m *RespawnOptions) GetNearestPointId() int32 {
+	if m != nil {
+		return m.NearestPointId
+	}
+	return 0
+}
+
+// 请求复活，point_id为0时使用最近的复活点
+type Respawn struct {
+	PointId              int32    `protobuf:"varint,1,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Respawn) Reset()         { *m = Respawn{} }
+func (m *Respawn) String() string { return proto.CompactTextString(m) }
+func (*Respawn) ProtoMessage()    {}
+func (*Respawn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{45}
+}
+
+func (m *Respawn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Respawn.Unmarshal(m, b)
+}
+func (m *Respawn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Respawn.Marshal(b, m, deterministic)
+}
+func (m *Respawn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Respawn.Merge(m, src)
+}
+func (m *Respawn) XXX_Size() int {
+	return xxx_messageInfo_Respawn.Size(m)
+}
+func (m *Respawn) XXX_DiscardUnknown() {
+	xxx_messageInfo_Respawn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Respawn proto.InternalMessageInfo
+
+func (m *Respawn) GetPointId() int32 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+// 复活结果，只在失败时返回
+type RespawnResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	PointId              int32      `protobuf:"varint,2,opt,name=point_id,json=pointId,proto3" json:"point_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RespawnResult) Reset()         { *m = RespawnResult{} }
+func (m *RespawnResult) String() string { return proto.CompactTextString(m) }
+func (*RespawnResult) ProtoMessage()    {}
+func (*RespawnResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{46}
+}
+
+func (m *RespawnResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespawnResult.Unmarshal(m, b)
+}
+func (m *RespawnResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespawnResult.Marshal(b, m, deterministic)
+}
+func (m *RespawnResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespawnResult.Merge(m, src)
+}
+func (m *RespawnResult) XXX_Size() int {
+	return xxx_messageInfo_RespawnResult.Size(m)
+}
+func (m *RespawnResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespawnResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespawnResult proto.InternalMessageInfo
+
+func (m *RespawnResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *RespawnResult) GetPointId() int32 {
+	if m != nil {
+		return m.PointId
+	}
+	return 0
+}
+
+// 实体复活，广播给新位置周围的玩家
+type Revive struct {
+	EntityId             int32        `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Pos                  *Position    `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
+	Stats                *CombatStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Revive) Reset()         { *m = Revive{} }
+func (m *Revive) String() string { return proto.CompactTextString(m) }
+func (*Revive) ProtoMessage()    {}
+func (*Revive) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{47}
+}
+
+func (m *Revive) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revive.Unmarshal(m, b)
+}
+func (m *Revive) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revive.Marshal(b, m, deterministic)
+}
+func (m *Revive) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revive.Merge(m, src)
+}
+func (m *Revive) XXX_Size() int {
+	return xxx_messageInfo_Revive.Size(m)
+}
+func (m *Revive) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revive.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revive proto.InternalMessageInfo
+
+func (m *Revive) GetEntityId() int32 {
+	if m != nil {
+		return m.EntityId
+	}
+	return 0
+}
+
+func (m *Revive) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+func (m *Revive) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// 经验变化，只发给自己，进入世界时也会发送一次
+type ExpChange struct {
+	Level                int32     `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Exp                  int64     `protobuf:"varint,2,opt,name=exp,proto3" json:"exp,omitempty"`
+	NextExp              int64     `protobuf:"varint,3,opt,name=next_exp,json=nextExp,proto3" json:"next_exp,omitempty"`
+	Gained               int64     `protobuf:"varint,4,opt,name=gained,proto3" json:"gained,omitempty"`
+	Source               ExpSource `protobuf:"varint,5,opt,name=source,proto3,enum=mmopb.ExpSource" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ExpChange) Reset()         { *m = ExpChange{} }
+func (m *ExpChange) String() string { return proto.CompactTextString(m) }
+func (*ExpChange) ProtoMessage()    {}
+func (*ExpChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{48}
+}
+
+func (m *ExpChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpChange.Unmarshal(m, b)
+}
+func (m *ExpChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpChange.Marshal(b, m, deterministic)
+}
+func (m *ExpChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpChange.Merge(m, src)
+}
+func (m *ExpChange) XXX_Size() int {
+	return xxx_messageInfo_ExpChange.Size(m)
+}
+func (m *ExpChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExpChange proto.InternalMessageInfo
+
+func (m *ExpChange) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *ExpChange) GetExp() int64 {
+	if m != nil {
+		return m.Exp
+	}
+	return 0
+}
+
+func (m *ExpChange) GetNextExp() int64 {
+	if m != nil {
+		return m.NextExp
+	}
+	return 0
+}
+
+func (m *ExpChange) GetGained() int64 {
+	if m != nil {
+		return m.Gained
+	}
+	return 0
+}
+
+func (m *ExpChange) GetSource() ExpSource {
+	if m != nil {
+		return m.Source
+	}
+	return ExpSource_Exp_Source_Unknown
+}
+
+// 升级事件，广播给周围的玩家
+type LevelUp struct {
+	PlayerId             int32        `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Level                int32        `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Stats                *CombatStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *LevelUp) Reset()         { *m = LevelUp{} }
+func (m *LevelUp) String() string { return proto.CompactTextString(m) }
+func (*LevelUp) ProtoMessage()    {}
+func (*LevelUp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{49}
+}
+
+func (m *LevelUp) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LevelUp.Unmarshal(m, b)
+}
+func (m *LevelUp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LevelUp.Marshal(b, m, deterministic)
+}
+func (m *LevelUp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LevelUp.Merge(m, src)
+}
+func (m *LevelUp) XXX_Size() int {
+	return xxx_messageInfo_LevelUp.Size(m)
+}
+func (m *LevelUp) XXX_DiscardUnknown() {
+	xxx_messageInfo_LevelUp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LevelUp proto.InternalMessageInfo
+
+func (m *LevelUp) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *LevelUp) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *LevelUp) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// 背包格子，count为0表示格子为空
+type ItemSlot struct {
+	Slot                 int32    `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	ItemId               int32    `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Count                int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ItemSlot) Reset()         { *m = ItemSlot{} }
+func (m *ItemSlot) String() string { return proto.CompactTextString(m) }
+func (*ItemSlot) ProtoMessage()    {}
+func (*ItemSlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{50}
+}
+
+func (m *ItemSlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ItemSlot.Unmarshal(m, b)
+}
+func (m *ItemSlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ItemSlot.Marshal(b, m, deterministic)
+}
+func (m *ItemSlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ItemSlot.Merge(m, src)
+}
+func (m *ItemSlot) XXX_Size() int {
+	return xxx_messageInfo_ItemSlot.Size(m)
+}
+func (m *ItemSlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ItemSlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ItemSlot proto.InternalMessageInfo
+
+func (m *ItemSlot) GetSlot() int32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *ItemSlot) GetItemId() int32 {
+	if m != nil {
+		return m.ItemId
+	}
+	return 0
+}
+
+func (m *ItemSlot) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// 同步整个背包，进入世界时发送，只包含非空格子
+type SyncInventory struct {
+	Size                 int32       `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Slots                []*ItemSlot `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty"`
+	Gold                 int64       `protobuf:"varint,3,opt,name=gold,proto3" json:"gold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SyncInventory) Reset()         { *m = SyncInventory{} }
+func (m *SyncInventory) String() string { return proto.CompactTextString(m) }
+func (*SyncInventory) ProtoMessage()    {}
+func (*SyncInventory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{51}
+}
+
+func (m *SyncInventory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncInventory.Unmarshal(m, b)
+}
+func (m *SyncInventory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncInventory.Marshal(b, m, deterministic)
+}
+func (m *SyncInventory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncInventory.Merge(m, src)
+}
+func (m *SyncInventory) XXX_Size() int {
+	return xxx_messageInfo_SyncInventory.Size(m)
+}
+func (m *SyncInventory) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncInventory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncInventory proto.InternalMessageInfo
+
+func (m *SyncInventory) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *SyncInventory) GetSlots() []*ItemSlot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+func (m *SyncInventory) GetGold() int64 {
+	if m != nil {
+		return m.Gold
+	}
+	return 0
+}
+
+// 背包变化，只包含发生变化的格子，gold为变化后的金币数量
+type InventoryChange struct {
+	Slots                []*ItemSlot `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	Gold                 int64       `protobuf:"varint,2,opt,name=gold,proto3" json:"gold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *InventoryChange) Reset()         { *m = InventoryChange{} }
+func (m *InventoryChange) String() string { return proto.CompactTextString(m) }
+func (*InventoryChange) ProtoMessage()    {}
+func (*InventoryChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{52}
+}
+
+func (m *InventoryChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InventoryChange.Unmarshal(m, b)
+}
+func (m *InventoryChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InventoryChange.Marshal(b, m, deterministic)
+}
+func (m *InventoryChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InventoryChange.Merge(m, src)
+}
+func (m *InventoryChange) XXX_Size() int {
+	return xxx_messageInfo_InventoryChange.Size(m)
+}
+func (m *InventoryChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_InventoryChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InventoryChange proto.InternalMessageInfo
+
+func (m *InventoryChange) GetSlots() []*ItemSlot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+func (m *InventoryChange) GetGold() int64 {
+	if m != nil {
+		return m.Gold
+	}
+	return 0
+}
+
+// 移动物品，目标格子有相同物品时合并，否则交换
+type MoveItem struct {
+	From                 int32    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int32    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveItem) Reset()         { *m = MoveItem{} }
+func (m *MoveItem) String() string { return proto.CompactTextString(m) }
+func (*MoveItem) ProtoMessage()    {}
+func (*MoveItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{53}
+}
+
+func (m *MoveItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveItem.Unmarshal(m, b)
+}
+func (m *MoveItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveItem.Marshal(b, m, deterministic)
+}
+func (m *MoveItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveItem.Merge(m, src)
+}
+func (m *MoveItem) XXX_Size() int {
+	return xxx_messageInfo_MoveItem.Size(m)
+}
+func (m *MoveItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveItem proto.InternalMessageInfo
+
+func (m *MoveItem) GetFrom() int32 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *MoveItem) GetTo() int32 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+// 拆分物品到空格子
+type SplitItem struct {
+	From                 int32    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int32    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Count                int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SplitItem) Reset()         { *m = SplitItem{} }
+func (m *SplitItem) String() string { return proto.CompactTextString(m) }
+func (*SplitItem) ProtoMessage()    {}
+func (*SplitItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{54}
+}
+
+func (m *SplitItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SplitItem.Unmarshal(m, b)
+}
+func (m *SplitItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SplitItem.Marshal(b, m, deterministic)
+}
+func (m *SplitItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SplitItem.Merge(m, src)
+}
+func (m *SplitItem) XXX_Size() int {
+	return xxx_messageInfo_SplitItem.Size(m)
+}
+func (m *SplitItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_SplitItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SplitItem proto.InternalMessageInfo
+
+func (m *SplitItem) GetFrom() int32 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *SplitItem) GetTo() int32 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *SplitItem) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// 使用物品
+type UseItem struct {
+	Slot                 int32    `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UseItem) Reset()         { *m = UseItem{} }
+func (m *UseItem) String() string { return proto.CompactTextString(m) }
+func (*UseItem) ProtoMessage()    {}
+func (*UseItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{55}
+}
+
+func (m *UseItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseItem.Unmarshal(m, b)
+}
+func (m *UseItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseItem.Marshal(b, m, deterministic)
+}
+func (m *UseItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseItem.Merge(m, src)
+}
+func (m *UseItem) XXX_Size() int {
+	return xxx_messageInfo_UseItem.Size(m)
+}
+func (m *UseItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseItem proto.InternalMessageInfo
+
+func (m *UseItem) GetSlot() int32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+// 背包操作结果，只在失败时返回
+type InventoryResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Slot                 int32      `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *InventoryResult) Reset()         { *m = InventoryResult{} }
+func (m *InventoryResult) String() string { return proto.CompactTextString(m) }
+func (*InventoryResult) ProtoMessage()    {}
+func (*InventoryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{56}
+}
+
+func (m *InventoryResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InventoryResult.Unmarshal(m, b)
+}
+func (m *InventoryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InventoryResult.Marshal(b, m, deterministic)
+}
+func (m *InventoryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InventoryResult.Merge(m, src)
+}
+func (m *InventoryResult) XXX_Size() int {
+	return xxx_messageInfo_InventoryResult.Size(m)
+}
+func (m *InventoryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_InventoryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InventoryResult proto.InternalMessageInfo
+
+func (m *InventoryResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *InventoryResult) GetSlot() int32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+// 实体战斗属性变化，广播给周围的玩家
+type EntityStats struct {
+	EntityId             int32        `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Stats                *CombatStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *EntityStats) Reset()         { *m = EntityStats{} }
+func (m *EntityStats) String() string { return proto.CompactTextString(m) }
+func (*EntityStats) ProtoMessage()    {}
+func (*EntityStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{57}
+}
+
+func (m *EntityStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntityStats.Unmarshal(m, b)
+}
+func (m *EntityStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntityStats.Marshal(b, m, deterministic)
+}
+func (m *EntityStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntityStats.Merge(m, src)
+}
+func (m *EntityStats) XXX_Size() int {
+	return xxx_messageInfo_EntityStats.Size(m)
+}
+func (m *EntityStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntityStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntityStats proto.InternalMessageInfo
+
+func (m *EntityStats) GetEntityId() int32 {
+	if m != nil {
+		return m.EntityId
+	}
+	return 0
+}
+
+func (m *EntityStats) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+// 穿戴背包中的装备，部位上已有的装备放回背包
+type EquipItem struct {
+	Slot                 int32    `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EquipItem) Reset()         { *m = EquipItem{} }
+func (m *EquipItem) String() string { return proto.CompactTextString(m) }
+func (*EquipItem) ProtoMessage()    {}
+func (*EquipItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{58}
+}
+
+func (m *EquipItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EquipItem.Unmarshal(m, b)
+}
+func (m *EquipItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EquipItem.Marshal(b, m, deterministic)
+}
+func (m *EquipItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EquipItem.Merge(m, src)
+}
+func (m *EquipItem) XXX_Size() int {
+	return xxx_messageInfo_EquipItem.Size(m)
+}
+func (m *EquipItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_EquipItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EquipItem proto.InternalMessageInfo
+
+func (m *EquipItem) GetSlot() int32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+// 卸下装备放回背包
+type UnequipItem struct {
+	EquipSlot            EquipSlot `protobuf:"varint,1,opt,name=equip_slot,json=equipSlot,proto3,enum=mmopb.EquipSlot" json:"equip_slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *UnequipItem) Reset()         { *m = UnequipItem{} }
+func (m *UnequipItem) String() string { return proto.CompactTextString(m) }
+func (*UnequipItem) ProtoMessage()    {}
+func (*UnequipItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{59}
+}
+
+func (m *UnequipItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnequipItem.Unmarshal(m, b)
+}
+func (m *UnequipItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnequipItem.Marshal(b, m, deterministic)
+}
+func (m *UnequipItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnequipItem.Merge(m, src)
+}
+func (m *UnequipItem) XXX_Size() int {
+	return xxx_messageInfo_UnequipItem.Size(m)
+}
+func (m *UnequipItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnequipItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnequipItem proto.InternalMessageInfo
+
+func (m *UnequipItem) GetEquipSlot() EquipSlot {
+	if m != nil {
+		return m.EquipSlot
+	}
+	return EquipSlot_Equip_Slot_None
+}
+
+// 装备操作结果，只在失败时返回
+type EquipResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	Slot                 int32      `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	EquipSlot            EquipSlot  `protobuf:"varint,3,opt,name=equip_slot,json=equipSlot,proto3,enum=mmopb.EquipSlot" json:"equip_slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *EquipResult) Reset()         { *m = EquipResult{} }
+func (m *EquipResult) String() string { return proto.CompactTextString(m) }
+func (*EquipResult) ProtoMessage()    {}
+func (*EquipResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{60}
+}
+
+func (m *EquipResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EquipResult.Unmarshal(m, b)
+}
+func (m *EquipResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EquipResult.Marshal(b, m, deterministic)
+}
+func (m *EquipResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EquipResult.Merge(m, src)
+}
+func (m *EquipResult) XXX_Size() int {
+	return xxx_messageInfo_EquipResult.Size(m)
+}
+func (m *EquipResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_EquipResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EquipResult proto.InternalMessageInfo
+
+func (m *EquipResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *EquipResult) GetSlot() int32 {
+	if m != nil {
+		return m.Slot
+	}
+	return 0
+}
+
+func (m *EquipResult) GetEquipSlot() EquipSlot {
+	if m != nil {
+		return m.EquipSlot
+	}
+	return EquipSlot_Equip_Slot_None
+}
+
+// 地面掉落物，进入视野时发送
+type GroundLoot struct {
+	LootId               int32     `protobuf:"varint,1,opt,name=loot_id,json=lootId,proto3" json:"loot_id,omitempty"`
+	ItemId               int32     `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Count                int32     `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Pos                  *Position `protobuf:"bytes,4,opt,name=pos,proto3" json:"pos,omitempty"`
+	OwnerIds             []int32   `protobuf:"varint,5,rep,packed,name=owner_ids,json=ownerIds,proto3" json:"owner_ids,omitempty"`
+	OwnerRemainMs        int32     `protobuf:"varint,6,opt,name=owner_remain_ms,json=ownerRemainMs,proto3" json:"owner_remain_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *GroundLoot) Reset()         { *m = GroundLoot{} }
+func (m *GroundLoot) String() string { return proto.CompactTextString(m) }
+func (*GroundLoot) ProtoMessage()    {}
+func (*GroundLoot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{61}
+}
+
+func (m *GroundLoot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroundLoot.Unmarshal(m, b)
+}
+func (m *GroundLoot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroundLoot.Marshal(b, m, deterministic)
+}
+func (m *GroundLoot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroundLoot.Merge(m, src)
+}
+func (m *GroundLoot) XXX_Size() int {
+	return xxx_messageInfo_GroundLoot.Size(m)
+}
+func (m *GroundLoot) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroundLoot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroundLoot proto.InternalMessageInfo
+
+func (m *GroundLoot) GetLootId() int32 {
+	if m != nil {
+		return m.LootId
+	}
+	return 0
+}
+
+func (m *GroundLoot) GetItemId() int32 {
+	if m != nil {
+		return m.ItemId
+	}
+	return 0
+}
+
+func (m *GroundLoot) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GroundLoot) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+func (m *GroundLoot) GetOwnerIds() []int32 {
+	if m != nil {
+		return m.OwnerIds
+	}
+	return nil
+}
+
+func (m *GroundLoot) GetOwnerRemainMs() int32 {
+	if m != nil {
+		return m.OwnerRemainMs
+	}
+	return 0
+}
+
+// 掉落物消失，被拾取时picker_id为拾取者，超时消失时为0
+type LootRemove struct {
+	LootId               int32    `protobuf:"varint,1,opt,name=loot_id,json=lootId,proto3" json:"loot_id,omitempty"`
+	PickerId             int32    `protobuf:"varint,2,opt,name=picker_id,json=pickerId,proto3" json:"picker_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LootRemove) Reset()         { *m = LootRemove{} }
+func (m *LootRemove) String() string { return proto.CompactTextString(m) }
+func (*LootRemove) ProtoMessage()    {}
+func (*LootRemove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{62}
+}
+
+func (m *LootRemove) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LootRemove.Unmarshal(m, b)
+}
+func (m *LootRemove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LootRemove.Marshal(b, m, deterministic)
+}
+func (m *LootRemove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LootRemove.Merge(m, src)
+}
+func (m *LootRemove) XXX_Size() int {
+	return xxx_messageInfo_LootRemove.Size(m)
+}
+func (m *LootRemove) XXX_DiscardUnknown() {
+	xxx_messageInfo_LootRemove.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LootRemove proto.InternalMessageInfo
+
+func (m *LootRemove) GetLootId() int32 {
+	if m != nil {
+		return m.LootId
+	}
+	return 0
+}
+
+func (m *LootRemove) GetPickerId() int32 {
+	if m != nil {
+		return m.PickerId
+	}
+	return 0
+}
+
+// 拾取掉落物
+type PickupLoot struct {
+	LootId               int32    `protobuf:"varint,1,opt,name=loot_id,json=lootId,proto3" json:"loot_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PickupLoot) Reset()         { *m = PickupLoot{} }
+func (m *PickupLoot) String() string { return proto.CompactTextString(m) }
+func (*PickupLoot) ProtoMessage()    {}
+func (*PickupLoot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{63}
+}
+
+func (m *PickupLoot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupLoot.Unmarshal(m, b)
+}
+func (m *PickupLoot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupLoot.Marshal(b, m, deterministic)
+}
+func (m *PickupLoot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupLoot.Merge(m, src)
+}
+func (m *PickupLoot) XXX_Size() int {
+	return xxx_messageInfo_PickupLoot.Size(m)
+}
+func (m *PickupLoot) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupLoot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupLoot proto.InternalMessageInfo
+
+func (m *PickupLoot) GetLootId() int32 {
+	if m != nil {
+		return m.LootId
+	}
+	return 0
+}
+
+// 拾取结果，只在失败时返回
+type PickupResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	LootId               int32      `protobuf:"varint,2,opt,name=loot_id,json=lootId,proto3" json:"loot_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PickupResult) Reset()         { *m = PickupResult{} }
+func (m *PickupResult) String() string { return proto.CompactTextString(m) }
+func (*PickupResult) ProtoMessage()    {}
+func (*PickupResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{64}
+}
+
+func (m *PickupResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupResult.Unmarshal(m, b)
+}
+func (m *PickupResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupResult.Marshal(b, m, deterministic)
+}
+func (m *PickupResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupResult.Merge(m, src)
+}
+func (m *PickupResult) XXX_Size() int {
+	return xxx_messageInfo_PickupResult.Size(m)
+}
+func (m *PickupResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupResult proto.InternalMessageInfo
+
+func (m *PickupResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *PickupResult) GetLootId() int32 {
+	if m != nil {
+		return m.LootId
+	}
+	return 0
+}
+
+// 物品和数量
+type ItemCount struct {
+	ItemId               int32    `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ItemCount) Reset()         { *m = ItemCount{} }
+func (m *ItemCount) String() string { return proto.CompactTextString(m) }
+func (*ItemCount) ProtoMessage()    {}
+func (*ItemCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{65}
+}
+
+func (m *ItemCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ItemCount.Unmarshal(m, b)
+}
+func (m *ItemCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ItemCount.Marshal(b, m, deterministic)
+}
+func (m *ItemCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ItemCount.Merge(m, src)
+}
+func (m *ItemCount) XXX_Size() int {
+	return xxx_messageInfo_ItemCount.Size(m)
+}
+func (m *ItemCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ItemCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ItemCount proto.InternalMessageInfo
+
+func (m *ItemCount) GetItemId() int32 {
+	if m != nil {
+		return m.ItemId
+	}
+	return 0
+}
+
+func (m *ItemCount) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// 请求和目标玩家交易
+type TradeRequest struct {
+	TargetId             int32    `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeRequest) Reset()         { *m = TradeRequest{} }
+func (m *TradeRequest) String() string { return proto.CompactTextString(m) }
+func (*TradeRequest) ProtoMessage()    {}
+func (*TradeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{66}
+}
+
+func (m *TradeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeRequest.Unmarshal(m, b)
+}
+func (m *TradeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeRequest.Marshal(b, m, deterministic)
+}
+func (m *TradeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeRequest.Merge(m, src)
+}
+func (m *TradeRequest) XXX_Size() int {
+	return xxx_messageInfo_TradeRequest.Size(m)
+}
+func (m *TradeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeRequest proto.InternalMessageInfo
+
+func (m *TradeRequest) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 收到交易邀请
+type TradeInvite struct {
+	InviterId            int32    `protobuf:"varint,1,opt,name=inviter_id,json=inviterId,proto3" json:"inviter_id,omitempty"`
+	InviterName          string   `protobuf:"bytes,2,opt,name=inviter_name,json=inviterName,proto3" json:"inviter_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeInvite) Reset()         { *m = TradeInvite{} }
+func (m *TradeInvite) String() string { return proto.CompactTextString(m) }
+func (*TradeInvite) ProtoMessage()    {}
+func (*TradeInvite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{67}
+}
+
+func (m *TradeInvite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeInvite.Unmarshal(m, b)
+}
+func (m *TradeInvite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeInvite.Marshal(b, m, deterministic)
+}
+func (m *TradeInvite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeInvite.Merge(m, src)
+}
+func (m *TradeInvite) XXX_Size() int {
+	return xxx_messageInfo_TradeInvite.Size(m)
+}
+func (m *TradeInvite) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeInvite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeInvite proto.InternalMessageInfo
+
+func (m *TradeInvite) GetInviterId() int32 {
+	if m != nil {
+		return m.InviterId
+	}
+	return 0
+}
+
+func (m *TradeInvite) GetInviterName() string {
+	if m != nil {
+		return m.InviterName
+	}
+	return ""
+}
+
+// 回应交易邀请
+type TradeRespond struct {
+	InviterId            int32    `protobuf:"varint,1,opt,name=inviter_id,json=inviterId,proto3" json:"inviter_id,omitempty"`
+	Accept               bool     `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeRespond) Reset()         { *m = TradeRespond{} }
+func (m *TradeRespond) String() string { return proto.CompactTextString(m) }
+func (*TradeRespond) ProtoMessage()    {}
+func (*TradeRespond) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{68}
+}
+
+func (m *TradeRespond) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeRespond.Unmarshal(m, b)
+}
+func (m *TradeRespond) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeRespond.Marshal(b, m, deterministic)
+}
+func (m *TradeRespond) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeRespond.Merge(m, src)
+}
+func (m *TradeRespond) XXX_Size() int {
+	return xxx_messageInfo_TradeRespond.Size(m)
+}
+func (m *TradeRespond) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeRespond.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeRespond proto.InternalMessageInfo
+
+func (m *TradeRespond) GetInviterId() int32 {
+	if m != nil {
+		return m.InviterId
+	}
+	return 0
+}
+
+func (m *TradeRespond) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+// 交易开始
+type TradeOpen struct {
+	TradeId              int32    `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	PartnerId            int32    `protobuf:"varint,2,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeOpen) Reset()         { *m = TradeOpen{} }
+func (m *TradeOpen) String() string { return proto.CompactTextString(m) }
+func (*TradeOpen) ProtoMessage()    {}
+func (*TradeOpen) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{69}
+}
+
+func (m *TradeOpen) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeOpen.Unmarshal(m, b)
+}
+func (m *TradeOpen) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeOpen.Marshal(b, m, deterministic)
+}
+func (m *TradeOpen) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeOpen.Merge(m, src)
+}
+func (m *TradeOpen) XXX_Size() int {
+	return xxx_messageInfo_TradeOpen.Size(m)
+}
+func (m *TradeOpen) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeOpen.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeOpen proto.InternalMessageInfo
+
+func (m *TradeOpen) GetTradeId() int32 {
+	if m != nil {
+		return m.TradeId
+	}
+	return 0
+}
+
+func (m *TradeOpen) GetPartnerId() int32 {
+	if m != nil {
+		return m.PartnerId
+	}
+	return 0
+}
+
+// 设置自己的交易内容，覆盖之前的内容，锁定后修改会取消交易
+type TradeOffer struct {
+	Items                []*ItemCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Gold                 int64        `protobuf:"varint,2,opt,name=gold,proto3" json:"gold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TradeOffer) Reset()         { *m = TradeOffer{} }
+func (m *TradeOffer) String() string { return proto.CompactTextString(m) }
+func (*TradeOffer) ProtoMessage()    {}
+func (*TradeOffer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{70}
+}
+
+func (m *TradeOffer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeOffer.Unmarshal(m, b)
+}
+func (m *TradeOffer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeOffer.Marshal(b, m, deterministic)
+}
+func (m *TradeOffer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeOffer.Merge(m, src)
+}
+func (m *TradeOffer) XXX_Size() int {
+	return xxx_messageInfo_TradeOffer.Size(m)
+}
+func (m *TradeOffer) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeOffer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeOffer proto.InternalMessageInfo
+
+func (m *TradeOffer) GetItems() []*ItemCount {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *TradeOffer) GetGold() int64 {
+	if m != nil {
+		return m.Gold
+	}
+	return 0
+}
+
+// 锁定交易内容
+type TradeLock struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeLock) Reset()         { *m = TradeLock{} }
+func (m *TradeLock) String() string { return proto.CompactTextString(m) }
+func (*TradeLock) ProtoMessage()    {}
+func (*TradeLock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{71}
+}
+
+func (m *TradeLock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeLock.Unmarshal(m, b)
+}
+func (m *TradeLock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeLock.Marshal(b, m, deterministic)
+}
+func (m *TradeLock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeLock.Merge(m, src)
+}
+func (m *TradeLock) XXX_Size() int {
+	return xxx_messageInfo_TradeLock.Size(m)
+}
+func (m *TradeLock) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeLock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeLock proto.InternalMessageInfo
+
+// 确认交易，双方都锁定后才能确认
+type TradeConfirm struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeConfirm) Reset()         { *m = TradeConfirm{} }
+func (m *TradeConfirm) String() string { return proto.CompactTextString(m) }
+func (*TradeConfirm) ProtoMessage()    {}
+func (*TradeConfirm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{72}
+}
+
+func (m *TradeConfirm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeConfirm.Unmarshal(m, b)
+}
+func (m *TradeConfirm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeConfirm.Marshal(b, m, deterministic)
+}
+func (m *TradeConfirm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeConfirm.Merge(m, src)
+}
+func (m *TradeConfirm) XXX_Size() int {
+	return xxx_messageInfo_TradeConfirm.Size(m)
+}
+func (m *TradeConfirm) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeConfirm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeConfirm proto.InternalMessageInfo
+
+// 取消交易
+type TradeCancel struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TradeCancel) Reset()         { *m = TradeCancel{} }
+func (m *TradeCancel) String() string { return proto.CompactTextString(m) }
+func (*TradeCancel) ProtoMessage()    {}
+func (*TradeCancel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{73}
+}
+
+func (m *TradeCancel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeCancel.Unmarshal(m, b)
+}
+func (m *TradeCancel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeCancel.Marshal(b, m, deterministic)
+}
+func (m *TradeCancel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeCancel.Merge(m, src)
+}
+func (m *TradeCancel) XXX_Size() int {
+	return xxx_messageInfo_TradeCancel.Size(m)
+}
+func (m *TradeCancel) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeCancel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeCancel proto.InternalMessageInfo
+
+// 一方的交易内容
+type TradeSideInfo struct {
+	PlayerId             int32        `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Items                []*ItemCount `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Gold                 int64        `protobuf:"varint,3,opt,name=gold,proto3" json:"gold,omitempty"`
+	Locked               bool         `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`
+	Confirmed            bool         `protobuf:"varint,5,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TradeSideInfo) Reset()         { *m = TradeSideInfo{} }
+func (m *TradeSideInfo) String() string { return proto.CompactTextString(m) }
+func (*TradeSideInfo) ProtoMessage()    {}
+func (*TradeSideInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{74}
+}
+
+func (m *TradeSideInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeSideInfo.Unmarshal(m, b)
+}
+func (m *TradeSideInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeSideInfo.Marshal(b, m, deterministic)
+}
+func (m *TradeSideInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeSideInfo.Merge(m, src)
+}
+func (m *TradeSideInfo) XXX_Size() int {
+	return xxx_messageInfo_TradeSideInfo.Size(m)
+}
+func (m *TradeSideInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeSideInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeSideInfo proto.InternalMessageInfo
+
+func (m *TradeSideInfo) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *TradeSideInfo) GetItems() []*ItemCount {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *TradeSideInfo) GetGold() int64 {
+	if m != nil {
+		return m.Gold
+	}
+	return 0
+}
+
+func (m *TradeSideInfo) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
+func (m *TradeSideInfo) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+// 交易状态变化时发给双方
+type TradeUpdate struct {
+	TradeId              int32            `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Sides                []*TradeSideInfo `protobuf:"bytes,2,rep,name=sides,proto3" json:"sides,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *TradeUpdate) Reset()         { *m = TradeUpdate{} }
+func (m *TradeUpdate) String() string { return proto.CompactTextString(m) }
+func (*TradeUpdate) ProtoMessage()    {}
+func (*TradeUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{75}
+}
+
+func (m *TradeUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeUpdate.Unmarshal(m, b)
+}
+func (m *TradeUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeUpdate.Marshal(b, m, deterministic)
+}
+func (m *TradeUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeUpdate.Merge(m, src)
+}
+func (m *TradeUpdate) XXX_Size() int {
+	return xxx_messageInfo_TradeUpdate.Size(m)
+}
+func (m *TradeUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeUpdate proto.InternalMessageInfo
+
+func (m *TradeUpdate) GetTradeId() int32 {
+	if m != nil {
+		return m.TradeId
+	}
+	return 0
+}
+
+func (m *TradeUpdate) GetSides() []*TradeSideInfo {
+	if m != nil {
+		return m.Sides
+	}
+	return nil
+}
+
+// 交易结束
+type TradeClosed struct {
+	TradeId              int32            `protobuf:"varint,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	Reason               TradeCloseReason `protobuf:"varint,2,opt,name=reason,proto3,enum=mmopb.TradeCloseReason" json:"reason,omitempty"`
+	PlayerId             int32            `protobuf:"varint,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *TradeClosed) Reset()         { *m = TradeClosed{} }
+func (m *TradeClosed) String() string { return proto.CompactTextString(m) }
+func (*TradeClosed) ProtoMessage()    {}
+func (*TradeClosed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{76}
+}
+
+func (m *TradeClosed) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeClosed.Unmarshal(m, b)
+}
+func (m *TradeClosed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeClosed.Marshal(b, m, deterministic)
+}
+func (m *TradeClosed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeClosed.Merge(m, src)
+}
+func (m *TradeClosed) XXX_Size() int {
+	return xxx_messageInfo_TradeClosed.Size(m)
+}
+func (m *TradeClosed) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeClosed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeClosed proto.InternalMessageInfo
+
+func (m *TradeClosed) GetTradeId() int32 {
+	if m != nil {
+		return m.TradeId
+	}
+	return 0
+}
+
+func (m *TradeClosed) GetReason() TradeCloseReason {
+	if m != nil {
+		return m.Reason
+	}
+	return TradeCloseReason_Trade_Done
+}
+
+func (m *TradeClosed) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// 交易操作结果，只在失败时返回
+type TradeResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TradeResult) Reset()         { *m = TradeResult{} }
+func (m *TradeResult) String() string { return proto.CompactTextString(m) }
+func (*TradeResult) ProtoMessage()    {}
+func (*TradeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{77}
+}
+
+func (m *TradeResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TradeResult.Unmarshal(m, b)
+}
+func (m *TradeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TradeResult.Marshal(b, m, deterministic)
+}
+func (m *TradeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TradeResult.Merge(m, src)
+}
+func (m *TradeResult) XXX_Size() int {
+	return xxx_messageInfo_TradeResult.Size(m)
+}
+func (m *TradeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_TradeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TradeResult proto.InternalMessageInfo
+
+func (m *TradeResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+// 任务进度，progress与任务目标一一对应
+type QuestInfo struct {
+	QuestId              int32      `protobuf:"varint,1,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	Progress             []int32    `protobuf:"varint,2,rep,packed,name=progress,proto3" json:"progress,omitempty"`
+	State                QuestState `protobuf:"varint,3,opt,name=state,proto3,enum=mmopb.QuestState" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *QuestInfo) Reset()         { *m = QuestInfo{} }
+func (m *QuestInfo) String() string { return proto.CompactTextString(m) }
+func (*QuestInfo) ProtoMessage()    {}
+func (*QuestInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{78}
+}
+
+func (m *QuestInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuestInfo.Unmarshal(m, b)
+}
+func (m *QuestInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuestInfo.Marshal(b, m, deterministic)
+}
+func (m *QuestInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuestInfo.Merge(m, src)
+}
+func (m *QuestInfo) XXX_Size() int {
+	return xxx_messageInfo_QuestInfo.Size(m)
+}
+func (m *QuestInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuestInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuestInfo proto.InternalMessageInfo
+
+func (m *QuestInfo) GetQuestId() int32 {
+	if m != nil {
+		return m.QuestId
+	}
+	return 0
+}
+
+func (m *QuestInfo) GetProgress() []int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (m *QuestInfo) GetState() QuestState {
+	if m != nil {
+		return m.State
+	}
+	return QuestState_Quest_State_Active
+}
+
+// 进入世界时同步全部任务
+type SyncQuests struct {
+	Quests               []*QuestInfo `protobuf:"bytes,1,rep,name=quests,proto3" json:"quests,omitempty"`
+	DoneIds              []int32      `protobuf:"varint,2,rep,packed,name=done_ids,json=doneIds,proto3" json:"done_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SyncQuests) Reset()         { *m = SyncQuests{} }
+func (m *SyncQuests) String() string { return proto.CompactTextString(m) }
+func (*SyncQuests) ProtoMessage()    {}
+func (*SyncQuests) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{79}
+}
+
+func (m *SyncQuests) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncQuests.Unmarshal(m, b)
+}
+func (m *SyncQuests) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncQuests.Marshal(b, m, deterministic)
+}
+func (m *SyncQuests) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncQuests.Merge(m, src)
+}
+func (m *SyncQuests) XXX_Size() int {
+	return xxx_messageInfo_SyncQuests.Size(m)
+}
+func (m *SyncQuests) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncQuests.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncQuests proto.InternalMessageInfo
+
+func (m *SyncQuests) GetQuests() []*QuestInfo {
+	if m != nil {
+		return m.Quests
+	}
+	return nil
+}
+
+func (m *SyncQuests) GetDoneIds() []int32 {
+	if m != nil {
+		return m.DoneIds
+	}
+	return nil
+}
+
+// 任务接取、进度变化、交付或放弃
+type QuestUpdate struct {
+	Quest                *QuestInfo `protobuf:"bytes,1,opt,name=quest,proto3" json:"quest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *QuestUpdate) Reset()         { *m = QuestUpdate{} }
+func (m *QuestUpdate) String() string { return proto.CompactTextString(m) }
+func (*QuestUpdate) ProtoMessage()    {}
+func (*QuestUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{80}
+}
+
+func (m *QuestUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuestUpdate.Unmarshal(m, b)
+}
+func (m *QuestUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuestUpdate.Marshal(b, m, deterministic)
+}
+func (m *QuestUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuestUpdate.Merge(m, src)
+}
+func (m *QuestUpdate) XXX_Size() int {
+	return xxx_messageInfo_QuestUpdate.Size(m)
+}
+func (m *QuestUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuestUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuestUpdate proto.InternalMessageInfo
+
+func (m *QuestUpdate) GetQuest() *QuestInfo {
+	if m != nil {
+		return m.Quest
+	}
+	return nil
+}
+
+// 接取任务
+type AcceptQuest struct {
+	QuestId              int32    `protobuf:"varint,1,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptQuest) Reset()         { *m = AcceptQuest{} }
+func (m *AcceptQuest) String() string { return proto.CompactTextString(m) }
+func (*AcceptQuest) ProtoMessage()    {}
+func (*AcceptQuest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{81}
+}
+
+func (m *AcceptQuest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptQuest.Unmarshal(m, b)
+}
+func (m *AcceptQuest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptQuest.Marshal(b, m, deterministic)
+}
+func (m *AcceptQuest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptQuest.Merge(m, src)
+}
+func (m *AcceptQuest) XXX_Size() int {
+	return xxx_messageInfo_AcceptQuest.Size(m)
+}
+func (m *AcceptQuest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptQuest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptQuest proto.InternalMessageInfo
+
+func (m *AcceptQuest) GetQuestId() int32 {
+	if m != nil {
+		return m.QuestId
+	}
+	return 0
+}
+
+// 放弃任务
+type AbandonQuest struct {
+	QuestId              int32    `protobuf:"varint,1,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AbandonQuest) Reset()         { *m = AbandonQuest{} }
+func (m *AbandonQuest) String() string { return proto.CompactTextString(m) }
+func (*AbandonQuest) ProtoMessage()    {}
+func (*AbandonQuest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{82}
+}
+
+func (m *AbandonQuest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AbandonQuest.Unmarshal(m, b)
+}
+func (m *AbandonQuest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AbandonQuest.Marshal(b, m, deterministic)
+}
+func (m *AbandonQuest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AbandonQuest.Merge(m, src)
+}
+func (m *AbandonQuest) XXX_Size() int {
+	return xxx_messageInfo_AbandonQuest.Size(m)
+}
+func (m *AbandonQuest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AbandonQuest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AbandonQuest proto.InternalMessageInfo
+
+func (m *AbandonQuest) GetQuestId() int32 {
+	if m != nil {
+		return m.QuestId
+	}
+	return 0
+}
+
+// 交付任务
+type TurnInQuest struct {
+	QuestId              int32    `protobuf:"varint,1,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TurnInQuest) Reset()         { *m = TurnInQuest{} }
+func (m *TurnInQuest) String() string { return proto.CompactTextString(m) }
+func (*TurnInQuest) ProtoMessage()    {}
+func (*TurnInQuest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{83}
+}
+
+func (m *TurnInQuest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TurnInQuest.Unmarshal(m, b)
+}
+func (m *TurnInQuest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TurnInQuest.Marshal(b, m, deterministic)
+}
+func (m *TurnInQuest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TurnInQuest.Merge(m, src)
+}
+func (m *TurnInQuest) XXX_Size() int {
+	return xxx_messageInfo_TurnInQuest.Size(m)
+}
+func (m *TurnInQuest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TurnInQuest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TurnInQuest proto.InternalMessageInfo
+
+func (m *TurnInQuest) GetQuestId() int32 {
+	if m != nil {
+		return m.QuestId
+	}
+	return 0
+}
+
+// 和NPC对话
+type TalkNpc struct {
+	NpcId                int32    `protobuf:"varint,1,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TalkNpc) Reset()         { *m = TalkNpc{} }
+func (m *TalkNpc) String() string { return proto.CompactTextString(m) }
+func (*TalkNpc) ProtoMessage()    {}
+func (*TalkNpc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{84}
+}
+
+func (m *TalkNpc) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TalkNpc.Unmarshal(m, b)
+}
+func (m *TalkNpc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TalkNpc.Marshal(b, m, deterministic)
+}
+func (m *TalkNpc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TalkNpc.Merge(m, src)
+}
+func (m *TalkNpc) XXX_Size() int {
+	return xxx_messageInfo_TalkNpc.Size(m)
+}
+func (m *TalkNpc) XXX_DiscardUnknown() {
+	xxx_messageInfo_TalkNpc.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TalkNpc proto.InternalMessageInfo
+
+func (m *TalkNpc) GetNpcId() int32 {
+	if m != nil {
+		return m.NpcId
+	}
+	return 0
+}
+
+// 任务操作结果，只在失败时返回
+type QuestResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	QuestId              int32      `protobuf:"varint,2,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	NpcId                int32      `protobuf:"varint,3,opt,name=npc_id,json=npcId,proto3" json:"npc_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *QuestResult) Reset()         { *m = QuestResult{} }
+func (m *QuestResult) String() string { return proto.CompactTextString(m) }
+func (*QuestResult) ProtoMessage()    {}
+func (*QuestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{85}
+}
+
+func (m *QuestResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuestResult.Unmarshal(m, b)
+}
+func (m *QuestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuestResult.Marshal(b, m, deterministic)
+}
+func (m *QuestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuestResult.Merge(m, src)
+}
+func (m *QuestResult) XXX_Size() int {
+	return xxx_messageInfo_QuestResult.Size(m)
+}
+func (m *QuestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuestResult proto.InternalMessageInfo
+
+func (m *QuestResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *QuestResult) GetQuestId() int32 {
+	if m != nil {
+		return m.QuestId
+	}
+	return 0
+}
+
+func (m *QuestResult) GetNpcId() int32 {
+	if m != nil {
+		return m.NpcId
+	}
+	return 0
+}
+
+// 进入或离开场景中的触发区域，只发给自己
+type ZoneEvent struct {
+	ZoneId               int32    `protobuf:"varint,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ZoneType             string   `protobuf:"bytes,3,opt,name=zone_type,json=zoneType,proto3" json:"zone_type,omitempty"`
+	Enter                bool     `protobuf:"varint,4,opt,name=enter,proto3" json:"enter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ZoneEvent) Reset()         { *m = ZoneEvent{} }
+func (m *ZoneEvent) String() string { return proto.CompactTextString(m) }
+func (*ZoneEvent) ProtoMessage()    {}
+func (*ZoneEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{86}
+}
+
+func (m *ZoneEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ZoneEvent.Unmarshal(m, b)
+}
+func (m *ZoneEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ZoneEvent.Marshal(b, m, deterministic)
+}
+func (m *ZoneEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ZoneEvent.Merge(m, src)
+}
+func (m *ZoneEvent) XXX_Size() int {
+	return xxx_messageInfo_ZoneEvent.Size(m)
+}
+func (m *ZoneEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ZoneEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ZoneEvent proto.InternalMessageInfo
+
+func (m *ZoneEvent) GetZoneId() int32 {
+	if m != nil {
+		return m.ZoneId
+	}
+	return 0
+}
+
+func (m *ZoneEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ZoneEvent) GetZoneType() string {
+	if m != nil {
+		return m.ZoneType
+	}
+	return ""
+}
+
+func (m *ZoneEvent) GetEnter() bool {
+	if m != nil {
+		return m.Enter
+	}
+	return false
+}
+
+// 邀请玩家加入队伍，没有队伍时创建队伍，只有队长可以邀请
+type PartyInvite struct {
+	TargetId             int32    `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyInvite) Reset()         { *m = PartyInvite{} }
+func (m *PartyInvite) String() string { return proto.CompactTextString(m) }
+func (*PartyInvite) ProtoMessage()    {}
+func (*PartyInvite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{87}
+}
+
+func (m *PartyInvite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyInvite.Unmarshal(m, b)
+}
+func (m *PartyInvite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyInvite.Marshal(b, m, deterministic)
+}
+func (m *PartyInvite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyInvite.Merge(m, src)
+}
+func (m *PartyInvite) XXX_Size() int {
+	return xxx_messageInfo_PartyInvite.Size(m)
+}
+func (m *PartyInvite) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyInvite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyInvite proto.InternalMessageInfo
+
+func (m *PartyInvite) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 收到组队邀请
+type PartyInvitation struct {
+	InviterId            int32    `protobuf:"varint,1,opt,name=inviter_id,json=inviterId,proto3" json:"inviter_id,omitempty"`
+	InviterName          string   `protobuf:"bytes,2,opt,name=inviter_name,json=inviterName,proto3" json:"inviter_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyInvitation) Reset()         { *m = PartyInvitation{} }
+func (m *PartyInvitation) String() string { return proto.CompactTextString(m) }
+func (*PartyInvitation) ProtoMessage()    {}
+func (*PartyInvitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{88}
+}
+
+func (m *PartyInvitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyInvitation.Unmarshal(m, b)
+}
+func (m *PartyInvitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyInvitation.Marshal(b, m, deterministic)
+}
+func (m *PartyInvitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyInvitation.Merge(m, src)
+}
+func (m *PartyInvitation) XXX_Size() int {
+	return xxx_messageInfo_PartyInvitation.Size(m)
+}
+func (m *PartyInvitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyInvitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyInvitation proto.InternalMessageInfo
+
+func (m *PartyInvitation) GetInviterId() int32 {
+	if m != nil {
+		return m.InviterId
+	}
+	return 0
+}
+
+func (m *PartyInvitation) GetInviterName() string {
+	if m != nil {
+		return m.InviterName
+	}
+	return ""
+}
+
+// 回应组队邀请
+type PartyRespond struct {
+	InviterId            int32    `protobuf:"varint,1,opt,name=inviter_id,json=inviterId,proto3" json:"inviter_id,omitempty"`
+	Accept               bool     `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyRespond) Reset()         { *m = PartyRespond{} }
+func (m *PartyRespond) String() string { return proto.CompactTextString(m) }
+func (*PartyRespond) ProtoMessage()    {}
+func (*PartyRespond) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{89}
+}
+
+func (m *PartyRespond) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyRespond.Unmarshal(m, b)
+}
+func (m *PartyRespond) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyRespond.Marshal(b, m, deterministic)
+}
+func (m *PartyRespond) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyRespond.Merge(m, src)
+}
+func (m *PartyRespond) XXX_Size() int {
+	return xxx_messageInfo_PartyRespond.Size(m)
+}
+func (m *PartyRespond) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyRespond.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyRespond proto.InternalMessageInfo
+
+func (m *PartyRespond) GetInviterId() int32 {
+	if m != nil {
+		return m.InviterId
+	}
+	return 0
+}
+
+func (m *PartyRespond) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+// 离开队伍
+type PartyLeave struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyLeave) Reset()         { *m = PartyLeave{} }
+func (m *PartyLeave) String() string { return proto.CompactTextString(m) }
+func (*PartyLeave) ProtoMessage()    {}
+func (*PartyLeave) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{90}
+}
+
+func (m *PartyLeave) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyLeave.Unmarshal(m, b)
+}
+func (m *PartyLeave) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyLeave.Marshal(b, m, deterministic)
+}
+func (m *PartyLeave) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyLeave.Merge(m, src)
+}
+func (m *PartyLeave) XXX_Size() int {
+	return xxx_messageInfo_PartyLeave.Size(m)
+}
+func (m *PartyLeave) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyLeave.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyLeave proto.InternalMessageInfo
+
+// 队长把成员踢出队伍
+type PartyKick struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyKick) Reset()         { *m = PartyKick{} }
+func (m *PartyKick) String() string { return proto.CompactTextString(m) }
+func (*PartyKick) ProtoMessage()    {}
+func (*PartyKick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{91}
+}
+
+func (m *PartyKick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyKick.Unmarshal(m, b)
+}
+func (m *PartyKick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyKick.Marshal(b, m, deterministic)
+}
+func (m *PartyKick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyKick.Merge(m, src)
+}
+func (m *PartyKick) XXX_Size() int {
+	return xxx_messageInfo_PartyKick.Size(m)
+}
+func (m *PartyKick) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyKick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyKick proto.InternalMessageInfo
+
+func (m *PartyKick) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// 队长转让给其他在线成员
+type PartyTransfer struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartyTransfer) Reset()         { *m = PartyTransfer{} }
+func (m *PartyTransfer) String() string { return proto.CompactTextString(m) }
+func (*PartyTransfer) ProtoMessage()    {}
+func (*PartyTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{92}
+}
+
+func (m *PartyTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyTransfer.Unmarshal(m, b)
+}
+func (m *PartyTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyTransfer.Marshal(b, m, deterministic)
+}
+func (m *PartyTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyTransfer.Merge(m, src)
+}
+func (m *PartyTransfer) XXX_Size() int {
+	return xxx_messageInfo_PartyTransfer.Size(m)
+}
+func (m *PartyTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyTransfer proto.InternalMessageInfo
+
+func (m *PartyTransfer) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+// 队伍成员的状态，不在同一个视野内也会定时同步
+type PartyMemberState struct {
+	PlayerId             int32        `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Online               bool         `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	Level                int32        `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Stats                *CombatStats `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	Pos                  *Position    `protobuf:"bytes,5,opt,name=pos,proto3" json:"pos,omitempty"`
+	SceneId              int32        `protobuf:"varint,6,opt,name=scene_id,json=sceneId,proto3" json:"scene_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PartyMemberState) Reset()         { *m = PartyMemberState{} }
+func (m *PartyMemberState) String() string { return proto.CompactTextString(m) }
+func (*PartyMemberState) ProtoMessage()    {}
+func (*PartyMemberState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{93}
+}
+
+func (m *PartyMemberState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyMemberState.Unmarshal(m, b)
+}
+func (m *PartyMemberState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyMemberState.Marshal(b, m, deterministic)
+}
+func (m *PartyMemberState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyMemberState.Merge(m, src)
+}
+func (m *PartyMemberState) XXX_Size() int {
+	return xxx_messageInfo_PartyMemberState.Size(m)
+}
+func (m *PartyMemberState) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyMemberState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyMemberState proto.InternalMessageInfo
+
+func (m *PartyMemberState) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PartyMemberState) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *PartyMemberState) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *PartyMemberState) GetStats() *CombatStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *PartyMemberState) GetPos() *Position {
+	if m != nil {
+		return m.Pos
+	}
+	return nil
+}
+
+func (m *PartyMemberState) GetSceneId() int32 {
+	if m != nil {
+		return m.SceneId
+	}
+	return 0
+}
+
+// 队伍成员
+type PartyMemberInfo struct {
+	PlayerId             int32             `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Name                 string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Class                PlayerClass       `protobuf:"varint,3,opt,name=class,proto3,enum=mmopb.PlayerClass" json:"class,omitempty"`
+	State                *PartyMemberState `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PartyMemberInfo) Reset()         { *m = PartyMemberInfo{} }
+func (m *PartyMemberInfo) String() string { return proto.CompactTextString(m) }
+func (*PartyMemberInfo) ProtoMessage()    {}
+func (*PartyMemberInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{94}
+}
+
+func (m *PartyMemberInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyMemberInfo.Unmarshal(m, b)
+}
+func (m *PartyMemberInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyMemberInfo.Marshal(b, m, deterministic)
+}
+func (m *PartyMemberInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyMemberInfo.Merge(m, src)
+}
+func (m *PartyMemberInfo) XXX_Size() int {
+	return xxx_messageInfo_PartyMemberInfo.Size(m)
+}
+func (m *PartyMemberInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyMemberInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyMemberInfo proto.InternalMessageInfo
+
+func (m *PartyMemberInfo) GetPlayerId() int32 {
+	if m != nil {
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *PartyMemberInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PartyMemberInfo) GetClass() PlayerClass {
 	if m != nil {
-		return m.Content
+		return m.Class
+	}
+	return PlayerClass_Class_Unknown
+}
+
+func (m *PartyMemberInfo) GetState() *PartyMemberState {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+// 队伍信息，成员或队长变化时发给全部在线成员，party_id为0表示已经不在队伍中
+type PartyInfo struct {
+	PartyId              int32              `protobuf:"varint,1,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	LeaderId             int32              `protobuf:"varint,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	Members              []*PartyMemberInfo `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PartyInfo) Reset()         { *m = PartyInfo{} }
+func (m *PartyInfo) String() string { return proto.CompactTextString(m) }
+func (*PartyInfo) ProtoMessage()    {}
+func (*PartyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{95}
+}
+
+func (m *PartyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyInfo.Unmarshal(m, b)
+}
+func (m *PartyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyInfo.Marshal(b, m, deterministic)
+}
+func (m *PartyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyInfo.Merge(m, src)
+}
+func (m *PartyInfo) XXX_Size() int {
+	return xxx_messageInfo_PartyInfo.Size(m)
+}
+func (m *PartyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyInfo proto.InternalMessageInfo
+
+func (m *PartyInfo) GetPartyId() int32 {
+	if m != nil {
+		return m.PartyId
+	}
+	return 0
+}
+
+func (m *PartyInfo) GetLeaderId() int32 {
+	if m != nil {
+		return m.LeaderId
+	}
+	return 0
+}
+
+func (m *PartyInfo) GetMembers() []*PartyMemberInfo {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+// 定时同步的队伍成员状态
+type PartyStates struct {
+	States               []*PartyMemberState `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PartyStates) Reset()         { *m = PartyStates{} }
+func (m *PartyStates) String() string { return proto.CompactTextString(m) }
+func (*PartyStates) ProtoMessage()    {}
+func (*PartyStates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{96}
+}
+
+func (m *PartyStates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyStates.Unmarshal(m, b)
+}
+func (m *PartyStates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyStates.Marshal(b, m, deterministic)
+}
+func (m *PartyStates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyStates.Merge(m, src)
+}
+func (m *PartyStates) XXX_Size() int {
+	return xxx_messageInfo_PartyStates.Size(m)
+}
+func (m *PartyStates) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyStates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyStates proto.InternalMessageInfo
+
+func (m *PartyStates) GetStates() []*PartyMemberState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+// 队伍操作结果，只在失败或邀请被拒绝时返回
+type PartyResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	TargetId             int32      `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PartyResult) Reset()         { *m = PartyResult{} }
+func (m *PartyResult) String() string { return proto.CompactTextString(m) }
+func (*PartyResult) ProtoMessage()    {}
+func (*PartyResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{97}
+}
+
+func (m *PartyResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartyResult.Unmarshal(m, b)
+}
+func (m *PartyResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartyResult.Marshal(b, m, deterministic)
+}
+func (m *PartyResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartyResult.Merge(m, src)
+}
+func (m *PartyResult) XXX_Size() int {
+	return xxx_messageInfo_PartyResult.Size(m)
+}
+func (m *PartyResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartyResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartyResult proto.InternalMessageInfo
+
+func (m *PartyResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *PartyResult) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 请求添加好友，对方也向自己发过请求时直接成为好友
+type FriendRequest struct {
+	TargetId             int32    `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FriendRequest) Reset()         { *m = FriendRequest{} }
+func (m *FriendRequest) String() string { return proto.CompactTextString(m) }
+func (*FriendRequest) ProtoMessage()    {}
+func (*FriendRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{98}
+}
+
+func (m *FriendRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendRequest.Unmarshal(m, b)
+}
+func (m *FriendRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendRequest.Marshal(b, m, deterministic)
+}
+func (m *FriendRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendRequest.Merge(m, src)
+}
+func (m *FriendRequest) XXX_Size() int {
+	return xxx_messageInfo_FriendRequest.Size(m)
+}
+func (m *FriendRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendRequest proto.InternalMessageInfo
+
+func (m *FriendRequest) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
+// 收到好友请求
+type FriendInvitation struct {
+	RequesterId          int32    `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	RequesterName        string   `protobuf:"bytes,2,opt,name=requester_name,json=requesterName,proto3" json:"requester_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FriendInvitation) Reset()         { *m = FriendInvitation{} }
+func (m *FriendInvitation) String() string { return proto.CompactTextString(m) }
+func (*FriendInvitation) ProtoMessage()    {}
+func (*FriendInvitation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{99}
+}
+
+func (m *FriendInvitation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendInvitation.Unmarshal(m, b)
+}
+func (m *FriendInvitation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendInvitation.Marshal(b, m, deterministic)
+}
+func (m *FriendInvitation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendInvitation.Merge(m, src)
+}
+func (m *FriendInvitation) XXX_Size() int {
+	return xxx_messageInfo_FriendInvitation.Size(m)
+}
+func (m *FriendInvitation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendInvitation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendInvitation proto.InternalMessageInfo
+
+func (m *FriendInvitation) GetRequesterId() int32 {
+	if m != nil {
+		return m.RequesterId
+	}
+	return 0
+}
+
+func (m *FriendInvitation) GetRequesterName() string {
+	if m != nil {
+		return m.RequesterName
 	}
 	return ""
 }
 
-// 玩家信息
-type Player struct {
-	PlayerId             int32     `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	Pos                  *Position `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+// 回应好友请求
+type FriendRespond struct {
+	RequesterId          int32    `protobuf:"varint,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	Accept               bool     `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Player) Reset()         { *m = Player{} }
-func (m *Player) String() string { return proto.CompactTextString(m) }
-func (*Player) ProtoMessage()    {}
-func (*Player) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{4}
+func (m *FriendRespond) Reset()         { *m = FriendRespond{} }
+func (m *FriendRespond) String() string { return proto.CompactTextString(m) }
+func (*FriendRespond) ProtoMessage()    {}
+func (*FriendRespond) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{100}
 }
 
-func (m *Player) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Player.Unmarshal(m, b)
+func (m *FriendRespond) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendRespond.Unmarshal(m, b)
 }
-func (m *Player) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Player.Marshal(b, m, deterministic)
+func (m *FriendRespond) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendRespond.Marshal(b, m, deterministic)
 }
-func (m *Player) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Player.Merge(m, src)
+func (m *FriendRespond) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendRespond.Merge(m, src)
 }
-func (m *Player) XXX_Size() int {
-	return xxx_messageInfo_Player.Size(m)
+func (m *FriendRespond) XXX_Size() int {
+	return xxx_messageInfo_FriendRespond.Size(m)
 }
-func (m *Player) XXX_DiscardUnknown() {
-	xxx_messageInfo_Player.DiscardUnknown(m)
+func (m *FriendRespond) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendRespond.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Player proto.InternalMessageInfo
+var xxx_messageInfo_FriendRespond proto.InternalMessageInfo
 
-func (m *Player) GetPlayerId() int32 {
+func (m *FriendRespond) GetRequesterId() int32 {
+	if m != nil {
+		return m.RequesterId
+	}
+	return 0
+}
+
+func (m *FriendRespond) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+// 删除好友，双方的列表中都会移除
+type RemoveFriend struct {
+	PlayerId             int32    `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveFriend) Reset()         { *m = RemoveFriend{} }
+func (m *RemoveFriend) String() string { return proto.CompactTextString(m) }
+func (*RemoveFriend) ProtoMessage()    {}
+func (*RemoveFriend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{101}
+}
+
+func (m *RemoveFriend) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveFriend.Unmarshal(m, b)
+}
+func (m *RemoveFriend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveFriend.Marshal(b, m, deterministic)
+}
+func (m *RemoveFriend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveFriend.Merge(m, src)
+}
+func (m *RemoveFriend) XXX_Size() int {
+	return xxx_messageInfo_RemoveFriend.Size(m)
+}
+func (m *RemoveFriend) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveFriend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveFriend proto.InternalMessageInfo
+
+func (m *RemoveFriend) GetPlayerId() int32 {
 	if m != nil {
 		return m.PlayerId
 	}
 	return 0
 }
 
-func (m *Player) GetPos() *Position {
+// 好友信息，离线好友的等级和场景是最后一次下线时的数据
+type FriendInfo struct {
+	PlayerId             int32       `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Name                 string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Class                PlayerClass `protobuf:"varint,3,opt,name=class,proto3,enum=mmopb.PlayerClass" json:"class,omitempty"`
+	Level                int32       `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
+	Online               bool        `protobuf:"varint,5,opt,name=online,proto3" json:"online,omitempty"`
+	SceneId              int32       `protobuf:"varint,6,opt,name=scene_id,json=sceneId,proto3" json:"scene_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FriendInfo) Reset()         { *m = FriendInfo{} }
+func (m *FriendInfo) String() string { return proto.CompactTextString(m) }
+func (*FriendInfo) ProtoMessage()    {}
+func (*FriendInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{102}
+}
+
+func (m *FriendInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendInfo.Unmarshal(m, b)
+}
+func (m *FriendInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendInfo.Marshal(b, m, deterministic)
+}
+func (m *FriendInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendInfo.Merge(m, src)
+}
+func (m *FriendInfo) XXX_Size() int {
+	return xxx_messageInfo_FriendInfo.Size(m)
+}
+func (m *FriendInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendInfo proto.InternalMessageInfo
+
+func (m *FriendInfo) GetPlayerId() int32 {
 	if m != nil {
-		return m.Pos
+		return m.PlayerId
+	}
+	return 0
+}
+
+func (m *FriendInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FriendInfo) GetClass() PlayerClass {
+	if m != nil {
+		return m.Class
+	}
+	return PlayerClass_Class_Unknown
+}
+
+func (m *FriendInfo) GetLevel() int32 {
+	if m != nil {
+		return m.Level
+	}
+	return 0
+}
+
+func (m *FriendInfo) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *FriendInfo) GetSceneId() int32 {
+	if m != nil {
+		return m.SceneId
+	}
+	return 0
+}
+
+// 好友列表，上线和好友关系变化时发送
+type SyncFriendList struct {
+	Friends              []*FriendInfo `protobuf:"bytes,1,rep,name=friends,proto3" json:"friends,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SyncFriendList) Reset()         { *m = SyncFriendList{} }
+func (m *SyncFriendList) String() string { return proto.CompactTextString(m) }
+func (*SyncFriendList) ProtoMessage()    {}
+func (*SyncFriendList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{103}
+}
+
+func (m *SyncFriendList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncFriendList.Unmarshal(m, b)
+}
+func (m *SyncFriendList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncFriendList.Marshal(b, m, deterministic)
+}
+func (m *SyncFriendList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncFriendList.Merge(m, src)
+}
+func (m *SyncFriendList) XXX_Size() int {
+	return xxx_messageInfo_SyncFriendList.Size(m)
+}
+func (m *SyncFriendList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncFriendList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncFriendList proto.InternalMessageInfo
+
+func (m *SyncFriendList) GetFriends() []*FriendInfo {
+	if m != nil {
+		return m.Friends
 	}
 	return nil
 }
 
-// 同步玩家显示数据
-type SyncPlayers struct {
-	Players              []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+// 好友上线或下线
+type FriendPresence struct {
+	Friend               *FriendInfo `protobuf:"bytes,1,opt,name=friend,proto3" json:"friend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *SyncPlayers) Reset()         { *m = SyncPlayers{} }
-func (m *SyncPlayers) String() string { return proto.CompactTextString(m) }
-func (*SyncPlayers) ProtoMessage()    {}
-func (*SyncPlayers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{5}
+func (m *FriendPresence) Reset()         { *m = FriendPresence{} }
+func (m *FriendPresence) String() string { return proto.CompactTextString(m) }
+func (*FriendPresence) ProtoMessage()    {}
+func (*FriendPresence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{104}
 }
 
-func (m *SyncPlayers) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SyncPlayers.Unmarshal(m, b)
+func (m *FriendPresence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendPresence.Unmarshal(m, b)
 }
-func (m *SyncPlayers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SyncPlayers.Marshal(b, m, deterministic)
+func (m *FriendPresence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendPresence.Marshal(b, m, deterministic)
 }
-func (m *SyncPlayers) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SyncPlayers.Merge(m, src)
+func (m *FriendPresence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendPresence.Merge(m, src)
 }
-func (m *SyncPlayers) XXX_Size() int {
-	return xxx_messageInfo_SyncPlayers.Size(m)
+func (m *FriendPresence) XXX_Size() int {
+	return xxx_messageInfo_FriendPresence.Size(m)
 }
-func (m *SyncPlayers) XXX_DiscardUnknown() {
-	xxx_messageInfo_SyncPlayers.DiscardUnknown(m)
+func (m *FriendPresence) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendPresence.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SyncPlayers proto.InternalMessageInfo
+var xxx_messageInfo_FriendPresence proto.InternalMessageInfo
 
-func (m *SyncPlayers) GetPlayers() []*Player {
+func (m *FriendPresence) GetFriend() *FriendInfo {
 	if m != nil {
-		return m.Players
+		return m.Friend
 	}
 	return nil
 }
 
+// 好友操作结果，只在失败或请求被拒绝时返回
+type FriendResult struct {
+	Result               ResultCode `protobuf:"varint,1,opt,name=result,proto3,enum=mmopb.ResultCode" json:"result,omitempty"`
+	TargetId             int32      `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FriendResult) Reset()         { *m = FriendResult{} }
+func (m *FriendResult) String() string { return proto.CompactTextString(m) }
+func (*FriendResult) ProtoMessage()    {}
+func (*FriendResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{105}
+}
+
+func (m *FriendResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FriendResult.Unmarshal(m, b)
+}
+func (m *FriendResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FriendResult.Marshal(b, m, deterministic)
+}
+func (m *FriendResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FriendResult.Merge(m, src)
+}
+func (m *FriendResult) XXX_Size() int {
+	return xxx_messageInfo_FriendResult.Size(m)
+}
+func (m *FriendResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_FriendResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FriendResult proto.InternalMessageInfo
+
+func (m *FriendResult) GetResult() ResultCode {
+	if m != nil {
+		return m.Result
+	}
+	return ResultCode_Result_Ok
+}
+
+func (m *FriendResult) GetTargetId() int32 {
+	if m != nil {
+		return m.TargetId
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("mmopb.BroadCastType", BroadCastType_name, BroadCastType_value)
+	proto.RegisterEnum("mmopb.PlayerClass", PlayerClass_name, PlayerClass_value)
+	proto.RegisterEnum("mmopb.EquipSlot", EquipSlot_name, EquipSlot_value)
+	proto.RegisterEnum("mmopb.SkillState", SkillState_name, SkillState_value)
+	proto.RegisterEnum("mmopb.ChatChannel", ChatChannel_name, ChatChannel_value)
+	proto.RegisterEnum("mmopb.WhisperStatus", WhisperStatus_name, WhisperStatus_value)
+	proto.RegisterEnum("mmopb.ResultCode", ResultCode_name, ResultCode_value)
+	proto.RegisterEnum("mmopb.ExpSource", ExpSource_name, ExpSource_value)
+	proto.RegisterEnum("mmopb.TradeCloseReason", TradeCloseReason_name, TradeCloseReason_value)
+	proto.RegisterEnum("mmopb.QuestState", QuestState_name, QuestState_value)
 	proto.RegisterType((*SyncPlayerId)(nil), "mmopb.SyncPlayerId")
 	proto.RegisterType((*Position)(nil), "mmopb.Position")
+	proto.RegisterType((*PlayerProfile)(nil), "mmopb.PlayerProfile")
+	proto.RegisterType((*EquipVisual)(nil), "mmopb.EquipVisual")
+	proto.RegisterType((*SkillAction)(nil), "mmopb.SkillAction")
 	proto.RegisterType((*BroadCast)(nil), "mmopb.BroadCast")
+	proto.RegisterType((*PlayAction)(nil), "mmopb.PlayAction")
+	proto.RegisterType((*ActionResult)(nil), "mmopb.ActionResult")
 	proto.RegisterType((*Talk)(nil), "mmopb.Talk")
+	proto.RegisterType((*ChatMessage)(nil), "mmopb.ChatMessage")
+	proto.RegisterType((*ChatResult)(nil), "mmopb.ChatResult")
+	proto.RegisterType((*WhisperResult)(nil), "mmopb.WhisperResult")
+	proto.RegisterType((*ChatHistory)(nil), "mmopb.ChatHistory")
+	proto.RegisterType((*GetWhisperHistory)(nil), "mmopb.GetWhisperHistory")
+	proto.RegisterType((*GmCommandResult)(nil), "mmopb.GmCommandResult")
+	proto.RegisterType((*BlockPlayer)(nil), "mmopb.BlockPlayer")
+	proto.RegisterType((*SyncBlockList)(nil), "mmopb.SyncBlockList")
+	proto.RegisterType((*BlockResult)(nil), "mmopb.BlockResult")
+	proto.RegisterType((*CombatStats)(nil), "mmopb.CombatStats")
 	proto.RegisterType((*Player)(nil), "mmopb.Player")
+	proto.RegisterType((*Monster)(nil), "mmopb.Monster")
 	proto.RegisterType((*SyncPlayers)(nil), "mmopb.SyncPlayers")
+	proto.RegisterType((*Ping)(nil), "mmopb.Ping")
+	proto.RegisterType((*Pong)(nil), "mmopb.Pong")
+	proto.RegisterType((*ServerShutdown)(nil), "mmopb.ServerShutdown")
+	proto.RegisterType((*Login)(nil), "mmopb.Login")
+	proto.RegisterType((*Register)(nil), "mmopb.Register")
+	proto.RegisterType((*CharacterBrief)(nil), "mmopb.CharacterBrief")
+	proto.RegisterType((*LoginResult)(nil), "mmopb.LoginResult")
+	proto.RegisterType((*CharacterList)(nil), "mmopb.CharacterList")
+	proto.RegisterType((*CreateCharacter)(nil), "mmopb.CreateCharacter")
+	proto.RegisterType((*CreateCharacterResult)(nil), "mmopb.CreateCharacterResult")
+	proto.RegisterType((*SelectCharacter)(nil), "mmopb.SelectCharacter")
+	proto.RegisterType((*SelectCharacterResult)(nil), "mmopb.SelectCharacterResult")
+	proto.RegisterType((*Attack)(nil), "mmopb.Attack")
+	proto.RegisterType((*AttackResult)(nil), "mmopb.AttackResult")
+	proto.RegisterType((*Damage)(nil), "mmopb.Damage")
+	proto.RegisterType((*Death)(nil), "mmopb.Death")
+	proto.RegisterType((*CastSkill)(nil), "mmopb.CastSkill")
+	proto.RegisterType((*CastSkillResult)(nil), "mmopb.CastSkillResult")
+	proto.RegisterType((*BuffInfo)(nil), "mmopb.BuffInfo")
+	proto.RegisterType((*SyncBuffs)(nil), "mmopb.SyncBuffs")
+	proto.RegisterType((*BuffChange)(nil), "mmopb.BuffChange")
+	proto.RegisterType((*RespawnPoint)(nil), "mmopb.RespawnPoint")
+	proto.RegisterType((*RespawnOptions)(nil), "mmopb.RespawnOptions")
+	proto.RegisterType((*Respawn)(nil), "mmopb.Respawn")
+	proto.RegisterType((*RespawnResult)(nil), "mmopb.RespawnResult")
+	proto.RegisterType((*Revive)(nil), "mmopb.Revive")
+	proto.RegisterType((*ExpChange)(nil), "mmopb.ExpChange")
+	proto.RegisterType((*LevelUp)(nil), "mmopb.LevelUp")
+	proto.RegisterType((*ItemSlot)(nil), "mmopb.ItemSlot")
+	proto.RegisterType((*SyncInventory)(nil), "mmopb.SyncInventory")
+	proto.RegisterType((*InventoryChange)(nil), "mmopb.InventoryChange")
+	proto.RegisterType((*MoveItem)(nil), "mmopb.MoveItem")
+	proto.RegisterType((*SplitItem)(nil), "mmopb.SplitItem")
+	proto.RegisterType((*UseItem)(nil), "mmopb.UseItem")
+	proto.RegisterType((*InventoryResult)(nil), "mmopb.InventoryResult")
+	proto.RegisterType((*EntityStats)(nil), "mmopb.EntityStats")
+	proto.RegisterType((*EquipItem)(nil), "mmopb.EquipItem")
+	proto.RegisterType((*UnequipItem)(nil), "mmopb.UnequipItem")
+	proto.RegisterType((*EquipResult)(nil), "mmopb.EquipResult")
+	proto.RegisterType((*GroundLoot)(nil), "mmopb.GroundLoot")
+	proto.RegisterType((*LootRemove)(nil), "mmopb.LootRemove")
+	proto.RegisterType((*PickupLoot)(nil), "mmopb.PickupLoot")
+	proto.RegisterType((*PickupResult)(nil), "mmopb.PickupResult")
+	proto.RegisterType((*ItemCount)(nil), "mmopb.ItemCount")
+	proto.RegisterType((*TradeRequest)(nil), "mmopb.TradeRequest")
+	proto.RegisterType((*TradeInvite)(nil), "mmopb.TradeInvite")
+	proto.RegisterType((*TradeRespond)(nil), "mmopb.TradeRespond")
+	proto.RegisterType((*TradeOpen)(nil), "mmopb.TradeOpen")
+	proto.RegisterType((*TradeOffer)(nil), "mmopb.TradeOffer")
+	proto.RegisterType((*TradeLock)(nil), "mmopb.TradeLock")
+	proto.RegisterType((*TradeConfirm)(nil), "mmopb.TradeConfirm")
+	proto.RegisterType((*TradeCancel)(nil), "mmopb.TradeCancel")
+	proto.RegisterType((*TradeSideInfo)(nil), "mmopb.TradeSideInfo")
+	proto.RegisterType((*TradeUpdate)(nil), "mmopb.TradeUpdate")
+	proto.RegisterType((*TradeClosed)(nil), "mmopb.TradeClosed")
+	proto.RegisterType((*TradeResult)(nil), "mmopb.TradeResult")
+	proto.RegisterType((*QuestInfo)(nil), "mmopb.QuestInfo")
+	proto.RegisterType((*SyncQuests)(nil), "mmopb.SyncQuests")
+	proto.RegisterType((*QuestUpdate)(nil), "mmopb.QuestUpdate")
+	proto.RegisterType((*AcceptQuest)(nil), "mmopb.AcceptQuest")
+	proto.RegisterType((*AbandonQuest)(nil), "mmopb.AbandonQuest")
+	proto.RegisterType((*TurnInQuest)(nil), "mmopb.TurnInQuest")
+	proto.RegisterType((*TalkNpc)(nil), "mmopb.TalkNpc")
+	proto.RegisterType((*QuestResult)(nil), "mmopb.QuestResult")
+	proto.RegisterType((*ZoneEvent)(nil), "mmopb.ZoneEvent")
+	proto.RegisterType((*PartyInvite)(nil), "mmopb.PartyInvite")
+	proto.RegisterType((*PartyInvitation)(nil), "mmopb.PartyInvitation")
+	proto.RegisterType((*PartyRespond)(nil), "mmopb.PartyRespond")
+	proto.RegisterType((*PartyLeave)(nil), "mmopb.PartyLeave")
+	proto.RegisterType((*PartyKick)(nil), "mmopb.PartyKick")
+	proto.RegisterType((*PartyTransfer)(nil), "mmopb.PartyTransfer")
+	proto.RegisterType((*PartyMemberState)(nil), "mmopb.PartyMemberState")
+	proto.RegisterType((*PartyMemberInfo)(nil), "mmopb.PartyMemberInfo")
+	proto.RegisterType((*PartyInfo)(nil), "mmopb.PartyInfo")
+	proto.RegisterType((*PartyStates)(nil), "mmopb.PartyStates")
+	proto.RegisterType((*PartyResult)(nil), "mmopb.PartyResult")
+	proto.RegisterType((*FriendRequest)(nil), "mmopb.FriendRequest")
+	proto.RegisterType((*FriendInvitation)(nil), "mmopb.FriendInvitation")
+	proto.RegisterType((*FriendRespond)(nil), "mmopb.FriendRespond")
+	proto.RegisterType((*RemoveFriend)(nil), "mmopb.RemoveFriend")
+	proto.RegisterType((*FriendInfo)(nil), "mmopb.FriendInfo")
+	proto.RegisterType((*SyncFriendList)(nil), "mmopb.SyncFriendList")
+	proto.RegisterType((*FriendPresence)(nil), "mmopb.FriendPresence")
+	proto.RegisterType((*FriendResult)(nil), "mmopb.FriendResult")
 }
 
 func init() { proto.RegisterFile("message.proto", fileDescriptor_33c57e4bae7b9afd) }
 
 var fileDescriptor_33c57e4bae7b9afd = []byte{
-	// 372 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcd, 0xae, 0xd2, 0x40,
-	0x14, 0x80, 0xfb, 0x47, 0xef, 0xed, 0xe9, 0x2d, 0xd4, 0x89, 0x8b, 0x89, 0x6e, 0xb0, 0x2e, 0x6c,
-	0x34, 0x61, 0x81, 0x89, 0x7b, 0xc0, 0x45, 0x35, 0x31, 0x21, 0x15, 0xe3, 0xb2, 0x19, 0xda, 0x01,
-	0x1b, 0xa1, 0x33, 0xe9, 0x4c, 0x08, 0xe5, 0xbd, 0x7c, 0x3f, 0x33, 0x33, 0x2d, 0x44, 0x73, 0xc3,
-	0x8e, 0xef, 0xfc, 0xcd, 0x77, 0x0e, 0x85, 0xe8, 0x48, 0x85, 0x20, 0x7b, 0x3a, 0xe3, 0x2d, 0x93,
-	0x0c, 0x8d, 0x8e, 0x47, 0xc6, 0xb7, 0xc9, 0x07, 0x78, 0xfa, 0xde, 0x35, 0xe5, 0xfa, 0x40, 0x3a,
-	0xda, 0x7e, 0xa9, 0xd0, 0x6b, 0x08, 0xb8, 0xfe, 0x5d, 0xd4, 0x15, 0xb6, 0xa7, 0x76, 0x3a, 0xca,
-	0x1f, 0x79, 0x9f, 0x4c, 0x96, 0xf0, 0xb8, 0x66, 0xa2, 0x96, 0x35, 0x6b, 0xd0, 0x13, 0xd8, 0x67,
-	0x5d, 0xe0, 0xe4, 0xf6, 0x59, 0x51, 0x87, 0x1d, 0x43, 0x9d, 0xa2, 0x0b, 0x76, 0x0d, 0x5d, 0x14,
-	0x9d, 0xb0, 0x67, 0xe8, 0x94, 0xfc, 0xb1, 0x21, 0x58, 0xb6, 0x8c, 0x54, 0x2b, 0x22, 0xe4, 0xdd,
-	0xe7, 0x50, 0x0a, 0x9e, 0xec, 0x38, 0xd5, 0x73, 0xc7, 0xf3, 0x97, 0x33, 0x6d, 0x3c, 0xbb, 0x36,
-	0x6f, 0x3a, 0x4e, 0x73, 0x5d, 0x81, 0x5e, 0xc1, 0x43, 0xc9, 0x1a, 0x49, 0x1b, 0xa9, 0x9f, 0x0d,
-	0x32, 0x2b, 0x1f, 0x02, 0xe8, 0x2d, 0xb8, 0x9c, 0x09, 0x2d, 0x10, 0xce, 0x27, 0xfd, 0x90, 0x61,
-	0x8d, 0xcc, 0xca, 0x55, 0x16, 0x61, 0xf0, 0x49, 0xa9, 0x02, 0x78, 0xa4, 0x24, 0x32, 0x2b, 0xef,
-	0x79, 0xe9, 0x83, 0xf7, 0x99, 0x48, 0x92, 0x7c, 0x05, 0x6f, 0x43, 0x0e, 0xbf, 0x51, 0x0a, 0xb1,
-	0x24, 0xed, 0x9e, 0xca, 0xe2, 0x7f, 0xf1, 0xb1, 0x89, 0x5f, 0x4f, 0x89, 0x6f, 0x52, 0x6a, 0x83,
-	0xe0, 0xaa, 0x94, 0x64, 0xe0, 0x9b, 0xaa, 0xfb, 0xfb, 0xbf, 0x31, 0xe6, 0xce, 0xb3, 0xe6, 0xda,
-	0x3b, 0xf9, 0x04, 0xe1, 0xed, 0xef, 0x13, 0xe8, 0x1d, 0x3c, 0x98, 0x6e, 0x81, 0xed, 0xa9, 0x9b,
-	0x86, 0xf3, 0x68, 0xe8, 0xd2, 0xd1, 0x7c, 0xc8, 0xbe, 0x2f, 0x21, 0xfa, 0xe7, 0x8e, 0x68, 0x02,
-	0xe1, 0x8f, 0x46, 0x70, 0x5a, 0xd6, 0xbb, 0x9a, 0x56, 0xb1, 0x85, 0xc6, 0x00, 0x3f, 0x59, 0x7b,
-	0xa8, 0x8a, 0xd5, 0x2f, 0x22, 0x63, 0x5b, 0xb1, 0x19, 0x52, 0xac, 0x99, 0x88, 0x1d, 0xf4, 0x02,
-	0xa2, 0x9e, 0x17, 0xfa, 0x50, 0xb1, 0xab, 0x4a, 0x16, 0x3b, 0x49, 0xdb, 0xe2, 0x1b, 0x3b, 0xd1,
-	0xd8, 0xdb, 0xfa, 0xfa, 0x4b, 0xfb, 0xf8, 0x37, 0x00, 0x00, 0xff, 0xff, 0xed, 0xd4, 0x52, 0x76,
-	0x7a, 0x02, 0x00, 0x00,
+	// 3929 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x5d, 0x6f, 0xdc, 0x48,
+	0x72, 0xe6, 0x8c, 0xe6, 0xab, 0x46, 0x33, 0xa2, 0xe9, 0xaf, 0xb1, 0xbd, 0x7b, 0xeb, 0xe5, 0x7a,
+	0x77, 0xb5, 0xb2, 0xcf, 0xbb, 0xf1, 0x66, 0x93, 0xcb, 0x1d, 0x2e, 0x38, 0x49, 0x96, 0xec, 0xc9,
+	0x49, 0x96, 0x96, 0x92, 0xce, 0x40, 0x90, 0x84, 0x69, 0x0d, 0x7b, 0x46, 0x8c, 0x48, 0x36, 0x8f,
+	0xe4, 0x8c, 0x25, 0x01, 0x79, 0xc9, 0x53, 0x80, 0xbc, 0x5c, 0xde, 0x02, 0x04, 0xc8, 0x43, 0x82,
+	0x7c, 0x20, 0x4f, 0x79, 0xc8, 0x4b, 0x80, 0xbc, 0xe6, 0x97, 0xe4, 0x8f, 0x04, 0x55, 0xdd, 0x24,
+	0x7b, 0x46, 0xd2, 0x48, 0xda, 0x73, 0xde, 0x58, 0xd5, 0xd5, 0xd5, 0xd5, 0x55, 0xd5, 0x55, 0x5d,
+	0xd5, 0x84, 0x4e, 0xc8, 0xd3, 0x94, 0x8d, 0xf8, 0x8b, 0x38, 0x11, 0x99, 0xb0, 0x6a, 0x61, 0x28,
+	0xe2, 0x43, 0xfb, 0x19, 0x2c, 0xee, 0x9d, 0x46, 0x83, 0xdd, 0x80, 0x9d, 0xf2, 0xa4, 0xef, 0x59,
+	0x8f, 0xa1, 0x15, 0xd3, 0xb7, 0xeb, 0x7b, 0x3d, 0xe3, 0x89, 0xb1, 0x5c, 0x73, 0x9a, 0xb1, 0x1a,
+	0xb4, 0xd7, 0xa0, 0xb9, 0x2b, 0x52, 0x3f, 0xf3, 0x45, 0x64, 0x2d, 0x82, 0x71, 0x42, 0x04, 0x15,
+	0xc7, 0x38, 0x41, 0xe8, 0xb4, 0x57, 0x91, 0xd0, 0x29, 0x42, 0x67, 0xbd, 0xaa, 0x84, 0xce, 0x10,
+	0x9a, 0xf4, 0x16, 0x24, 0x34, 0xb1, 0xff, 0xd3, 0x80, 0x8e, 0x5c, 0x6d, 0x37, 0x11, 0x43, 0x3f,
+	0xe0, 0x96, 0x05, 0x0b, 0x11, 0x0b, 0x39, 0x31, 0x6b, 0x39, 0xf4, 0x6d, 0x2d, 0x43, 0x6d, 0x10,
+	0xb0, 0x34, 0x25, 0x9e, 0xdd, 0x97, 0xd6, 0x0b, 0x92, 0xf6, 0x85, 0x9c, 0xb8, 0x8e, 0x23, 0x8e,
+	0x24, 0xb0, 0x3e, 0x83, 0x0e, 0x8b, 0x63, 0xce, 0x12, 0x16, 0x0d, 0x38, 0x0a, 0x5d, 0x25, 0xa1,
+	0x17, 0x4b, 0x64, 0xdf, 0xb3, 0xee, 0x42, 0x2d, 0xe0, 0x13, 0x1e, 0x90, 0x18, 0x35, 0x47, 0x02,
+	0xd6, 0x0a, 0xd4, 0xf9, 0xaf, 0xc7, 0x7e, 0x9c, 0xf6, 0x6a, 0x4f, 0xaa, 0xcb, 0xed, 0x62, 0x95,
+	0x0d, 0x44, 0xfe, 0xca, 0x4f, 0xc7, 0x2c, 0x70, 0x14, 0x85, 0x3d, 0x82, 0xb6, 0x86, 0xb6, 0x9e,
+	0xc2, 0x42, 0x1a, 0x88, 0x8c, 0x64, 0xee, 0xbe, 0x34, 0xf5, 0x89, 0x7b, 0x81, 0xc8, 0x1c, 0x1a,
+	0xb5, 0x1e, 0x40, 0xc3, 0xcf, 0x78, 0x88, 0x52, 0x55, 0x68, 0xe1, 0x3a, 0x82, 0x7d, 0xcf, 0x7a,
+	0x08, 0xcd, 0x50, 0x78, 0x3c, 0x28, 0xe5, 0x6d, 0x10, 0xdc, 0xf7, 0xec, 0x7f, 0x35, 0xa0, 0xbd,
+	0x77, 0xec, 0x07, 0xc1, 0xea, 0x80, 0xf4, 0xfc, 0x10, 0x9a, 0x29, 0x82, 0xa5, 0x3d, 0x1a, 0x04,
+	0xf7, 0x3d, 0xeb, 0x4b, 0xa8, 0xa5, 0x19, 0xcb, 0xb8, 0x52, 0xd2, 0x6d, 0x25, 0x05, 0xcd, 0xde,
+	0xc3, 0x01, 0x47, 0x8e, 0xa3, 0x51, 0x33, 0x96, 0x8c, 0x78, 0x56, 0xae, 0xd7, 0x94, 0x88, 0xbe,
+	0x27, 0x0d, 0xb9, 0xa0, 0x19, 0xf2, 0xac, 0x57, 0xcb, 0x4d, 0xf7, 0x18, 0x5a, 0x03, 0x96, 0x66,
+	0x6e, 0xe6, 0x87, 0xbc, 0x57, 0x97, 0x13, 0x11, 0xb1, 0xef, 0x87, 0xdc, 0xfe, 0xdb, 0x0a, 0xb4,
+	0xd6, 0x12, 0xc1, 0xbc, 0x75, 0x96, 0x66, 0x73, 0x1d, 0xc7, 0x5a, 0x86, 0x85, 0xec, 0x34, 0xce,
+	0x05, 0xbd, 0xab, 0x04, 0x2d, 0x26, 0xef, 0x9f, 0xc6, 0xdc, 0x21, 0x0a, 0xeb, 0x11, 0x34, 0x06,
+	0x22, 0xca, 0x78, 0x94, 0x91, 0xa0, 0xad, 0x37, 0xb7, 0x9c, 0x1c, 0x61, 0x7d, 0x06, 0xd5, 0x58,
+	0xa4, 0x24, 0x6b, 0xfb, 0xe5, 0x52, 0xee, 0x12, 0xca, 0x21, 0xdf, 0xdc, 0x72, 0x70, 0xd4, 0xea,
+	0x41, 0x9d, 0x91, 0xe6, 0x68, 0x17, 0xb5, 0x37, 0xb7, 0x1c, 0x05, 0x5b, 0x2b, 0x50, 0x23, 0xcd,
+	0xf5, 0x1a, 0x4f, 0x0c, 0xcd, 0xda, 0x9a, 0xb2, 0xdf, 0xdc, 0x72, 0x24, 0x89, 0xf5, 0x02, 0x1a,
+	0xb1, 0x74, 0x4f, 0xda, 0x76, 0xfb, 0xe5, 0xdd, 0x29, 0x0f, 0x54, 0xae, 0xeb, 0xe4, 0x44, 0x6b,
+	0x75, 0x58, 0x78, 0xc5, 0x32, 0x66, 0x3f, 0x05, 0x40, 0x0a, 0x65, 0xbb, 0xfb, 0x85, 0x2c, 0x52,
+	0x21, 0x0a, 0xb2, 0xbf, 0x87, 0x45, 0x49, 0xe1, 0xf0, 0x74, 0x1c, 0x64, 0xd6, 0x57, 0x50, 0x4f,
+	0xe8, 0xab, 0x67, 0x4c, 0x59, 0x52, 0x0e, 0xaf, 0x0b, 0x8f, 0x3b, 0x8a, 0x40, 0x63, 0x59, 0x99,
+	0x62, 0x79, 0x02, 0x0b, 0xfb, 0x2c, 0x38, 0xb6, 0x96, 0xc1, 0x54, 0xa6, 0x9e, 0xb5, 0x46, 0x57,
+	0xe2, 0x8b, 0x93, 0xde, 0x2b, 0x35, 0x5d, 0xa1, 0x93, 0x97, 0x83, 0xd6, 0x73, 0x68, 0x0c, 0x8e,
+	0x58, 0x14, 0xf1, 0xa0, 0x57, 0x9d, 0x3a, 0x7e, 0xeb, 0x47, 0x2c, 0x5b, 0x97, 0x23, 0x4e, 0x4e,
+	0x62, 0xff, 0xaf, 0x01, 0x6d, 0x1c, 0xd8, 0x96, 0xe1, 0x45, 0x9f, 0x6d, 0x5c, 0x39, 0x1b, 0xdd,
+	0x26, 0xe5, 0x91, 0x27, 0x05, 0x95, 0x5b, 0x6a, 0x4a, 0x44, 0xdf, 0xb3, 0x3e, 0x81, 0xb6, 0x1a,
+	0xa4, 0x00, 0x41, 0x0e, 0xe1, 0x80, 0x44, 0xbd, 0x65, 0xe1, 0x8c, 0x63, 0x2f, 0xcc, 0x38, 0xb6,
+	0xb6, 0xc1, 0xda, 0xf4, 0x06, 0x1f, 0x40, 0x03, 0x3d, 0xda, 0x0d, 0x53, 0xb2, 0x6e, 0xd5, 0xa9,
+	0x23, 0xb8, 0x8d, 0xce, 0xd3, 0x10, 0xc3, 0x61, 0xe0, 0x47, 0x9c, 0x9c, 0xa4, 0xe9, 0xe4, 0xa0,
+	0xfd, 0x6f, 0x06, 0x00, 0x6e, 0xe0, 0xe6, 0x16, 0xd3, 0xf4, 0x51, 0xb9, 0x96, 0x3e, 0x2e, 0x3f,
+	0xaa, 0x4f, 0xa1, 0x1b, 0x8e, 0x33, 0xee, 0x26, 0x3c, 0x64, 0x7e, 0x84, 0xe2, 0x2f, 0x90, 0xf8,
+	0x8b, 0x88, 0x75, 0x08, 0xb9, 0x9d, 0xda, 0x7f, 0x67, 0x40, 0xe7, 0xdd, 0x91, 0x9f, 0xc6, 0x3c,
+	0x51, 0xd2, 0x4e, 0x31, 0x35, 0x66, 0x98, 0x3e, 0x87, 0x3a, 0x46, 0x89, 0x71, 0x3a, 0x73, 0x3a,
+	0x15, 0x8b, 0x3d, 0x1a, 0x73, 0x14, 0x0d, 0xfa, 0x5f, 0x9a, 0x89, 0x84, 0x4b, 0xe1, 0x9a, 0x8e,
+	0x82, 0xae, 0x29, 0xda, 0x5f, 0x2b, 0x5f, 0x79, 0xe3, 0xe3, 0xb4, 0xd3, 0x9b, 0xfb, 0x4a, 0xb9,
+	0x8d, 0xca, 0xcc, 0x36, 0x5e, 0x40, 0x53, 0x25, 0xb8, 0xb4, 0x57, 0x9d, 0x0a, 0xe7, 0x9a, 0x73,
+	0x3a, 0x05, 0x8d, 0xfd, 0x0d, 0xdc, 0x7e, 0xcd, 0x33, 0xb5, 0xc9, 0x5c, 0x9e, 0xb9, 0xd9, 0x2f,
+	0x82, 0xa5, 0xd7, 0xe1, 0xba, 0x08, 0x43, 0x16, 0x79, 0x37, 0x77, 0x03, 0xf2, 0x46, 0x9a, 0x5b,
+	0x1e, 0x37, 0x02, 0x51, 0xa5, 0x62, 0x9c, 0xc5, 0x63, 0x15, 0xf1, 0x1c, 0x05, 0xd9, 0xbf, 0x80,
+	0xf6, 0x5a, 0x20, 0x06, 0xc7, 0xf2, 0xc4, 0xce, 0x0f, 0xb0, 0x77, 0xa1, 0x76, 0x88, 0xb4, 0xc4,
+	0xbb, 0xe9, 0x48, 0xc0, 0x7e, 0x01, 0x1d, 0x4c, 0xee, 0xc4, 0x65, 0xcb, 0x4f, 0x33, 0xeb, 0x63,
+	0x80, 0x82, 0x47, 0xda, 0x33, 0x9e, 0x54, 0x97, 0x6b, 0x4e, 0x2b, 0x67, 0x92, 0xda, 0x42, 0xad,
+	0x78, 0xf3, 0xdd, 0x4d, 0x09, 0x57, 0x99, 0x11, 0xae, 0x07, 0x0d, 0x92, 0xa7, 0x70, 0x9a, 0x1c,
+	0xb4, 0xcf, 0xa0, 0xbd, 0x2e, 0xc2, 0x43, 0x96, 0xa1, 0x97, 0xa5, 0x56, 0x17, 0x2a, 0x47, 0xb1,
+	0xda, 0x5b, 0xe5, 0x28, 0xb6, 0xee, 0x41, 0x3d, 0x64, 0x27, 0xee, 0x51, 0xac, 0x58, 0xd6, 0x42,
+	0x76, 0xf2, 0x26, 0x46, 0xb2, 0x30, 0x56, 0x87, 0xa3, 0x12, 0x16, 0x64, 0x61, 0x9c, 0xa7, 0xf7,
+	0x90, 0x9d, 0x6c, 0xc7, 0xb8, 0xd9, 0x50, 0x4c, 0xb8, 0x9b, 0xc6, 0x9c, 0x7b, 0x2a, 0xa7, 0xb5,
+	0x10, 0xb3, 0x87, 0x08, 0xfb, 0x1f, 0x0d, 0xa8, 0x5f, 0x47, 0xb5, 0x9f, 0xca, 0xac, 0x53, 0xb9,
+	0x30, 0xeb, 0xc8, 0x9c, 0xa3, 0x65, 0x8b, 0xea, 0x35, 0xb2, 0x05, 0xde, 0x6e, 0xf0, 0x38, 0xe5,
+	0xa9, 0xac, 0x70, 0xd4, 0x52, 0x15, 0x32, 0x73, 0xa7, 0xf6, 0xbf, 0x1b, 0xd0, 0xd8, 0x16, 0x51,
+	0x9a, 0xf1, 0x44, 0xee, 0x87, 0x3e, 0x4b, 0x31, 0x5b, 0x0a, 0x23, 0x83, 0x65, 0xc6, 0xc3, 0x38,
+	0x60, 0x19, 0x2f, 0x8d, 0x00, 0x39, 0xaa, 0xef, 0x15, 0xf7, 0xac, 0xaa, 0x76, 0xcf, 0xfa, 0x74,
+	0x5e, 0x4a, 0x95, 0x9b, 0x2b, 0x84, 0xad, 0x5d, 0x25, 0xec, 0xdf, 0xe0, 0xd5, 0xa5, 0xb8, 0x4c,
+	0xa6, 0xd6, 0x97, 0xd0, 0x90, 0x5a, 0x94, 0xae, 0xd6, 0x7e, 0xd9, 0x99, 0x52, 0x8b, 0x93, 0x8f,
+	0x5a, 0x2b, 0xd0, 0x54, 0xfb, 0x40, 0x3d, 0x23, 0x65, 0x57, 0x51, 0xaa, 0xbd, 0x3b, 0xc5, 0x38,
+	0x5e, 0x7a, 0x02, 0x21, 0xb2, 0xfc, 0x90, 0xe7, 0x3e, 0xf9, 0x3a, 0x11, 0xe3, 0xc8, 0xdb, 0x12,
+	0x22, 0x73, 0xe4, 0xb8, 0xfd, 0x14, 0x16, 0x76, 0xfd, 0x68, 0x64, 0x7d, 0x04, 0x2d, 0x8c, 0xee,
+	0x69, 0xc6, 0x42, 0xe9, 0x5b, 0x55, 0xa7, 0x44, 0x10, 0x95, 0xb8, 0x92, 0x6a, 0x13, 0xba, 0x7b,
+	0x3c, 0x99, 0xf0, 0x64, 0xef, 0x68, 0x9c, 0x79, 0xe2, 0x7d, 0x84, 0xf4, 0x03, 0x31, 0x8e, 0x08,
+	0xc8, 0x6d, 0x51, 0x20, 0xf0, 0x48, 0x27, 0x9c, 0xa5, 0x2a, 0x4b, 0xb7, 0x1c, 0x05, 0xd9, 0x3f,
+	0x87, 0xda, 0x96, 0x18, 0xf9, 0x11, 0x1e, 0x09, 0x36, 0x20, 0x7a, 0x75, 0xed, 0xcd, 0x41, 0xeb,
+	0x11, 0x34, 0x63, 0x96, 0xa6, 0xef, 0x45, 0x92, 0x07, 0x8a, 0x02, 0xb6, 0x7f, 0x01, 0x4d, 0x87,
+	0x8f, 0x7c, 0xf2, 0x86, 0x1f, 0xc6, 0xe1, 0x4f, 0xa1, 0xbb, 0x7e, 0xc4, 0x12, 0x36, 0xc8, 0x78,
+	0xb2, 0x96, 0xf8, 0x7c, 0x38, 0xdf, 0xf7, 0x35, 0xc7, 0xae, 0x5c, 0xc3, 0xb1, 0x31, 0x80, 0xd0,
+	0xfe, 0x6e, 0x1e, 0x40, 0xbe, 0x03, 0x18, 0xe4, 0x82, 0xe5, 0x4e, 0x70, 0xaf, 0x0c, 0xe0, 0x9a,
+	0xc4, 0x8e, 0x46, 0x68, 0x6f, 0x42, 0xa7, 0x18, 0xa5, 0x08, 0x37, 0xcd, 0xc7, 0xb8, 0x2e, 0x9f,
+	0x1d, 0x58, 0x5a, 0x4f, 0x38, 0xcb, 0x78, 0x41, 0xf3, 0xdb, 0x95, 0x25, 0xf6, 0x7b, 0xb8, 0x37,
+	0xc3, 0xf0, 0xe6, 0x3a, 0xf9, 0x16, 0x5a, 0x85, 0x88, 0x4a, 0xff, 0x97, 0x6c, 0xa5, 0xa4, 0xb3,
+	0x5f, 0xc0, 0xd2, 0x1e, 0x0f, 0xf8, 0x20, 0x2b, 0x77, 0x32, 0x37, 0xab, 0xb9, 0x70, 0x6f, 0x86,
+	0xfe, 0xc3, 0x46, 0x7f, 0xfb, 0x73, 0xa8, 0xaf, 0x66, 0x19, 0x1b, 0x1c, 0xcf, 0xbd, 0x86, 0xd8,
+	0xbf, 0x82, 0x45, 0x49, 0xf6, 0x83, 0x96, 0xbf, 0xf4, 0x5e, 0x60, 0xff, 0xb3, 0x01, 0xf5, 0x57,
+	0x2c, 0xc4, 0x9b, 0xe9, 0x27, 0xd0, 0x66, 0xb4, 0x84, 0xae, 0x09, 0xc8, 0x51, 0xb2, 0xf8, 0xbd,
+	0x94, 0x11, 0x9e, 0x69, 0x8f, 0xf8, 0xa8, 0xcc, 0xa3, 0x20, 0x3c, 0x6e, 0x83, 0xc4, 0xcf, 0xfc,
+	0x01, 0x93, 0xe5, 0x65, 0xd3, 0x29, 0x60, 0x95, 0xd0, 0x6a, 0x45, 0x42, 0xd3, 0x8b, 0xb9, 0xfa,
+	0x54, 0x31, 0x67, 0xaf, 0x42, 0xed, 0x15, 0x67, 0xd9, 0x11, 0x0a, 0xc1, 0xa3, 0xcc, 0xcf, 0x4e,
+	0x35, 0x2d, 0x49, 0x84, 0x94, 0x10, 0xe9, 0xa7, 0x34, 0x2d, 0x11, 0x64, 0xca, 0x16, 0x56, 0x53,
+	0x54, 0xd0, 0xcc, 0xab, 0x1b, 0xe7, 0x6e, 0x93, 0xca, 0xc1, 0xea, 0x54, 0x39, 0xa8, 0x8a, 0xc3,
+	0x33, 0xfb, 0x1d, 0x2c, 0x15, 0x0b, 0xdc, 0xdc, 0x4c, 0xba, 0x44, 0x95, 0xe9, 0xcd, 0x8f, 0xa1,
+	0xb9, 0x36, 0x1e, 0x0e, 0xfb, 0xd1, 0x50, 0xe0, 0xe5, 0xfc, 0x70, 0x3c, 0x1c, 0x96, 0x72, 0xd7,
+	0x11, 0x94, 0x06, 0x48, 0xd1, 0x54, 0x69, 0x5e, 0xfa, 0x48, 0x08, 0xb7, 0x53, 0xde, 0x3a, 0xd5,
+	0x95, 0x39, 0x51, 0x37, 0xce, 0xbc, 0x82, 0x95, 0x0a, 0x5b, 0x28, 0x2b, 0x58, 0x52, 0xd8, 0x0e,
+	0xb4, 0xe8, 0x7e, 0x34, 0x1e, 0x0e, 0xd3, 0xf9, 0x7a, 0xff, 0x1c, 0x6a, 0x28, 0x45, 0x1e, 0x99,
+	0xf2, 0x4c, 0x99, 0x0b, 0xed, 0xc8, 0x51, 0xfb, 0x08, 0x00, 0x51, 0x78, 0x73, 0x1d, 0xf1, 0xf9,
+	0x1c, 0x3f, 0x83, 0x05, 0x9c, 0x33, 0x73, 0xaf, 0x28, 0x18, 0xd2, 0x20, 0x06, 0xf9, 0x84, 0xe3,
+	0x95, 0xa5, 0xb8, 0x39, 0x29, 0xd0, 0xfe, 0x73, 0x58, 0x74, 0x78, 0x1a, 0xb3, 0xf7, 0xd1, 0xae,
+	0xf0, 0x23, 0x52, 0x6e, 0x8c, 0x1f, 0x9a, 0xb9, 0x09, 0xd6, 0xf2, 0x7e, 0xe5, 0x7c, 0xde, 0xaf,
+	0x5e, 0x9e, 0xf7, 0xed, 0xbf, 0x32, 0xa0, 0xab, 0x96, 0xd8, 0x89, 0x11, 0x9d, 0x92, 0x05, 0x07,
+	0x3c, 0xe2, 0xba, 0x4f, 0x21, 0xdc, 0xf7, 0xac, 0x67, 0x50, 0xa7, 0xf5, 0x72, 0x0d, 0xdd, 0x29,
+	0xfd, 0xa0, 0x10, 0xd2, 0x51, 0x24, 0x58, 0xa4, 0x46, 0x9c, 0x25, 0x3c, 0xcd, 0xdc, 0x42, 0x68,
+	0x69, 0xb8, 0xae, 0xc2, 0xef, 0x4a, 0xd9, 0xed, 0xa7, 0xd0, 0x50, 0x1c, 0xe6, 0xec, 0xd0, 0x3e,
+	0x80, 0x8e, 0xa2, 0xfa, 0x41, 0x5e, 0x59, 0xb0, 0xad, 0x4c, 0xb3, 0x4d, 0xa0, 0xee, 0xf0, 0x89,
+	0x3f, 0xb9, 0xc2, 0x92, 0xd7, 0xb8, 0x20, 0x16, 0x77, 0xa8, 0xea, 0x55, 0x77, 0xa8, 0xdf, 0x18,
+	0xd0, 0xda, 0x38, 0x89, 0x95, 0x07, 0x15, 0x7d, 0x2b, 0x43, 0xef, 0x5b, 0x99, 0x50, 0xe5, 0x27,
+	0xf2, 0x4e, 0x5c, 0x75, 0xf0, 0x13, 0x37, 0x11, 0xf1, 0x93, 0xcc, 0x45, 0x74, 0x95, 0xd0, 0x0d,
+	0x84, 0x37, 0x4e, 0x62, 0x3c, 0x35, 0x23, 0xe6, 0x47, 0xdc, 0x53, 0x05, 0x99, 0x82, 0xac, 0x65,
+	0xa8, 0xa7, 0x62, 0x9c, 0x0c, 0x38, 0x85, 0x27, 0xad, 0x87, 0x75, 0x12, 0xef, 0x11, 0xde, 0x51,
+	0xe3, 0xf6, 0x10, 0x1a, 0x5b, 0xb8, 0xee, 0x41, 0x7c, 0x65, 0x0d, 0x22, 0x85, 0xad, 0xe8, 0xc2,
+	0x5e, 0x7f, 0xeb, 0xdb, 0xd0, 0xec, 0x67, 0x3c, 0xc4, 0xfe, 0x19, 0xfa, 0x6c, 0xd1, 0x5f, 0xab,
+	0x5d, 0xd5, 0x4d, 0xbb, 0x0b, 0x35, 0x79, 0x11, 0x92, 0x3e, 0x24, 0x01, 0xfb, 0xcf, 0x64, 0xf1,
+	0xd3, 0x8f, 0x26, 0x3c, 0xa2, 0xe2, 0x0e, 0x79, 0xfa, 0x67, 0xbc, 0xe0, 0xe9, 0x9f, 0x71, 0x3c,
+	0xd7, 0xc8, 0x7b, 0xf6, 0x5c, 0xe7, 0x72, 0x38, 0x72, 0x14, 0xa7, 0x8e, 0x44, 0xe0, 0x29, 0xdd,
+	0xd2, 0xb7, 0xbd, 0x05, 0x4b, 0x05, 0x6f, 0x65, 0xae, 0x82, 0x9b, 0x71, 0x2d, 0x6e, 0x15, 0x8d,
+	0xdb, 0x0b, 0x68, 0x6e, 0x8b, 0x09, 0x47, 0x52, 0x1c, 0x1f, 0x26, 0x22, 0xcc, 0x05, 0xc5, 0x6f,
+	0xcc, 0x24, 0x99, 0x50, 0xfb, 0xae, 0x64, 0xc2, 0xde, 0x80, 0xd6, 0x5e, 0x1c, 0xf8, 0xd9, 0x75,
+	0x27, 0x5c, 0xa2, 0xa4, 0x8f, 0xa1, 0x71, 0x90, 0x16, 0xab, 0xce, 0xaa, 0xdc, 0xde, 0xd5, 0xf6,
+	0x78, 0xf3, 0xa3, 0x95, 0x73, 0xac, 0x68, 0x1c, 0xf7, 0xa1, 0xbd, 0x41, 0x07, 0x47, 0x56, 0x7c,
+	0x73, 0x0f, 0x56, 0xe1, 0x3a, 0x95, 0xab, 0x5c, 0xe7, 0x13, 0x68, 0x51, 0xef, 0xf5, 0xd2, 0x8d,
+	0xfc, 0x21, 0xb4, 0x0f, 0x22, 0x5e, 0x90, 0x7c, 0x0d, 0x40, 0x80, 0x3b, 0xb7, 0x89, 0xdb, 0xe2,
+	0xf9, 0xa7, 0xfd, 0x97, 0xaa, 0xfd, 0xfb, 0x41, 0x94, 0x30, 0xb3, 0x7c, 0xf5, 0xea, 0xe5, 0xff,
+	0xdb, 0x00, 0x28, 0x2b, 0x1c, 0x3c, 0x09, 0x58, 0xe3, 0x68, 0x29, 0x12, 0xc1, 0xbe, 0x77, 0xc3,
+	0x23, 0x72, 0x9d, 0xea, 0xef, 0x31, 0xb4, 0xc4, 0xfb, 0x48, 0x35, 0x0c, 0x6a, 0xd4, 0x30, 0x68,
+	0x12, 0xa2, 0xef, 0xa5, 0xd6, 0x17, 0xb0, 0x24, 0x07, 0xcb, 0xfc, 0x2b, 0x6f, 0x35, 0x1d, 0x42,
+	0x17, 0x6d, 0x9f, 0x35, 0x00, 0xaa, 0xcc, 0x28, 0x77, 0x5d, 0x2e, 0x3d, 0x46, 0x17, 0x3f, 0xbf,
+	0x9d, 0xe5, 0xd7, 0x48, 0x5f, 0xde, 0xcd, 0xec, 0xcf, 0x01, 0x76, 0xfd, 0xc1, 0xf1, 0x38, 0x9e,
+	0xab, 0x01, 0xdb, 0x81, 0x45, 0x49, 0x76, 0x73, 0x4b, 0x69, 0x3c, 0x2b, 0x53, 0x3c, 0x7f, 0x0a,
+	0x2d, 0xf4, 0x9a, 0x75, 0xd2, 0x99, 0xa6, 0x62, 0xe3, 0x62, 0x15, 0x57, 0xf4, 0x03, 0xf6, 0x0c,
+	0x16, 0xf7, 0x13, 0xe6, 0x71, 0x87, 0xff, 0x7a, 0xcc, 0xd3, 0xf9, 0xad, 0x38, 0x7b, 0x07, 0xda,
+	0x44, 0xdc, 0x8f, 0x26, 0x7e, 0xc6, 0xb1, 0xe0, 0xf7, 0xe9, 0x4b, 0x2f, 0xf8, 0x15, 0x86, 0xf2,
+	0xce, 0x62, 0x3e, 0xac, 0xe5, 0xf7, 0xb6, 0xc2, 0x61, 0x7f, 0xd4, 0xde, 0x28, 0x56, 0x4f, 0x63,
+	0x11, 0x79, 0x57, 0x71, 0xa4, 0xe6, 0xf2, 0x80, 0xc7, 0x99, 0x6a, 0x23, 0x29, 0x08, 0x83, 0x0d,
+	0xb1, 0xd9, 0x89, 0x39, 0xe5, 0xe1, 0x0c, 0x01, 0x2d, 0x0f, 0x13, 0xdc, 0x27, 0xf6, 0x31, 0x4b,
+	0xb2, 0x48, 0xb7, 0x60, 0x4b, 0x61, 0xfa, 0x9e, 0xfd, 0x06, 0x40, 0xb2, 0x19, 0x0e, 0x79, 0x62,
+	0x7d, 0x01, 0x35, 0xd4, 0x5c, 0x1e, 0x2c, 0x4d, 0x2d, 0x58, 0x92, 0xa6, 0x1d, 0x39, 0x7c, 0x61,
+	0xb4, 0x6c, 0x2b, 0x81, 0xb6, 0xb0, 0xcb, 0xd5, 0x55, 0x9b, 0x5c, 0x17, 0xd1, 0xd0, 0x4f, 0x42,
+	0xbb, 0xa3, 0xb4, 0xb8, 0x8e, 0x8f, 0x3f, 0x81, 0xfd, 0x0f, 0x06, 0x74, 0x08, 0xde, 0xf3, 0x51,
+	0xb3, 0x43, 0x31, 0x3f, 0x8b, 0x15, 0x62, 0x55, 0xae, 0x27, 0x96, 0x96, 0x12, 0x50, 0x7f, 0xaa,
+	0xcf, 0x25, 0x0b, 0x01, 0x05, 0xc9, 0x66, 0x01, 0x09, 0xa7, 0x1a, 0x51, 0x4d, 0xa7, 0x44, 0x60,
+	0x48, 0x24, 0xf9, 0x0e, 0x62, 0x8f, 0x65, 0x7c, 0x9e, 0x7e, 0xf1, 0x05, 0xc3, 0xf7, 0x78, 0x2e,
+	0x5b, 0x5e, 0x8c, 0x4f, 0xed, 0xce, 0x91, 0x24, 0xf6, 0x24, 0xd7, 0x42, 0x20, 0x52, 0xee, 0xcd,
+	0xe3, 0xfa, 0xf5, 0x54, 0xb3, 0xa2, 0xfb, 0xf2, 0x81, 0xce, 0x96, 0xa6, 0x3b, 0x34, 0x9c, 0x77,
+	0x31, 0xa6, 0xf5, 0x57, 0x9d, 0x29, 0xf7, 0x7e, 0xa2, 0xd6, 0xbd, 0xf1, 0xf9, 0xb3, 0x8f, 0xa1,
+	0xf5, 0x3d, 0x9e, 0x11, 0xb2, 0xd1, 0x43, 0x68, 0xd2, 0x81, 0xd1, 0xe4, 0x25, 0xb8, 0xef, 0x51,
+	0x7f, 0x23, 0x11, 0xa3, 0x84, 0xa7, 0x52, 0x11, 0x35, 0xa7, 0x80, 0xcb, 0x27, 0xb1, 0xea, 0xd4,
+	0x6a, 0xc4, 0x57, 0x7f, 0x12, 0xb3, 0xbf, 0x07, 0xc0, 0xdb, 0x01, 0x0d, 0xe0, 0xfd, 0xac, 0x4e,
+	0xdc, 0x67, 0x9d, 0xb1, 0x90, 0xc7, 0x51, 0xe3, 0x28, 0x97, 0x27, 0xe8, 0x06, 0x9c, 0x2f, 0xde,
+	0x40, 0x18, 0xbb, 0xa7, 0xdf, 0x41, 0x9b, 0xe8, 0x95, 0x1d, 0xbf, 0x80, 0x1a, 0xcd, 0x21, 0xf1,
+	0x2f, 0x62, 0x29, 0x87, 0xed, 0x65, 0x68, 0xaf, 0xd2, 0x31, 0xa3, 0x91, 0x39, 0x1b, 0xb7, 0xbf,
+	0x82, 0xc5, 0xd5, 0x43, 0x16, 0x79, 0x22, 0xba, 0x92, 0x74, 0x19, 0xda, 0xfb, 0xe3, 0x24, 0xea,
+	0x5f, 0x4d, 0xf9, 0x04, 0x1a, 0xf8, 0x70, 0xf4, 0x36, 0x1e, 0x60, 0x1f, 0x35, 0x8a, 0x07, 0x25,
+	0x4d, 0x2d, 0x8a, 0x07, 0x7d, 0xcf, 0xfe, 0x0b, 0xb5, 0xaf, 0x1f, 0x74, 0xb7, 0x2e, 0x96, 0xad,
+	0x4c, 0x1b, 0xb1, 0x5c, 0xab, 0xaa, 0xaf, 0x75, 0x0c, 0xad, 0x3f, 0x16, 0x11, 0xdf, 0x98, 0xa8,
+	0x67, 0x9a, 0x33, 0xa1, 0x57, 0x1b, 0xf5, 0x33, 0x52, 0xf5, 0x85, 0x15, 0xcd, 0x63, 0x68, 0x11,
+	0x31, 0xbd, 0x33, 0xca, 0x16, 0x67, 0x13, 0x11, 0xf8, 0xb6, 0x88, 0xb1, 0x99, 0x47, 0x19, 0x4f,
+	0xd4, 0xb9, 0x94, 0x80, 0xbd, 0x02, 0xed, 0x5d, 0x96, 0x64, 0xa7, 0x2a, 0xdc, 0xce, 0x0d, 0xcd,
+	0x7b, 0xb0, 0x54, 0xd2, 0x32, 0x7a, 0xdd, 0xfb, 0x20, 0xe1, 0x99, 0x98, 0xfe, 0x96, 0xe1, 0x79,
+	0x11, 0x80, 0xd8, 0x6c, 0x71, 0x36, 0xe1, 0xf6, 0x32, 0xb4, 0x08, 0xfa, 0xa5, 0x2f, 0x5b, 0x2e,
+	0x97, 0xb7, 0x7e, 0x9e, 0x43, 0x87, 0x28, 0xf7, 0x13, 0x16, 0xa5, 0xc3, 0xab, 0x1a, 0x45, 0xff,
+	0x63, 0x80, 0x49, 0xe4, 0xdb, 0x3c, 0x3c, 0x94, 0xef, 0x42, 0x7c, 0xee, 0x0c, 0x94, 0x57, 0x44,
+	0xf4, 0x98, 0xa6, 0xe4, 0x95, 0x50, 0x59, 0x28, 0x54, 0x2f, 0x2c, 0x14, 0xae, 0x6a, 0x8a, 0xe7,
+	0xd7, 0x96, 0xda, 0x9c, 0x6b, 0x8b, 0x5e, 0xa9, 0xd6, 0xa7, 0x2a, 0x55, 0xfb, 0xef, 0x0d, 0x58,
+	0xd2, 0xf6, 0x71, 0x75, 0x46, 0xb8, 0xc8, 0xdb, 0x8a, 0x46, 0x60, 0xf5, 0xaa, 0xff, 0x13, 0x7e,
+	0x9c, 0x47, 0x24, 0xb9, 0xad, 0x3c, 0xb8, 0xce, 0x6a, 0x32, 0x8f, 0x4b, 0x63, 0x65, 0xbd, 0x3c,
+	0x08, 0x62, 0xf6, 0x3c, 0xd5, 0x4b, 0x5e, 0x1a, 0xa4, 0xbb, 0x52, 0xc0, 0xd9, 0xf4, 0xbb, 0xa9,
+	0x44, 0xf4, 0x3d, 0xeb, 0x1b, 0x68, 0x84, 0xc4, 0x3a, 0xef, 0x92, 0xdf, 0x3f, 0xbf, 0x2a, 0x85,
+	0xa0, 0x9c, 0x0c, 0xef, 0xc7, 0x34, 0x46, 0xb2, 0xa4, 0x98, 0x12, 0x48, 0x9c, 0x3c, 0x1e, 0x5e,
+	0x2a, 0xb5, 0x22, 0xb3, 0x0f, 0xd4, 0xfc, 0x0f, 0xdc, 0xbc, 0x7b, 0x0e, 0x9d, 0xcd, 0xc4, 0xe7,
+	0xf8, 0xde, 0x76, 0x8d, 0xeb, 0xd3, 0x9f, 0x80, 0x29, 0xa9, 0xb5, 0x43, 0xfa, 0x29, 0x2c, 0x26,
+	0x72, 0xae, 0x6e, 0xdc, 0x76, 0x81, 0xa3, 0xde, 0x4e, 0xb7, 0x24, 0xd1, 0x2c, 0xdd, 0x29, 0xb0,
+	0x74, 0x58, 0xff, 0xa8, 0x94, 0x45, 0x9e, 0xd6, 0x6b, 0xb0, 0xbe, 0xec, 0xc4, 0x3e, 0x83, 0x45,
+	0x79, 0x19, 0x96, 0x1c, 0xe7, 0x1f, 0xbc, 0xff, 0x30, 0x00, 0xf2, 0x7d, 0xfd, 0xff, 0xfa, 0xea,
+	0xc5, 0xbf, 0xc9, 0x94, 0xc7, 0xb8, 0x36, 0x75, 0x8c, 0xe7, 0x9c, 0xb1, 0x9f, 0x43, 0x17, 0xb3,
+	0xab, 0x94, 0x9a, 0xfa, 0xf2, 0xcf, 0xa0, 0x31, 0x24, 0x28, 0x77, 0xa9, 0xdc, 0x25, 0xca, 0x9d,
+	0x39, 0x39, 0x85, 0xfd, 0x33, 0xe8, 0x4a, 0xf4, 0x6e, 0xc2, 0x53, 0x1e, 0x0d, 0x38, 0x3a, 0x94,
+	0x1c, 0x54, 0xd9, 0xf4, 0x82, 0xd9, 0x8a, 0x00, 0x1b, 0xc9, 0x85, 0x9d, 0x3e, 0xa0, 0x2f, 0xae,
+	0xbc, 0x87, 0xce, 0xd4, 0x0f, 0x2b, 0xd6, 0x12, 0xd6, 0x94, 0x69, 0xcc, 0x07, 0xfe, 0xd0, 0xe7,
+	0x9e, 0x79, 0xcb, 0xea, 0x02, 0xbc, 0x13, 0x49, 0xe0, 0xb9, 0xf8, 0xe2, 0x6c, 0x1a, 0x08, 0x4b,
+	0x25, 0xbb, 0xbb, 0x22, 0x35, 0x2b, 0xd6, 0xed, 0xfc, 0xcf, 0x27, 0x57, 0xfe, 0xfd, 0x61, 0x56,
+	0x91, 0x64, 0x75, 0x88, 0xfe, 0x83, 0xc5, 0xbf, 0xb9, 0x60, 0x59, 0xd0, 0xcd, 0xa7, 0xc8, 0x27,
+	0x15, 0xb3, 0xb6, 0x32, 0x82, 0xb6, 0x66, 0x2b, 0xe4, 0x42, 0x1f, 0xee, 0x41, 0x74, 0x1c, 0x89,
+	0xf7, 0x91, 0x79, 0xab, 0x44, 0xbd, 0x63, 0x49, 0xe2, 0x8b, 0x44, 0xae, 0x2d, 0x51, 0xdb, 0x6c,
+	0xc4, 0xcd, 0x8a, 0x65, 0xc2, 0xa2, 0x84, 0x57, 0x93, 0xc1, 0x11, 0x4f, 0xcc, 0x6a, 0x89, 0xd9,
+	0x4d, 0x7c, 0x9e, 0x66, 0xe6, 0xc2, 0xca, 0xbf, 0x18, 0xaa, 0x8c, 0xa6, 0x16, 0xcc, 0x1d, 0x58,
+	0x22, 0xc0, 0x45, 0xc8, 0x7d, 0x2b, 0x22, 0x6e, 0xde, 0xb2, 0xee, 0xc1, 0x6d, 0x0d, 0xf9, 0x8e,
+	0xb3, 0x58, 0x44, 0xa6, 0x31, 0x43, 0xfb, 0x86, 0x33, 0xcf, 0xac, 0x58, 0x77, 0xc1, 0xd4, 0x90,
+	0xeb, 0x47, 0xb8, 0x48, 0x75, 0x86, 0x74, 0x8b, 0x8f, 0x52, 0x73, 0x61, 0x06, 0xb9, 0xc9, 0x79,
+	0x66, 0xd6, 0xac, 0x1e, 0xdc, 0xd5, 0x90, 0x78, 0x47, 0x4a, 0x53, 0x91, 0x9c, 0x9a, 0xf5, 0x95,
+	0x5d, 0x80, 0xf2, 0x27, 0x27, 0x5c, 0x87, 0x20, 0x17, 0x2d, 0xe3, 0xee, 0x65, 0x2c, 0xc9, 0xa4,
+	0xa4, 0x1a, 0x76, 0xd3, 0x8f, 0xfc, 0xf4, 0xc8, 0x34, 0x66, 0xd0, 0xb2, 0x44, 0x30, 0x2b, 0x2b,
+	0xff, 0xa5, 0x7e, 0x4c, 0x50, 0xff, 0x1c, 0x58, 0xf7, 0xc1, 0x42, 0xd0, 0x55, 0xb0, 0x4b, 0x76,
+	0x35, 0x6f, 0x59, 0x0f, 0xe0, 0xce, 0x14, 0xfe, 0x2d, 0x67, 0xc9, 0xe1, 0xa9, 0x69, 0x9c, 0x9b,
+	0xb0, 0x87, 0x27, 0xc1, 0xac, 0x9c, 0xc3, 0x53, 0x94, 0x34, 0xab, 0xe7, 0xf0, 0xaf, 0xc7, 0x7e,
+	0xe0, 0x99, 0x0b, 0xb8, 0xe9, 0xe9, 0x85, 0xe5, 0x0f, 0x0a, 0x66, 0xed, 0xdc, 0xd2, 0x7b, 0xa7,
+	0x69, 0xc6, 0x43, 0xb3, 0xbe, 0x32, 0x28, 0x7e, 0xf7, 0x90, 0xff, 0x6a, 0xe0, 0x1e, 0x15, 0xc2,
+	0x7d, 0xc5, 0x03, 0x7f, 0xc2, 0x13, 0x72, 0xcf, 0x3b, 0xb0, 0x94, 0xa3, 0x77, 0xe4, 0x5f, 0x2d,
+	0xa6, 0xa1, 0x23, 0xd7, 0x64, 0xb5, 0x22, 0x1d, 0x35, 0x47, 0x6e, 0x8f, 0x33, 0xee, 0x99, 0xd5,
+	0x95, 0x7f, 0x5a, 0x04, 0x28, 0x4f, 0x8c, 0xd5, 0x81, 0x96, 0x84, 0xdc, 0x9d, 0x63, 0xe9, 0x80,
+	0x0a, 0xdc, 0x64, 0x7e, 0xc0, 0x3d, 0xd3, 0x40, 0xab, 0x28, 0xd4, 0x5b, 0xb4, 0x33, 0xbe, 0x0a,
+	0x9a, 0x15, 0xeb, 0x21, 0xdc, 0x53, 0x58, 0xf9, 0x9e, 0xea, 0x62, 0x7d, 0xe1, 0x47, 0x23, 0xb3,
+	0x6a, 0x3d, 0x82, 0xfb, 0x6a, 0x68, 0x55, 0x3e, 0x64, 0xba, 0xfd, 0x68, 0xc2, 0x02, 0x1f, 0xb5,
+	0xf2, 0x00, 0xee, 0xe4, 0xcc, 0x58, 0xc8, 0x8b, 0x81, 0x9a, 0xc6, 0x8f, 0x06, 0x5e, 0x8d, 0xe3,
+	0xc0, 0x1f, 0xb0, 0x8c, 0x9b, 0x75, 0x8d, 0x5f, 0xf1, 0xb2, 0xe5, 0x6e, 0xf9, 0xa1, 0x9f, 0x99,
+	0x0d, 0xeb, 0x47, 0xf0, 0xe8, 0xdc, 0x18, 0x8a, 0xb9, 0x89, 0x2d, 0x16, 0xb3, 0x69, 0x3d, 0x86,
+	0x07, 0xe7, 0xc6, 0x77, 0x28, 0xea, 0x99, 0x2d, 0x6d, 0x70, 0x5f, 0x06, 0x8b, 0x72, 0x26, 0x68,
+	0x92, 0xee, 0x8c, 0x33, 0x77, 0x67, 0xe8, 0x3a, 0xd8, 0x19, 0x34, 0xdb, 0x18, 0x2d, 0xd4, 0xc0,
+	0x2b, 0x3c, 0x1e, 0x8b, 0xe8, 0x01, 0xd3, 0x6c, 0x08, 0xdf, 0x41, 0x8b, 0xe4, 0x6b, 0x0b, 0x11,
+	0xe0, 0x73, 0xb2, 0xd9, 0xd5, 0x36, 0x23, 0xbd, 0xb7, 0x5c, 0x72, 0x09, 0x5d, 0x46, 0xd3, 0xf4,
+	0x46, 0x24, 0xc6, 0xa3, 0x23, 0x77, 0x7b, 0xd7, 0x34, 0xb5, 0x35, 0xd7, 0xc6, 0xe9, 0xa9, 0x79,
+	0x5b, 0xe3, 0xfd, 0x56, 0xa8, 0x05, 0x2d, 0x8d, 0x37, 0x75, 0xdd, 0x35, 0xde, 0x77, 0xb4, 0x09,
+	0x6b, 0x6c, 0xe4, 0x6e, 0x8e, 0x83, 0xc0, 0xbc, 0xab, 0x29, 0x1d, 0xab, 0x62, 0x8d, 0xfe, 0x9e,
+	0xc6, 0xab, 0x18, 0x92, 0x02, 0x99, 0xf7, 0x35, 0xd5, 0xd0, 0x81, 0xce, 0x8d, 0xf8, 0x60, 0x76,
+	0xd2, 0x3a, 0x8b, 0x22, 0x91, 0xb9, 0x07, 0x29, 0x37, 0x7b, 0xda, 0x24, 0x85, 0xa6, 0x90, 0x60,
+	0x3e, 0x9c, 0xf1, 0xaf, 0x1d, 0xec, 0x37, 0x99, 0x8f, 0x34, 0x56, 0xaf, 0x45, 0xe0, 0xe9, 0xeb,
+	0x3f, 0xb6, 0x3e, 0x82, 0xde, 0x05, 0xcb, 0x50, 0xb1, 0x6a, 0x7e, 0x74, 0xde, 0x1c, 0xa4, 0xb2,
+	0x8f, 0x75, 0xd7, 0x23, 0xa1, 0xd5, 0x84, 0x1f, 0xe9, 0x6e, 0x80, 0x18, 0xe5, 0xe6, 0x74, 0x82,
+	0x3e, 0xd1, 0xe4, 0xa0, 0xd2, 0x49, 0xd3, 0xd1, 0x13, 0x4d, 0x0e, 0x39, 0x76, 0x10, 0xb1, 0x09,
+	0xf3, 0x03, 0x76, 0x18, 0x70, 0xf3, 0xd3, 0x0b, 0x67, 0x3a, 0x9c, 0x79, 0xa7, 0xa6, 0x3d, 0xed,
+	0xb6, 0x14, 0x04, 0xf4, 0xb9, 0x9f, 0x69, 0x9e, 0x40, 0x91, 0x62, 0x5f, 0x08, 0x77, 0x93, 0xa5,
+	0x99, 0xf9, 0x54, 0x33, 0x19, 0x8d, 0x94, 0xe7, 0xe4, 0x73, 0x8c, 0x16, 0xfa, 0x90, 0x3c, 0xf0,
+	0x5f, 0x4c, 0x1f, 0x01, 0x34, 0x7f, 0x14, 0x71, 0x0f, 0xe3, 0xa0, 0x67, 0x7e, 0x79, 0xd1, 0x42,
+	0x5b, 0x22, 0x1a, 0x99, 0xcb, 0x98, 0xc0, 0x72, 0x87, 0x51, 0xe1, 0xe4, 0x2b, 0x6d, 0x4b, 0x84,
+	0x73, 0xf1, 0x42, 0x20, 0x7d, 0x69, 0xc5, 0xfa, 0x18, 0x1e, 0xe6, 0x06, 0x0b, 0xf3, 0x8c, 0xe6,
+	0xaa, 0x9f, 0xac, 0xcc, 0x67, 0x9a, 0x55, 0x5e, 0x87, 0xee, 0x1a, 0xf3, 0xdc, 0xd5, 0x64, 0x94,
+	0x9a, 0xcf, 0x35, 0xe9, 0x5e, 0xa3, 0x97, 0xb9, 0xbb, 0x3c, 0x09, 0xfd, 0x34, 0xc5, 0xa4, 0xfa,
+	0x63, 0x6d, 0x50, 0xe6, 0x59, 0x4d, 0xfb, 0x2f, 0xa6, 0xc2, 0x0c, 0x0d, 0x16, 0x5a, 0xfa, 0x5a,
+	0x53, 0x05, 0x85, 0x69, 0x29, 0xe3, 0x37, 0xe7, 0x5d, 0x40, 0x06, 0xf1, 0xdf, 0xd1, 0x16, 0xc2,
+	0x01, 0x39, 0x67, 0x8b, 0x2e, 0xe1, 0xe6, 0x4b, 0x5d, 0x8a, 0x20, 0x41, 0x03, 0x96, 0x33, 0xbf,
+	0xd5, 0x5d, 0x9e, 0xca, 0x3b, 0xf7, 0x15, 0x1f, 0x60, 0x78, 0xf1, 0xcc, 0xdf, 0xd5, 0x26, 0xca,
+	0x7b, 0x8c, 0xa6, 0xaf, 0xef, 0x34, 0x43, 0xe6, 0x5c, 0x25, 0x91, 0xf9, 0x7b, 0x9a, 0xf4, 0xb4,
+	0x5f, 0x89, 0xfe, 0x7d, 0x4d, 0x7a, 0x0a, 0xc2, 0x79, 0x84, 0xfe, 0x89, 0xce, 0x4a, 0x05, 0xdc,
+	0x8d, 0x13, 0x3f, 0xcd, 0x52, 0xf3, 0x0f, 0x34, 0x11, 0x76, 0xd5, 0x9f, 0x23, 0xc5, 0x71, 0xfd,
+	0xa9, 0x36, 0x0f, 0x4b, 0x75, 0xd4, 0xbe, 0xac, 0xd1, 0xcc, 0x9f, 0xad, 0x78, 0xd0, 0x2a, 0xde,
+	0x8f, 0xd0, 0x76, 0x1b, 0x27, 0xb1, 0x2b, 0x21, 0xed, 0xb6, 0x82, 0xc9, 0xbe, 0xc4, 0xff, 0xd2,
+	0x0f, 0x02, 0x99, 0x2e, 0x34, 0x24, 0xb9, 0xbe, 0x59, 0x99, 0xc1, 0xaa, 0xdc, 0xb9, 0xf2, 0x1b,
+	0x03, 0xcc, 0xd9, 0xe6, 0x14, 0x5e, 0x78, 0x08, 0xe7, 0xbe, 0x92, 0x37, 0x95, 0x3b, 0xb0, 0x24,
+	0x61, 0x99, 0xe3, 0x8b, 0xa4, 0xa4, 0x88, 0xfc, 0x74, 0x20, 0xa2, 0x88, 0x0f, 0x32, 0x99, 0xa3,
+	0x25, 0x76, 0x2a, 0x64, 0x57, 0x51, 0x73, 0x0a, 0x8f, 0x0d, 0x4b, 0x57, 0x3e, 0xf2, 0x60, 0x3a,
+	0x32, 0x55, 0xc7, 0x31, 0xd7, 0x65, 0x6d, 0x25, 0x00, 0x28, 0x7b, 0x4c, 0xc8, 0x90, 0x20, 0x97,
+	0x40, 0x72, 0xb4, 0x89, 0xba, 0x3d, 0xe9, 0x78, 0x79, 0xac, 0x49, 0x2a, 0x1d, 0x4d, 0x1b, 0xa0,
+	0x54, 0x39, 0xc5, 0x44, 0x76, 0x7e, 0x30, 0x19, 0x1f, 0xd6, 0xe9, 0x17, 0xfe, 0x6f, 0xff, 0x6f,
+	0x00, 0x7e, 0x89, 0x06, 0xfd, 0xd3, 0x2f, 0x00, 0x00,
 }
@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"aoi_mmo_game/api"
 	"aoi_mmo_game/core"
@@ -12,6 +15,72 @@ import (
 )
 
 func main() {
+	// 加载全服存档
+	if err := core.InitServerData(); err != nil {
+		fmt.Println("init server data err: ", err)
+		return
+	}
+
+	// 加载等级和职业成长
+	if err := core.LoadLevels(); err != nil {
+		fmt.Println("load levels err: ", err)
+		return
+	}
+
+	// 加载buff，技能数据会引用buff
+	if err := core.LoadBuffs(); err != nil {
+		fmt.Println("load buffs err: ", err)
+		return
+	}
+
+	// 加载物品，物品数据会引用buff
+	if err := core.LoadItems(); err != nil {
+		fmt.Println("load items err: ", err)
+		return
+	}
+
+	// 加载技能
+	if err := core.LoadSkills(); err != nil {
+		fmt.Println("load skills err: ", err)
+		return
+	}
+
+	// 加载场景
+	if err := core.LoadScenes(); err != nil {
+		fmt.Println("load scenes err: ", err)
+		return
+	}
+
+	// 生成怪物
+	if err := core.LoadMonsters(); err != nil {
+		fmt.Println("load monsters err: ", err)
+		return
+	}
+
+	// 读取表情动作
+	if err := core.LoadEmotes(); err != nil {
+		fmt.Println("load emotes err: ", err)
+		return
+	}
+
+	// 读取聊天限制
+	if err := core.LoadChat(); err != nil {
+		fmt.Println("load chat err: ", err)
+		return
+	}
+
+	// 读取GM账号
+	if err := core.LoadGm(); err != nil {
+		fmt.Println("load gm err: ", err)
+		return
+	}
+
+	// 读取任务
+	if err := core.LoadQuests(); err != nil {
+		fmt.Println("load quests err: ", err)
+		return
+	}
+
 	// 创建服务
 	s := znet.NewServer()
 
@@ -21,51 +90,109 @@ func main() {
 	s.SetOnConnStop(onConnectionLost)
 
 	// 注册聊天路由
-	s.AddRouter(mmopb.CSMsgIdTalk, &api.WorldChatRouter{})
+	s.AddRouter(mmopb.CSMsgIdTalk, &api.ChatRouter{})
 	// 移动路由
 	s.AddRouter(mmopb.CSMsgIdMove, &api.PlayerMoveRouter{})
+	// 心跳路由
+	s.AddRouter(mmopb.CSMsgIdPong, &api.PongRouter{})
+	// 登录和角色路由
+	s.AddRouter(mmopb.CSMsgIdLogin, &api.LoginRouter{})
+	s.AddRouter(mmopb.CSMsgIdRegister, &api.RegisterRouter{})
+	s.AddRouter(mmopb.CSMsgIdCharacterList, &api.CharacterListRouter{})
+	s.AddRouter(mmopb.CSMsgIdCreateCharacter, &api.CreateCharacterRouter{})
+	s.AddRouter(mmopb.CSMsgIdSelectCharacter, &api.SelectCharacterRouter{})
+	// 战斗路由
+	s.AddRouter(mmopb.CSMsgIdAttack, &api.AttackRouter{})
+	s.AddRouter(mmopb.CSMsgIdCastSkill, &api.CastSkillRouter{})
+	s.AddRouter(mmopb.CSMsgIdRespawn, &api.RespawnRouter{})
+	// 背包路由
+	s.AddRouter(mmopb.CSMsgIdMoveItem, &api.MoveItemRouter{})
+	s.AddRouter(mmopb.CSMsgIdSplitItem, &api.SplitItemRouter{})
+	s.AddRouter(mmopb.CSMsgIdUseItem, &api.UseItemRouter{})
+	s.AddRouter(mmopb.CSMsgIdEquipItem, &api.EquipItemRouter{})
+	s.AddRouter(mmopb.CSMsgIdUnequipItem, &api.UnequipItemRouter{})
+	s.AddRouter(mmopb.CSMsgIdPickupLoot, &api.PickupLootRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeRequest, &api.TradeRequestRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeRespond, &api.TradeRespondRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeOffer, &api.TradeOfferRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeLock, &api.TradeLockRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeConfirm, &api.TradeConfirmRouter{})
+	s.AddRouter(mmopb.CSMsgIdTradeCancel, &api.TradeCancelRouter{})
+	s.AddRouter(mmopb.CSMsgIdAcceptQuest, &api.AcceptQuestRouter{})
+	s.AddRouter(mmopb.CSMsgIdAbandonQuest, &api.AbandonQuestRouter{})
+	s.AddRouter(mmopb.CSMsgIdTurnInQuest, &api.TurnInQuestRouter{})
+	s.AddRouter(mmopb.CSMsgIdTalkNpc, &api.TalkNpcRouter{})
+	s.AddRouter(mmopb.CSMsgIdBlockPlayer, &api.BlockPlayerRouter{})
+	s.AddRouter(mmopb.CSMsgIdWhisperHistory, &api.WhisperHistoryRouter{})
+	s.AddRouter(mmopb.CSMsgIdPlayAction, &api.PlayActionRouter{})
+	s.AddRouter(mmopb.CSMsgIdPartyInvite, &api.PartyInviteRouter{})
+	s.AddRouter(mmopb.CSMsgIdPartyRespond, &api.PartyRespondRouter{})
+	s.AddRouter(mmopb.CSMsgIdPartyLeave, &api.PartyLeaveRouter{})
+	s.AddRouter(mmopb.CSMsgIdPartyKick, &api.PartyKickRouter{})
+	s.AddRouter(mmopb.CSMsgIdPartyTransfer, &api.PartyTransferRouter{})
+	s.AddRouter(mmopb.CSMsgIdFriendRequest, &api.FriendRequestRouter{})
+	s.AddRouter(mmopb.CSMsgIdFriendRespond, &api.FriendRespondRouter{})
+	s.AddRouter(mmopb.CSMsgIdRemoveFriend, &api.RemoveFriendRouter{})
+
+	// 开启心跳检测
+	core.SessionMgrObj.StartHeartbeat()
+	// 开启场景心跳
+	core.WorldMgrObj.StartTick()
+	core.StartPartySync()
 
 	// 开启服务
-	s.Serve()
+	s.Start()
+
+	// 等待停服信号
+	waitForShutdown(s)
+}
+
+// waitForShutdown 阻塞等待停服信号，收到后执行优雅停服
+func waitForShutdown(s ziface.IServer) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigChan
+	fmt.Println("======> receive signal ", sig, " <======")
+
+	// 倒计时期间再次收到信号则立即停服
+	go func() {
+		<-sigChan
+		fmt.Println("======> receive signal again, shutdown now <======")
+		core.WorldMgrObj.SaveAllPlayers()
+		os.Exit(1)
+	}()
+
+	core.Shutdown(core.SHUTDOWN_COUNTDOWN, "服务器维护")
+	s.Stop()
 }
 
 // onConnectionLost 客户端断开连接
 func onConnectionLost(conn ziface.IConnection) {
-	// 获得断线的玩家id
-	playerId, err := conn.GetProperty("playerId")
-	if err != nil || playerId.(int32) <= 0 {
-		fmt.Println("conn property playerId not exist")
-		conn.Stop()
+	// 同一个连接的关闭回调可能执行多次，只处理第一次
+	session := core.SessionMgrObj.GetSession(conn.GetConnID())
+	if session == nil || !session.MarkStopped() {
 		return
 	}
 
-	// 根据pid获取对应的玩家对象
-	player := core.WorldMgrObj.GetPlayerById(playerId.(int32))
-
-	// 触发玩家下线业务
-	if player != nil {
-		player.LostConnection()
+	// 移除连接会话
+	core.SessionMgrObj.RemoveSession(conn.GetConnID())
 
-		fmt.Println("======> player id = ", player.PlayerId, " left <======")
-	}
+	// 触发玩家下线业务，保存完玩家数据后再释放角色和账号，
+	// 正在进入世界的角色由选择角色的流程在进入完成后下线
+	session.Leave()
 }
 
 // onConnectionAdd 当客户端建立连接时当hook函数
 func onConnectionAdd(conn ziface.IConnection) {
-	player := core.NewPlayer(conn)
-	// 同步当前playerId给客户端，走msgId:1消息
-	player.SyncPlayerId()
-	// 同步当前玩家的初始化坐标信息给客户端，走msgId:200消息
-	player.BroadCastStartPosition()
-
-	// 添加到世界管理器
-	core.WorldMgrObj.AddPlayer(player)
-
-	// 绑定连接和playerId
-	conn.SetProperty("playerId", player.PlayerId)
+	// 停服期间不再接受登录
+	if core.IsShuttingDown() {
+		fmt.Println("server is shutting down, refuse conn id = ", conn.GetConnID())
+		conn.Stop()
+		return
+	}
 
-	// 同步周围玩家信息
-	player.SyncSurrounding()
+	// 创建连接会话，等待客户端登录并选择角色
+	core.SessionMgrObj.AddSession(conn)
 
-	fmt.Println("======> player id = ", player.PlayerId, " arrived <======")
+	fmt.Println("======> conn id = ", conn.GetConnID(), " arrived <======")
 }